@@ -4,26 +4,44 @@
 package bspwmdesktop
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"unicode"
 
 	"github.com/diogox/bspc-go"
 
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
 	"github.com/diogox/bspm/internal/bspwm/filter"
 )
 
+// ErrInvalidName is returned for desktop names bspwm can't be sent, since its commands are split on whitespace.
+var ErrInvalidName = errors.New("desktop name can't be empty or have whitespace")
+
 type (
 	Service interface {
 		Get(filter filter.DesktopFilter) (bspc.Desktop, error)
 		SetLayout(filter filter.DesktopFilter, layout bspc.LayoutType) error
+		// Add adds a desktop with the given name after the monitor's last one.
+		Add(monitorID bspc.ID, name string) error
+		// Remove removes the desktop, moving its windows to another one on the same monitor.
+		Remove(id bspc.ID) error
+		Rename(id bspc.ID, name string) error
+		// Reorder puts the monitor's desktops in the order of the given names. Desktops sharing a name are put in
+		// the order they already are in.
+		Reorder(monitorID bspc.ID, names []string) error
 	}
 	service struct {
 		client bspc.Client
+		echoes *bspwmevent.Echoes
 	}
 )
 
-func NewService(client bspc.Client) Service {
+// NewService returns a service that registers the events its commands cause as echoes.
+func NewService(client bspc.Client, echoes *bspwmevent.Echoes) Service {
 	return service{
 		client: client,
+		echoes: echoes,
 	}
 }
 
@@ -45,9 +63,97 @@ func (s service) SetLayout(filter filter.DesktopFilter, layout bspc.LayoutType)
 
 	cmd := fmt.Sprintf(descriptor, filter, layout)
 
+	// The filter isn't necessarily a desktop id, so the echo can only be told apart by its layout.
+	withdraw := bspwmevent.ExpectEcho(s.echoes, func(payload bspc.EventDesktopLayout) bool {
+		return payload.DesktopLayout == layout
+	})
+
 	if err := s.client.Query(cmd, nil); err != nil {
+		withdraw()
 		return fmt.Errorf("failed to set desktop layout: %w", err)
 	}
 
 	return nil
 }
+
+func (s service) Add(monitorID bspc.ID, name string) error {
+	const descriptor = "monitor %d -a %s"
+
+	if !isValidName(name) {
+		return ErrInvalidName
+	}
+
+	cmd := fmt.Sprintf(descriptor, monitorID, name)
+
+	withdraw := bspwmevent.ExpectEcho(s.echoes, func(payload bspc.EventDesktopAdd) bool {
+		return payload.MonitorID == monitorID && payload.DesktopName == name
+	})
+
+	if err := s.client.Query(cmd, nil); err != nil {
+		withdraw()
+		return fmt.Errorf("failed to add desktop: %w", err)
+	}
+
+	return nil
+}
+
+func (s service) Remove(id bspc.ID) error {
+	const descriptor = "desktop %d -r"
+
+	cmd := fmt.Sprintf(descriptor, id)
+
+	withdraw := bspwmevent.ExpectEcho(s.echoes, func(payload bspc.EventDesktopRemove) bool {
+		return payload.DesktopID == id
+	})
+
+	if err := s.client.Query(cmd, nil); err != nil {
+		withdraw()
+		return fmt.Errorf("failed to remove desktop: %w", err)
+	}
+
+	return nil
+}
+
+func (s service) Rename(id bspc.ID, name string) error {
+	const descriptor = "desktop %d -n %s"
+
+	if !isValidName(name) {
+		return ErrInvalidName
+	}
+
+	cmd := fmt.Sprintf(descriptor, id, name)
+
+	withdraw := bspwmevent.ExpectEcho(s.echoes, func(payload bspc.EventDesktopRename) bool {
+		return payload.DesktopID == id && payload.DesktopNewName == name
+	})
+
+	if err := s.client.Query(cmd, nil); err != nil {
+		withdraw()
+		return fmt.Errorf("failed to rename desktop: %w", err)
+	}
+
+	return nil
+}
+
+// Reorder doesn't expect any echoes, since which desktops bspwm swaps to get them in order can't be told beforehand.
+func (s service) Reorder(monitorID bspc.ID, names []string) error {
+	const descriptor = "monitor %d -o %s"
+
+	for _, name := range names {
+		if !isValidName(name) {
+			return ErrInvalidName
+		}
+	}
+
+	cmd := fmt.Sprintf(descriptor, monitorID, strings.Join(names, " "))
+
+	if err := s.client.Query(cmd, nil); err != nil {
+		return fmt.Errorf("failed to reorder desktops: %w", err)
+	}
+
+	return nil
+}
+
+func isValidName(name string) bool {
+	return name != "" && strings.IndexFunc(name, unicode.IsSpace) < 0
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/diogox/bspc-go/bspctest"
 
 	bspwmdesktop "github.com/diogox/bspm/internal/bspwm/desktop"
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
 	"github.com/diogox/bspm/internal/bspwm/filter"
 )
 
@@ -35,7 +36,7 @@ func TestService_Get(t *testing.T) {
 			Query(buildQuery(filter.DesktopFocused), bspctest.QueryResponse(t, want)).
 			Return(nil)
 
-		s := bspwmdesktop.NewService(mockClient)
+		s := bspwmdesktop.NewService(mockClient, bspwmevent.NewEchoes())
 
 		got, err := s.Get(filter.DesktopFocused)
 		require.NoError(t, err)
@@ -53,7 +54,7 @@ func TestService_Get(t *testing.T) {
 			Query(gomock.Any(), gomock.Any()).
 			Return(expectedErr)
 
-		s := bspwmdesktop.NewService(mockClient)
+		s := bspwmdesktop.NewService(mockClient, bspwmevent.NewEchoes())
 
 		_, err := s.Get(filter.DesktopFocused)
 		require.Error(t, err)
@@ -61,3 +62,241 @@ func TestService_Get(t *testing.T) {
 		assert.True(t, errors.Is(err, expectedErr))
 	})
 }
+
+func TestService_SetLayout(t *testing.T) {
+	t.Run("should set layout and expect its echo", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockClient := bspwmdesktop.NewMockClient(ctrl)
+		mockClient.EXPECT().
+			Query("desktop focused -l monocle", nil).
+			Return(nil)
+
+		echoes := bspwmevent.NewEchoes()
+
+		err := bspwmdesktop.NewService(mockClient, echoes).SetLayout(filter.DesktopFocused, bspc.LayoutTypeMonocle)
+		require.NoError(t, err)
+
+		assert.True(t, echoes.IsEcho(bspc.EventTypeDesktopLayout, bspc.EventDesktopLayout{
+			DesktopID:     bspc.ID(1),
+			DesktopLayout: bspc.LayoutTypeMonocle,
+		}))
+		assert.False(t, echoes.IsEcho(bspc.EventTypeDesktopLayout, bspc.EventDesktopLayout{
+			DesktopID:     bspc.ID(1),
+			DesktopLayout: bspc.LayoutTypeTiled,
+		}))
+	})
+	t.Run("should not expect echo when bspc returns error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+
+		mockClient := bspwmdesktop.NewMockClient(ctrl)
+		mockClient.EXPECT().
+			Query(gomock.Any(), gomock.Any()).
+			Return(expectedErr)
+
+		echoes := bspwmevent.NewEchoes()
+
+		err := bspwmdesktop.NewService(mockClient, echoes).SetLayout(filter.DesktopFocused, bspc.LayoutTypeMonocle)
+		require.Error(t, err)
+
+		assert.True(t, errors.Is(err, expectedErr))
+		assert.False(t, echoes.IsEcho(bspc.EventTypeDesktopLayout, bspc.EventDesktopLayout{
+			DesktopLayout: bspc.LayoutTypeMonocle,
+		}))
+	})
+}
+
+func TestService_Add(t *testing.T) {
+	t.Run("should add desktop and expect its echo", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockClient := bspwmdesktop.NewMockClient(ctrl)
+		mockClient.EXPECT().
+			Query("monitor 1 -a 4", nil).
+			Return(nil)
+
+		echoes := bspwmevent.NewEchoes()
+
+		err := bspwmdesktop.NewService(mockClient, echoes).Add(bspc.ID(1), "4")
+		require.NoError(t, err)
+
+		assert.True(t, echoes.IsEcho(bspc.EventTypeDesktopAdd, bspc.EventDesktopAdd{
+			MonitorID:   bspc.ID(1),
+			DesktopID:   bspc.ID(2),
+			DesktopName: "4",
+		}))
+	})
+	t.Run("should not add desktop with invalid name", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		s := bspwmdesktop.NewService(bspwmdesktop.NewMockClient(ctrl), bspwmevent.NewEchoes())
+
+		err := s.Add(bspc.ID(1), "web browser")
+		require.Error(t, err)
+
+		assert.True(t, errors.Is(err, bspwmdesktop.ErrInvalidName))
+	})
+	t.Run("should not expect echo when bspc returns error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+
+		mockClient := bspwmdesktop.NewMockClient(ctrl)
+		mockClient.EXPECT().
+			Query(gomock.Any(), gomock.Any()).
+			Return(expectedErr)
+
+		echoes := bspwmevent.NewEchoes()
+
+		err := bspwmdesktop.NewService(mockClient, echoes).Add(bspc.ID(1), "4")
+		require.Error(t, err)
+
+		assert.True(t, errors.Is(err, expectedErr))
+		assert.False(t, echoes.IsEcho(bspc.EventTypeDesktopAdd, bspc.EventDesktopAdd{
+			MonitorID:   bspc.ID(1),
+			DesktopName: "4",
+		}))
+	})
+}
+
+func TestService_Remove(t *testing.T) {
+	t.Run("should remove desktop and expect its echo", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockClient := bspwmdesktop.NewMockClient(ctrl)
+		mockClient.EXPECT().
+			Query("desktop 2 -r", nil).
+			Return(nil)
+
+		echoes := bspwmevent.NewEchoes()
+
+		err := bspwmdesktop.NewService(mockClient, echoes).Remove(bspc.ID(2))
+		require.NoError(t, err)
+
+		assert.True(t, echoes.IsEcho(bspc.EventTypeDesktopRemove, bspc.EventDesktopRemove{
+			MonitorID: bspc.ID(1),
+			DesktopID: bspc.ID(2),
+		}))
+		assert.False(t, echoes.IsEcho(bspc.EventTypeDesktopRemove, bspc.EventDesktopRemove{
+			MonitorID: bspc.ID(1),
+			DesktopID: bspc.ID(3),
+		}))
+	})
+	t.Run("should return error when bspc returns error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+
+		mockClient := bspwmdesktop.NewMockClient(ctrl)
+		mockClient.EXPECT().
+			Query(gomock.Any(), gomock.Any()).
+			Return(expectedErr)
+
+		err := bspwmdesktop.NewService(mockClient, bspwmevent.NewEchoes()).Remove(bspc.ID(2))
+		require.Error(t, err)
+
+		assert.True(t, errors.Is(err, expectedErr))
+	})
+}
+
+func TestService_Rename(t *testing.T) {
+	t.Run("should rename desktop and expect its echo", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockClient := bspwmdesktop.NewMockClient(ctrl)
+		mockClient.EXPECT().
+			Query("desktop 2 -n web", nil).
+			Return(nil)
+
+		echoes := bspwmevent.NewEchoes()
+
+		err := bspwmdesktop.NewService(mockClient, echoes).Rename(bspc.ID(2), "web")
+		require.NoError(t, err)
+
+		assert.True(t, echoes.IsEcho(bspc.EventTypeDesktopRename, bspc.EventDesktopRename{
+			MonitorID:      bspc.ID(1),
+			DesktopID:      bspc.ID(2),
+			DesktopOldName: "2",
+			DesktopNewName: "web",
+		}))
+	})
+	t.Run("should not rename desktop with invalid name", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		s := bspwmdesktop.NewService(bspwmdesktop.NewMockClient(ctrl), bspwmevent.NewEchoes())
+
+		err := s.Rename(bspc.ID(2), "")
+		require.Error(t, err)
+
+		assert.True(t, errors.Is(err, bspwmdesktop.ErrInvalidName))
+	})
+	t.Run("should return error when bspc returns error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+
+		mockClient := bspwmdesktop.NewMockClient(ctrl)
+		mockClient.EXPECT().
+			Query(gomock.Any(), gomock.Any()).
+			Return(expectedErr)
+
+		err := bspwmdesktop.NewService(mockClient, bspwmevent.NewEchoes()).Rename(bspc.ID(2), "web")
+		require.Error(t, err)
+
+		assert.True(t, errors.Is(err, expectedErr))
+	})
+}
+
+func TestService_Reorder(t *testing.T) {
+	t.Run("should reorder desktops", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockClient := bspwmdesktop.NewMockClient(ctrl)
+		mockClient.EXPECT().
+			Query("monitor 1 -o 2 1 3", nil).
+			Return(nil)
+
+		err := bspwmdesktop.NewService(mockClient, bspwmevent.NewEchoes()).Reorder(bspc.ID(1), []string{"2", "1", "3"})
+		require.NoError(t, err)
+	})
+	t.Run("should not reorder desktops with invalid names", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		s := bspwmdesktop.NewService(bspwmdesktop.NewMockClient(ctrl), bspwmevent.NewEchoes())
+
+		err := s.Reorder(bspc.ID(1), []string{"1", "web browser"})
+		require.Error(t, err)
+
+		assert.True(t, errors.Is(err, bspwmdesktop.ErrInvalidName))
+	})
+	t.Run("should return error when bspc returns error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+
+		mockClient := bspwmdesktop.NewMockClient(ctrl)
+		mockClient.EXPECT().
+			Query(gomock.Any(), gomock.Any()).
+			Return(expectedErr)
+
+		err := bspwmdesktop.NewService(mockClient, bspwmevent.NewEchoes()).Reorder(bspc.ID(1), []string{"1"})
+		require.Error(t, err)
+
+		assert.True(t, errors.Is(err, expectedErr))
+	})
+}
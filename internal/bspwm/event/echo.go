@@ -0,0 +1,136 @@
+package bspwmevent
+
+import (
+	"sync"
+	"time"
+
+	"github.com/diogox/bspc-go"
+)
+
+// echoTimeout is how long an echo is expected for. Some commands don't cause any event (e.g. hiding a node that's
+// already hidden), so expectations can't be kept around until they're met.
+const echoTimeout = time.Second
+
+type (
+	// Echoes keeps track of the events bspm's own commands are expected to cause (e.g. node_flag events when hiding
+	// nodes), so features reacting to those events can tell them apart from the ones caused by the user.
+	Echoes struct {
+		mutex    sync.Mutex
+		expected []*echo
+		nextID   uint64
+		timeout  time.Duration
+	}
+
+	echo struct {
+		id        uint64
+		eventType bspc.EventType
+		match     func(eventPayload interface{}) bool
+		expiresAt time.Time
+	}
+)
+
+func NewEchoes() *Echoes {
+	return &Echoes{
+		timeout: echoTimeout,
+	}
+}
+
+// Expect registers an event the caller is about to cause. It should be called before the command is sent, since its
+// event might arrive before the command returns. The returned function withdraws the expectation, in case the
+// command fails.
+func (e *Echoes) Expect(eventType bspc.EventType, match func(eventPayload interface{}) bool) func() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.removeExpired()
+
+	e.nextID++
+	id := e.nextID
+
+	e.expected = append(e.expected, &echo{
+		id:        id,
+		eventType: eventType,
+		match:     match,
+		expiresAt: time.Now().Add(e.timeout),
+	})
+
+	return func() {
+		e.mutex.Lock()
+		defer e.mutex.Unlock()
+
+		for i, ec := range e.expected {
+			if ec.id == id {
+				e.expected = append(e.expected[:i], e.expected[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// ExpectEcho is like Expect, for the event type whose payload the match function takes in.
+func ExpectEcho[T Payload](e *Echoes, match func(payload T) bool) func() {
+	return e.Expect(EventTypeOf[T](), func(eventPayload interface{}) bool {
+		payload, ok := eventPayload.(T)
+		return ok && match(payload)
+	})
+}
+
+// IsEcho returns true if the event is one bspm caused itself.
+func (e *Echoes) IsEcho(eventType bspc.EventType, eventPayload interface{}) bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	_, ok := e.find(eventType, eventPayload)
+	return ok
+}
+
+// consume removes the expectation the event meets, if any. Each expectation is only met by one event.
+func (e *Echoes) consume(eventType bspc.EventType, eventPayload interface{}) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if i, ok := e.find(eventType, eventPayload); ok {
+		e.expected = append(e.expected[:i], e.expected[i+1:]...)
+	}
+}
+
+// find returns the index of the oldest expectation the event meets. It must be called with the mutex held.
+func (e *Echoes) find(eventType bspc.EventType, eventPayload interface{}) (int, bool) {
+	e.removeExpired()
+
+	for i, ec := range e.expected {
+		if ec.eventType == eventType && ec.match(eventPayload) {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// removeExpired must be called with the mutex held.
+func (e *Echoes) removeExpired() {
+	now := time.Now()
+
+	expected := e.expected[:0]
+	for _, ec := range e.expected {
+		if now.Before(ec.expiresAt) {
+			expected = append(expected, ec)
+		}
+	}
+
+	e.expected = expected
+}
+
+// IgnoreEchoes doesn't call the callback for events bspm caused itself. Echoes are only recognised until every
+// callback for the event returns, so it needs to come before middleware deferring the callback, like Debounce.
+func IgnoreEchoes(echoes *Echoes) Middleware {
+	return func(eventType bspc.EventType, next Callback) Callback {
+		return func(eventPayload interface{}) error {
+			if echoes.IsEcho(eventType, eventPayload) {
+				return nil
+			}
+
+			return next(eventPayload)
+		}
+	}
+}
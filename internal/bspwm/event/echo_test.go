@@ -0,0 +1,116 @@
+package bspwmevent_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diogox/bspc-go"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	"github.com/diogox/bspm/internal/log"
+	"github.com/diogox/bspm/internal/subscription"
+)
+
+func TestEchoes(t *testing.T) {
+	hidden := func(id bspc.ID) bspc.EventNodeFlag {
+		return bspc.EventNodeFlag{NodeID: id, Flag: bspc.FlagTypeHidden, WasEnabled: true}
+	}
+
+	matchNode := func(id bspc.ID) func(payload bspc.EventNodeFlag) bool {
+		return func(payload bspc.EventNodeFlag) bool {
+			return payload.NodeID == id
+		}
+	}
+
+	t.Run("should recognise expected events", func(t *testing.T) {
+		echoes := bspwmevent.NewEchoes()
+		bspwmevent.ExpectEcho(echoes, matchNode(1))
+
+		assert.True(t, echoes.IsEcho(bspc.EventTypeNodeFlag, hidden(1)))
+		assert.False(t, echoes.IsEcho(bspc.EventTypeNodeFlag, hidden(2)))
+		assert.False(t, echoes.IsEcho(bspc.EventTypeNodeState, bspc.EventNodeState{NodeID: 1}))
+	})
+	t.Run("should not recognise withdrawn events", func(t *testing.T) {
+		echoes := bspwmevent.NewEchoes()
+		withdraw := bspwmevent.ExpectEcho(echoes, matchNode(1))
+		withdraw()
+
+		assert.False(t, echoes.IsEcho(bspc.EventTypeNodeFlag, hidden(1)))
+	})
+	t.Run("should not recognise expired events", func(t *testing.T) {
+		echoes := bspwmevent.NewTestEchoes(time.Millisecond)
+		bspwmevent.ExpectEcho(echoes, matchNode(1))
+
+		assert.Eventually(t, func() bool {
+			return !echoes.IsEcho(bspc.EventTypeNodeFlag, hidden(1))
+		}, time.Second, time.Millisecond)
+	})
+}
+
+func TestIgnoreEchoes(t *testing.T) {
+	t.Run("should only skip callbacks for the event each echo was expected for", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockClient = bspwmevent.NewMockClient(ctrl)
+			evCh       = make(chan bspc.Event)
+			echoes     = bspwmevent.NewEchoes()
+			all        = make(chan bspc.EventNodeFlag, 2)
+			external   = make(chan bspc.EventNodeFlag, 2)
+			payload    = bspc.EventNodeFlag{NodeID: 1, Flag: bspc.FlagTypeHidden, WasEnabled: true}
+		)
+
+		mockClient.EXPECT().
+			SubscribeEvents(gomock.Any(), gomock.Any()).
+			Return(evCh, make(chan error), nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		m := bspwmevent.NewManager(logger, mockClient, subscription.NewManager(), echoes)
+
+		bspwmevent.On(m, func(payload bspc.EventNodeFlag) error {
+			all <- payload
+			return nil
+		})
+		bspwmevent.On(m, func(payload bspc.EventNodeFlag) error {
+			external <- payload
+			return nil
+		}, bspwmevent.IgnoreEchoes(echoes))
+
+		cancel, err := m.Start()
+		require.NoError(t, err)
+		defer cancel()
+
+		bspwmevent.ExpectEcho(echoes, func(p bspc.EventNodeFlag) bool {
+			return p == payload
+		})
+
+		// The first one is the echo, the second one was caused by someone else.
+		evCh <- bspc.Event{Type: bspc.EventTypeNodeFlag, Payload: payload}
+		evCh <- bspc.Event{Type: bspc.EventTypeNodeFlag, Payload: payload}
+
+		for i := 0; i < 2; i++ {
+			select {
+			case got := <-all:
+				assert.Equal(t, payload, got)
+			case <-time.After(time.Second):
+				t.Fatal("timed out waiting for callback")
+			}
+		}
+
+		select {
+		case got := <-external:
+			assert.Equal(t, payload, got)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for callback")
+		}
+
+		assert.Empty(t, external)
+	})
+}
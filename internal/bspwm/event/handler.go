@@ -0,0 +1,118 @@
+package bspwmevent
+
+import (
+	"fmt"
+
+	"github.com/diogox/bspc-go"
+)
+
+// Payload is any of the payloads bspwm events come with.
+type Payload interface {
+	bspc.EventMonitorAdd |
+		bspc.EventMonitorRename |
+		bspc.EventMonitorRemove |
+		bspc.EventMonitorSwap |
+		bspc.EventMonitorFocus |
+		bspc.EventMonitorGeometry |
+		bspc.EventDesktopAdd |
+		bspc.EventDesktopRename |
+		bspc.EventDesktopRemove |
+		bspc.EventDesktopSwap |
+		bspc.EventDesktopTransfer |
+		bspc.EventDesktopFocus |
+		bspc.EventDesktopActivate |
+		bspc.EventDesktopLayout |
+		bspc.EventNodeAdd |
+		bspc.EventNodeRemove |
+		bspc.EventNodeSwap |
+		bspc.EventNodeTransfer |
+		bspc.EventNodeFocus |
+		bspc.EventNodeActivate |
+		bspc.EventNodePreselect |
+		bspc.EventNodeStack |
+		bspc.EventNodeGeometry |
+		bspc.EventNodeState |
+		bspc.EventNodeFlag |
+		bspc.EventNodeLayer |
+		bspc.EventPointerAction
+}
+
+// On registers a handler for the event type whose payload it takes in, so it doesn't need to assert it.
+// e.g. On(m, func(payload bspc.EventNodeAdd) error { ... }) is called for node_add events.
+func On[T Payload](m Manager, handler func(payload T) error, middleware ...Middleware) Handle {
+	eventType := EventTypeOf[T]()
+
+	return m.On(eventType, func(eventPayload interface{}) error {
+		payload, ok := eventPayload.(T)
+		if !ok {
+			return fmt.Errorf("invalid %s event payload: %T", eventType, eventPayload)
+		}
+
+		return handler(payload)
+	}, middleware...)
+}
+
+// EventTypeOf returns the type of the events that come with the given payload.
+func EventTypeOf[T Payload]() bspc.EventType {
+	var payload T
+
+	switch interface{}(payload).(type) {
+	case bspc.EventMonitorAdd:
+		return bspc.EventTypeMonitorAdd
+	case bspc.EventMonitorRename:
+		return bspc.EventTypeMonitorRename
+	case bspc.EventMonitorRemove:
+		return bspc.EventTypeMonitorRemove
+	case bspc.EventMonitorSwap:
+		return bspc.EventTypeMonitorSwap
+	case bspc.EventMonitorFocus:
+		return bspc.EventTypeMonitorFocus
+	case bspc.EventMonitorGeometry:
+		return bspc.EventTypeMonitorGeometry
+	case bspc.EventDesktopAdd:
+		return bspc.EventTypeDesktopAdd
+	case bspc.EventDesktopRename:
+		return bspc.EventTypeDesktopRename
+	case bspc.EventDesktopRemove:
+		return bspc.EventTypeDesktopRemove
+	case bspc.EventDesktopSwap:
+		return bspc.EventTypeDesktopSwap
+	case bspc.EventDesktopTransfer:
+		return bspc.EventTypeDesktopTransfer
+	case bspc.EventDesktopFocus:
+		return bspc.EventTypeDesktopFocus
+	case bspc.EventDesktopActivate:
+		return bspc.EventTypeDesktopActivate
+	case bspc.EventDesktopLayout:
+		return bspc.EventTypeDesktopLayout
+	case bspc.EventNodeAdd:
+		return bspc.EventTypeNodeAdd
+	case bspc.EventNodeRemove:
+		return bspc.EventTypeNodeRemove
+	case bspc.EventNodeSwap:
+		return bspc.EventTypeNodeSwap
+	case bspc.EventNodeTransfer:
+		return bspc.EventTypeNodeTransfer
+	case bspc.EventNodeFocus:
+		return bspc.EventTypeNodeFocus
+	case bspc.EventNodeActivate:
+		return bspc.EventTypeNodeActivate
+	case bspc.EventNodePreselect:
+		return bspc.EventTypeNodePreselect
+	case bspc.EventNodeStack:
+		return bspc.EventTypeNodeStack
+	case bspc.EventNodeGeometry:
+		return bspc.EventTypeNodeGeometry
+	case bspc.EventNodeState:
+		return bspc.EventTypeNodeState
+	case bspc.EventNodeFlag:
+		return bspc.EventTypeNodeFlag
+	case bspc.EventNodeLayer:
+		return bspc.EventTypeNodeLayer
+	case bspc.EventPointerAction:
+		return bspc.EventTypePointerAction
+	default:
+		// Unreachable, as long as Payload and this switch are kept in sync.
+		panic(fmt.Sprintf("unknown event payload: %T", payload))
+	}
+}
@@ -0,0 +1,73 @@
+package bspwmevent_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/diogox/bspc-go"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+)
+
+func TestOn(t *testing.T) {
+	t.Run("should register handler for the event type of its payload", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockManager = bspwmevent.NewMockManager(ctrl)
+			callback    bspwmevent.Callback
+			got         bspc.EventNodeFocus
+			payload     = bspc.EventNodeFocus{NodeID: bspc.ID(1)}
+			handlerErr  = errors.New("error")
+		)
+
+		mockManager.EXPECT().
+			On(bspc.EventTypeNodeFocus, gomock.Any()).
+			Do(func(_ bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) {
+				callback = cb
+			})
+
+		bspwmevent.On(mockManager, func(payload bspc.EventNodeFocus) error {
+			got = payload
+			return handlerErr
+		})
+		require.NotNil(t, callback)
+
+		assert.Equal(t, handlerErr, callback(payload))
+		assert.Equal(t, payload, got)
+	})
+	t.Run("should return error for invalid payloads", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockManager = bspwmevent.NewMockManager(ctrl)
+			callback    bspwmevent.Callback
+		)
+
+		mockManager.EXPECT().
+			On(bspc.EventTypeNodeAdd, gomock.Any(), gomock.Any()).
+			Do(func(_ bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) {
+				callback = cb
+			})
+
+		bspwmevent.On(mockManager, func(bspc.EventNodeAdd) error {
+			t.Fatal("handler should not be called")
+			return nil
+		}, bspwmevent.Recover())
+		require.NotNil(t, callback)
+
+		assert.Error(t, callback(bspc.EventNodeRemove{}))
+	})
+}
+
+func TestEventTypeOf(t *testing.T) {
+	assert.Equal(t, bspc.EventTypeMonitorAdd, bspwmevent.EventTypeOf[bspc.EventMonitorAdd]())
+	assert.Equal(t, bspc.EventTypeDesktopFocus, bspwmevent.EventTypeOf[bspc.EventDesktopFocus]())
+	assert.Equal(t, bspc.EventTypeNodeSwap, bspwmevent.EventTypeOf[bspc.EventNodeSwap]())
+	assert.Equal(t, bspc.EventTypePointerAction, bspwmevent.EventTypeOf[bspc.EventPointerAction]())
+}
@@ -1,107 +1,493 @@
+//go:generate mockgen -package bspwmevent -destination ./bspc_mock.go github.com/diogox/bspc-go Client
 //go:generate mockgen -package bspwmevent -destination ./manager_mock.go -self_package github.com/diogox/bspm/internal/bspwm/event github.com/diogox/bspm/internal/bspwm/event Manager
 
 package bspwmevent
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/diogox/bspc-go"
 	"go.uber.org/zap"
 
 	"github.com/diogox/bspm/internal/log"
+	"github.com/diogox/bspm/internal/subscription"
 )
 
+const (
+	minResubscribeBackoff = 250 * time.Millisecond
+	maxResubscribeBackoff = 5 * time.Second
+
+	// healthCheckInterval is how often bspwm is queried, to make sure it's still there. The event subscription
+	// doesn't always notice bspwm going away, so this is the only way to find out it needs to be renewed.
+	healthCheckInterval = 2 * time.Second
+)
+
+// EventTypes are all the bspwm event types. The manager subscribes to all of them at once, so the subscription
+// doesn't have to change as callbacks are added and removed.
+var EventTypes = []bspc.EventType{
+	bspc.EventTypeMonitorAdd,
+	bspc.EventTypeMonitorRename,
+	bspc.EventTypeMonitorRemove,
+	bspc.EventTypeMonitorSwap,
+	bspc.EventTypeMonitorFocus,
+	bspc.EventTypeMonitorGeometry,
+	bspc.EventTypeDesktopAdd,
+	bspc.EventTypeDesktopRename,
+	bspc.EventTypeDesktopRemove,
+	bspc.EventTypeDesktopSwap,
+	bspc.EventTypeDesktopTransfer,
+	bspc.EventTypeDesktopFocus,
+	bspc.EventTypeDesktopActivate,
+	bspc.EventTypeDesktopLayout,
+	bspc.EventTypeNodeAdd,
+	bspc.EventTypeNodeRemove,
+	bspc.EventTypeNodeSwap,
+	bspc.EventTypeNodeTransfer,
+	bspc.EventTypeNodeFocus,
+	bspc.EventTypeNodeActivate,
+	bspc.EventTypeNodePreselect,
+	bspc.EventTypeNodeStack,
+	bspc.EventTypeNodeGeometry,
+	bspc.EventTypeNodeState,
+	bspc.EventTypeNodeFlag,
+	bspc.EventTypeNodeLayer,
+	bspc.EventTypePointerAction,
+}
+
 type (
-	callbackFunc func(eventPayload interface{}) error
-	cancelFunc   func()
+	// Callback is called with the payload of the events it's registered for.
+	Callback func(eventPayload interface{}) error
+
+	reconnectFunc func() error
+	cancelFunc    func()
+)
+
+type (
+	// Handle identifies a callback registered with On, so it can be removed with Off.
+	Handle struct {
+		eventType bspc.EventType
+		id        uint64
+	}
+
+	handler struct {
+		id         uint64
+		callback   Callback
+		middleware []Middleware
+
+		// wrapped is the callback wrapped in its middleware, and then in the manager's.
+		wrapped Callback
+	}
 )
 
 type (
 	Manager interface {
-		On(eventType bspc.EventType, callback callbackFunc)
+		On(eventType bspc.EventType, callback Callback, middleware ...Middleware) Handle
+		Off(handle Handle)
+		OnReconnect(callback reconnectFunc)
+		Use(middleware ...Middleware)
 		Start() (cancelFunc, error)
 	}
 	manager struct {
-		logger    *log.Logger
-		client    bspc.Client
-		callbacks map[bspc.EventType][]callbackFunc
+		logger        *log.Logger
+		client        bspc.Client
+		subscriptions subscription.Manager
+		echoes        *Echoes
+
+		mutex              sync.Mutex
+		handlers           map[bspc.EventType][]handler
+		nextHandlerID      uint64
+		reconnectCallbacks []reconnectFunc
+
+		// middleware wraps every callback, within the callback's own middleware.
+		middleware []Middleware
+
+		// started is true once Start is called. From then on, the first callback registered opens the subscription.
+		started bool
+
+		// streamCtx is done once the current stream is closed by closeStream.
+		streamCtx    context.Context
+		cancelStream context.CancelFunc
+
+		// events is where the stream sends its events to, so they're handled one at a time. bspc still reads each
+		// event type from its own socket, so events of different types may arrive in a different order than bspwm
+		// emitted them in.
+		events chan bspc.Event
+
+		// broken is signaled when the stream breaks, so it's renewed.
+		broken chan struct{}
+
+		minBackoff          time.Duration
+		maxBackoff          time.Duration
+		healthCheckInterval time.Duration
 	}
 )
 
-func NewManager(logger *log.Logger, client bspc.Client) Manager {
-	return manager{
-		logger:    logger,
-		client:    client,
-		callbacks: make(map[bspc.EventType][]callbackFunc),
+// NewManager returns a manager whose callbacks are already wrapped in the Logging and Recover middleware.
+// Echoes are recognised until every callback for the event returns, so IgnoreEchoes can filter them out.
+func NewManager(logger *log.Logger, client bspc.Client, subscriptions subscription.Manager, echoes *Echoes) Manager {
+	return &manager{
+		logger:              logger,
+		client:              client,
+		subscriptions:       subscriptions,
+		echoes:              echoes,
+		handlers:            make(map[bspc.EventType][]handler),
+		events:              make(chan bspc.Event),
+		broken:              make(chan struct{}, 1),
+		middleware:          []Middleware{Logging(logger), Recover()},
+		minBackoff:          minResubscribeBackoff,
+		maxBackoff:          maxResubscribeBackoff,
+		healthCheckInterval: healthCheckInterval,
 	}
 }
 
 // On takes in an event type and the callback that should be called when a corresponding
-// event is triggered. Callbacks are called in order for each event.
-func (m manager) On(eventType bspc.EventType, callback callbackFunc) {
-	cc, ok := m.callbacks[eventType]
+// event is triggered. Callbacks are called in order for each event, wrapped in the given middleware.
+// It can be called at any time. The subscription already covers every event type, so it's left as it is.
+func (m *manager) On(eventType bspc.EventType, callback Callback, middleware ...Middleware) Handle {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.nextHandlerID++
+	handle := Handle{
+		eventType: eventType,
+		id:        m.nextHandlerID,
+	}
+
+	m.handlers[eventType] = append(m.handlers[eventType], handler{
+		id:         handle.id,
+		callback:   callback,
+		middleware: middleware,
+		wrapped:    m.wrap(eventType, callback, middleware),
+	})
+
+	m.subscribeNow()
+
+	return handle
+}
+
+// Off removes the callback registered with On. It can be called at any time, including from within a callback.
+// Events of types without callbacks left are still received, but nothing is done with them.
+func (m *manager) Off(handle Handle) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	hh := m.handlers[handle.eventType]
+	for i, h := range hh {
+		if h.id != handle.id {
+			continue
+		}
+
+		// Copied, so a dispatch in progress isn't affected.
+		remaining := make([]handler, 0, len(hh)-1)
+		remaining = append(remaining, hh[:i]...)
+		remaining = append(remaining, hh[i+1:]...)
+
+		if len(remaining) == 0 {
+			delete(m.handlers, handle.eventType)
+			return
+		}
+
+		m.handlers[handle.eventType] = remaining
+		return
+	}
+}
+
+// OnReconnect takes in a callback that should be called whenever the subscription to bspwm's events is renewed,
+// before any new event is handled. Since events might have been missed in the meantime, it's where features
+// should reconcile their state with bspwm's.
+func (m *manager) OnReconnect(callback reconnectFunc) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.reconnectCallbacks = append(m.reconnectCallbacks, callback)
+}
+
+// Use adds middleware that wraps every callback, including the ones already registered.
+func (m *manager) Use(middleware ...Middleware) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.middleware = append(m.middleware, middleware...)
+
+	for eventType, hh := range m.handlers {
+		// Copied, so a dispatch in progress isn't affected.
+		rewrapped := make([]handler, 0, len(hh))
+		for _, h := range hh {
+			h.wrapped = m.wrap(eventType, h.callback, h.middleware)
+			rewrapped = append(rewrapped, h)
+		}
+
+		m.handlers[eventType] = rewrapped
+	}
+}
+
+// wrap wraps the callback in the manager's middleware, and then in its own. It must be called with the mutex held.
+func (m *manager) wrap(eventType bspc.EventType, callback Callback, middleware []Middleware) Callback {
+	return chain(middleware...)(eventType, chain(m.middleware...)(eventType, callback))
+}
+
+// Start subscribes to every event type and calls the callbacks when their events are triggered. If there are no
+// callbacks yet, that's left for the first one registered.
+// If the subscription breaks (e.g. when bspwm restarts), it's renewed, with an exponential backoff.
+func (m *manager) Start() (cancelFunc, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.started {
+		return nil, errors.New("event manager already started")
+	}
+
+	if len(m.handlers) > 0 {
+		if err := m.subscribe(); err != nil {
+			return nil, fmt.Errorf("failed to subscribe to events: %w", err)
+		}
+	}
+
+	m.started = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go m.run(ctx)
+
+	return cancelFunc(func() {
+		cancel()
+
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		m.started = false
+		m.closeStream()
+	}), nil
+}
+
+func (m *manager) run(ctx context.Context) {
+	healthCheck := time.NewTicker(m.healthCheckInterval)
+	defer healthCheck.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			m.logger.Info("closing bspwm events subscription")
+			return
+
+		case ev := <-m.events:
+			m.dispatch(ev)
+
+		case <-healthCheck.C:
+			if err := m.client.Query("query --monitors", nil); err != nil {
+				m.logger.Error("bspwm health check failed", zap.Error(err))
+
+				if !m.reconnect(ctx) {
+					return
+				}
+			}
+
+		case <-m.broken:
+			if !m.reconnect(ctx) {
+				return
+			}
+		}
+	}
+}
+
+// dispatch calls the callbacks registered for the event. Their errors are left to the middleware to handle.
+func (m *manager) dispatch(ev bspc.Event) {
+	m.mutex.Lock()
+	hh := m.handlers[ev.Type]
+	m.mutex.Unlock()
+
+	for _, h := range hh {
+		_ = h.wrapped(ev.Payload)
+	}
+
+	m.echoes.consume(ev.Type, ev.Payload)
+}
+
+// reconnect renews the subscription and runs the reconnect callbacks. It returns false if the context was done first.
+func (m *manager) reconnect(ctx context.Context) bool {
+	m.logger.Warning("bspwm events subscription broke, resubscribing")
+
+	m.mutex.Lock()
+	m.closeStream()
+	m.mutex.Unlock()
+
+	attempts, ok := m.resubscribe(ctx)
 	if !ok {
-		m.callbacks[eventType] = []callbackFunc{callback}
+		m.logger.Info("closing bspwm events subscription")
+		return false
+	}
+
+	m.logger.Info("resubscribed to bspwm events", zap.Int("attempts", attempts))
+
+	m.mutex.Lock()
+	callbacks := m.reconnectCallbacks
+	m.mutex.Unlock()
+
+	for _, callback := range callbacks {
+		if err := callback(); err != nil {
+			m.logger.Error("error running reconnect callback", zap.Error(err))
+		}
+	}
+
+	ReconnectedTopic.Publish(m.subscriptions, Reconnected{Attempts: attempts})
+
+	return true
+}
+
+// resubscribe keeps trying to subscribe to the events, until it succeeds or the context is done.
+// It returns the number of attempts it took, and false if the context was done first.
+func (m *manager) resubscribe(ctx context.Context) (int, bool) {
+	backoff := m.minBackoff
+
+	for attempts := 1; ; attempts++ {
+		select {
+		case <-ctx.Done():
+			return attempts, false
+		case <-time.After(backoff):
+		}
+
+		m.mutex.Lock()
+		err := m.subscribe()
+		m.mutex.Unlock()
+
+		if err == nil {
+			// Anything that broke in the meantime was already renewed.
+			select {
+			case <-m.broken:
+			default:
+			}
+
+			return attempts, true
+		}
+
+		m.logger.Warning("failed to resubscribe to bspwm events",
+			zap.Int("attempt", attempts),
+			zap.Error(err),
+		)
+
+		if backoff *= 2; backoff > m.maxBackoff {
+			backoff = m.maxBackoff
+		}
+	}
+}
+
+// subscribeNow opens the subscription, if the manager is started and it isn't open yet. If that fails, it's retried
+// as if it broke. It must be called with the mutex held.
+func (m *manager) subscribeNow() {
+	if !m.started || m.streamCtx != nil {
 		return
 	}
 
-	m.callbacks[eventType] = append(cc, callback)
+	if err := m.subscribe(); err != nil {
+		m.logger.Error("failed to subscribe to bspwm events", zap.Error(err))
+		m.signalBroken()
+	}
 }
 
-// Start subscribes to all the necessary events and calls the callbacks when they are triggered.
-// It should be called after all the necessary event callbacks are added.
-func (m manager) Start() (cancelFunc, error) {
-	var evTypes []bspc.EventType
-	for t := range m.callbacks {
-		evTypes = append(evTypes, t)
+// breakStream signals that the stream needs to be renewed, unless the broken stream was already replaced.
+func (m *manager) breakStream(ctx context.Context) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if ctx != m.streamCtx {
+		return
+	}
+
+	m.signalBroken()
+}
+
+// signalBroken signals that the stream needs to be renewed, unless that's already pending.
+func (m *manager) signalBroken() {
+	select {
+	case m.broken <- struct{}{}:
+	default:
 	}
+}
 
-	if len(evTypes) == 0 {
-		return nil, errors.New("no events subscribed")
+// subscribe subscribes to every event type, unless the subscription is already open. Events of types without
+// callbacks are ignored by dispatch. It must be called with the mutex held.
+//
+// bspc can't close a subscription, so the connections of one that's renewed are left behind, along with the
+// goroutine draining them. That only happens when the subscription breaks, so they're bounded by how often bspwm
+// goes away, and usually gone with it.
+func (m *manager) subscribe() error {
+	if m.streamCtx != nil {
+		return nil
 	}
 
-	evCh, errCh, err := m.client.SubscribeEvents(evTypes[0], evTypes[1:]...)
+	evCh, errCh, err := m.client.SubscribeEvents(EventTypes[0], EventTypes[1:]...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to subscribe to events: %w", err)
+		return fmt.Errorf("failed to subscribe to events: %w", err)
 	}
 
-	cancelCh := make(chan struct{})
-	go m.handleEvents(evCh, errCh, cancelCh)
+	m.streamCtx, m.cancelStream = context.WithCancel(context.Background())
+	go m.stream(m.streamCtx, evCh, errCh)
 
-	cancel := func() { cancelCh <- struct{}{} }
-	return cancel, nil
+	return nil
 }
 
-func (m manager) handleEvents(evCh chan bspc.Event, errCh chan error, cancelCh chan struct{}) {
+// closeStream stops handling the events of the current stream, if any. It must be called with the mutex held.
+func (m *manager) closeStream() {
+	if m.cancelStream != nil {
+		m.cancelStream()
+	}
+
+	m.streamCtx, m.cancelStream = nil, nil
+}
+
+// stream sends the events received to be handled, until the context is done or the subscription breaks.
+func (m *manager) stream(ctx context.Context, evCh chan bspc.Event, errCh chan error) {
+	// bspc can't close the connection, so its events are discarded from then on. Otherwise, bspwm could block
+	// writing to it. See subscribe.
+	defer drain(evCh, errCh)
+
 	for {
 		select {
-		case <-cancelCh:
-			m.logger.Info("closing transparent monocle events subscription")
+		case <-ctx.Done():
 			return
 
 		case err, ok := <-errCh:
 			if !ok {
-				m.logger.Error("error channel closed unexpectedly", zap.Error(err))
-				return
+				m.logger.Error("error channel closed unexpectedly")
+			} else {
+				// The connection that failed stops receiving events, so the subscription has to be renewed.
+				m.logger.Error("error received subscribing to events", zap.Error(err))
 			}
 
-			m.logger.Error("error received subscribing to events", zap.Error(err))
+			m.breakStream(ctx)
+			return
 
 		case ev, ok := <-evCh:
 			if !ok {
 				m.logger.Error("event channel closed unexpectedly")
+				m.breakStream(ctx)
 				return
 			}
 
-			for _, callback := range m.callbacks[ev.Type] {
-				if err := callback(ev.Payload); err != nil {
-					m.logger.Error("error running event callback",
-						zap.String("event_type", string(ev.Type)),
-						zap.Any("event_payload", ev.Payload),
-						zap.Error(err),
-					)
-				}
+			select {
+			case m.events <- ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// drain discards everything received on the channels, until both are closed. bspc never closes them, so in
+// practice it runs for as long as the daemon does.
+func drain(evCh chan bspc.Event, errCh chan error) {
+	for evCh != nil || errCh != nil {
+		select {
+		case _, ok := <-evCh:
+			if !ok {
+				evCh = nil
+			}
+		case _, ok := <-errCh:
+			if !ok {
+				errCh = nil
 			}
 		}
 	}
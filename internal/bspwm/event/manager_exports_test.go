@@ -0,0 +1,34 @@
+package bspwmevent
+
+import (
+	"time"
+
+	"github.com/diogox/bspc-go"
+
+	"github.com/diogox/bspm/internal/log"
+	"github.com/diogox/bspm/internal/subscription"
+)
+
+// NewTestManager returns a manager with the given intervals, so tests don't have to wait for the default ones.
+func NewTestManager(
+	logger *log.Logger,
+	client bspc.Client,
+	subscriptions subscription.Manager,
+	backoff time.Duration,
+	healthCheckInterval time.Duration,
+) Manager {
+	m := NewManager(logger, client, subscriptions, NewEchoes()).(*manager)
+	m.minBackoff = backoff
+	m.maxBackoff = backoff
+	m.healthCheckInterval = healthCheckInterval
+
+	return m
+}
+
+// NewTestEchoes returns echoes expected for the given duration, so tests don't have to wait for them to expire.
+func NewTestEchoes(timeout time.Duration) *Echoes {
+	e := NewEchoes()
+	e.timeout = timeout
+
+	return e
+}
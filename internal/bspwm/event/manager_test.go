@@ -0,0 +1,538 @@
+package bspwmevent_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/diogox/bspc-go"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	"github.com/diogox/bspm/internal/log"
+	"github.com/diogox/bspm/internal/subscription"
+)
+
+func TestManager_Start(t *testing.T) {
+	t.Run("should subscribe to every event type at once", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockClient := bspwmevent.NewMockClient(ctrl)
+		mockClient.EXPECT().
+			SubscribeEvents(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, moreEventTypes ...bspc.EventType) (chan bspc.Event, chan error, error) {
+				assert.Equal(t, bspwmevent.EventTypes, append([]bspc.EventType{eventType}, moreEventTypes...))
+				return make(chan bspc.Event), make(chan error), nil
+			})
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		m := bspwmevent.NewTestManager(logger, mockClient, subscription.NewManager(), time.Millisecond, time.Hour)
+		m.On(bspc.EventTypeNodeAdd, func(interface{}) error { return nil })
+
+		cancel, err := m.Start()
+		require.NoError(t, err)
+		cancel()
+	})
+	t.Run("should call callbacks for received events", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockClient = bspwmevent.NewMockClient(ctrl)
+			evCh       = make(chan bspc.Event, 1)
+			called     = make(chan interface{}, 1)
+			payload    = bspc.EventNodeAdd{NodeID: bspc.ID(1)}
+		)
+
+		mockClient.EXPECT().
+			SubscribeEvents(gomock.Any(), gomock.Any()).
+			Return(evCh, make(chan error), nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		m := bspwmevent.NewTestManager(logger, mockClient, subscription.NewManager(), time.Millisecond, time.Hour)
+		m.On(bspc.EventTypeNodeAdd, func(eventPayload interface{}) error {
+			called <- eventPayload
+			return nil
+		})
+
+		cancel, err := m.Start()
+		require.NoError(t, err)
+		defer cancel()
+
+		evCh <- bspc.Event{Type: bspc.EventTypeNodeAdd, Payload: payload}
+
+		select {
+		case got := <-called:
+			assert.Equal(t, payload, got)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for callback")
+		}
+	})
+	t.Run("should resubscribe when subscription breaks", func(t *testing.T) {
+		for name, breakSubscription := range map[string]func(evCh chan bspc.Event, errCh chan error){
+			"with an error": func(_ chan bspc.Event, errCh chan error) {
+				errCh <- errors.New("error")
+			},
+			"with closed channels": func(evCh chan bspc.Event, errCh chan error) {
+				close(evCh)
+				close(errCh)
+			},
+		} {
+			breakSubscription := breakSubscription
+
+			t.Run(name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				var (
+					mockClient    = bspwmevent.NewMockClient(ctrl)
+					subscriptions = subscription.NewManager()
+					evCh          = make(chan bspc.Event)
+					errCh         = make(chan error, 1)
+					reconnected   = make(chan struct{})
+				)
+
+				gomock.InOrder(
+					mockClient.EXPECT().
+						SubscribeEvents(gomock.Any(), gomock.Any()).
+						Return(evCh, errCh, nil),
+					mockClient.EXPECT().
+						SubscribeEvents(gomock.Any(), gomock.Any()).
+						Return(nil, nil, errors.New("bspwm is down")),
+					mockClient.EXPECT().
+						SubscribeEvents(gomock.Any(), gomock.Any()).
+						Return(make(chan bspc.Event), make(chan error), nil),
+				)
+
+				logger, err := log.New(zaptest.NewLogger(t), false)
+				require.NoError(t, err)
+
+				ctx, cancelSubscription := context.WithCancel(context.Background())
+				defer cancelSubscription()
+
+				reconnectedCh := bspwmevent.ReconnectedTopic.Subscribe(ctx, subscriptions)
+
+				m := bspwmevent.NewTestManager(logger, mockClient, subscriptions, time.Millisecond, time.Hour)
+				m.On(bspc.EventTypeNodeAdd, func(interface{}) error { return nil })
+				m.OnReconnect(func() error {
+					close(reconnected)
+					return nil
+				})
+
+				cancel, err := m.Start()
+				require.NoError(t, err)
+				defer cancel()
+
+				breakSubscription(evCh, errCh)
+
+				select {
+				case <-reconnected:
+				case <-time.After(time.Second):
+					t.Fatal("timed out waiting for reconnect callback")
+				}
+
+				select {
+				case got := <-reconnectedCh:
+					assert.Equal(t, bspwmevent.Reconnected{Attempts: 2}, got)
+				case <-time.After(time.Second):
+					t.Fatal("timed out waiting for reconnected topic")
+				}
+			})
+		}
+	})
+	t.Run("should resubscribe when health check fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockClient  = bspwmevent.NewMockClient(ctrl)
+			reconnected = make(chan struct{})
+		)
+
+		gomock.InOrder(
+			mockClient.EXPECT().
+				SubscribeEvents(gomock.Any(), gomock.Any()).
+				Return(make(chan bspc.Event), make(chan error), nil),
+			mockClient.EXPECT().
+				Query("query --monitors", nil).
+				Return(errors.New("bspwm is down")),
+			mockClient.EXPECT().
+				SubscribeEvents(gomock.Any(), gomock.Any()).
+				Return(make(chan bspc.Event), make(chan error), nil),
+		)
+		mockClient.EXPECT().
+			Query(gomock.Any(), gomock.Any()).
+			Return(nil).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		m := bspwmevent.NewTestManager(logger, mockClient, subscription.NewManager(), time.Millisecond, 10*time.Millisecond)
+		m.On(bspc.EventTypeNodeAdd, func(interface{}) error { return nil })
+		m.OnReconnect(func() error {
+			close(reconnected)
+			return nil
+		})
+
+		cancel, err := m.Start()
+		require.NoError(t, err)
+		defer cancel()
+
+		select {
+		case <-reconnected:
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for reconnect callback")
+		}
+	})
+	t.Run("should start without callbacks", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		cancel, err := bspwmevent.NewManager(logger, bspwmevent.NewMockClient(ctrl), subscription.NewManager(), bspwmevent.NewEchoes()).Start()
+		require.NoError(t, err)
+		cancel()
+	})
+	t.Run("should return error when already started", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		m := bspwmevent.NewManager(logger, bspwmevent.NewMockClient(ctrl), subscription.NewManager(), bspwmevent.NewEchoes())
+
+		cancel, err := m.Start()
+		require.NoError(t, err)
+		defer cancel()
+
+		_, err = m.Start()
+		assert.Error(t, err)
+	})
+}
+
+func TestManager_On(t *testing.T) {
+	t.Run("should subscribe to new event types after starting", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockClient = bspwmevent.NewMockClient(ctrl)
+			evCh       = make(chan bspc.Event)
+			called     = make(chan interface{}, 2)
+			payload    = bspc.EventNodeRemove{NodeID: bspc.ID(1)}
+		)
+
+		mockClient.EXPECT().
+			SubscribeEvents(gomock.Any(), gomock.Any()).
+			Return(evCh, make(chan error), nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		m := bspwmevent.NewTestManager(logger, mockClient, subscription.NewManager(), time.Millisecond, time.Hour)
+
+		cancel, err := m.Start()
+		require.NoError(t, err)
+		defer cancel()
+
+		// The second callback for the same event type must not subscribe again.
+		for i := 0; i < 2; i++ {
+			m.On(bspc.EventTypeNodeRemove, func(eventPayload interface{}) error {
+				called <- eventPayload
+				return nil
+			})
+		}
+
+		evCh <- bspc.Event{Type: bspc.EventTypeNodeRemove, Payload: payload}
+
+		for i := 0; i < 2; i++ {
+			select {
+			case got := <-called:
+				assert.Equal(t, payload, got)
+			case <-time.After(time.Second):
+				t.Fatal("timed out waiting for callback")
+			}
+		}
+	})
+	t.Run("should retry subscribing when it fails after starting", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockClient  = bspwmevent.NewMockClient(ctrl)
+			reconnected = make(chan struct{})
+		)
+
+		gomock.InOrder(
+			mockClient.EXPECT().
+				SubscribeEvents(gomock.Any(), gomock.Any()).
+				Return(nil, nil, errors.New("error")),
+			mockClient.EXPECT().
+				SubscribeEvents(gomock.Any(), gomock.Any()).
+				Return(make(chan bspc.Event), make(chan error), nil),
+		)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		m := bspwmevent.NewTestManager(logger, mockClient, subscription.NewManager(), time.Millisecond, time.Hour)
+		m.OnReconnect(func() error {
+			close(reconnected)
+			return nil
+		})
+
+		cancel, err := m.Start()
+		require.NoError(t, err)
+		defer cancel()
+
+		m.On(bspc.EventTypeNodeRemove, func(interface{}) error { return nil })
+
+		select {
+		case <-reconnected:
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for reconnect callback")
+		}
+	})
+	t.Run("should handle new event types without subscribing again", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockClient = bspwmevent.NewMockClient(ctrl)
+			evCh       = make(chan bspc.Event)
+			called     = make(chan bspc.EventType, 2)
+		)
+
+		mockClient.EXPECT().
+			SubscribeEvents(gomock.Any(), gomock.Any()).
+			Return(evCh, make(chan error), nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		m := bspwmevent.NewTestManager(logger, mockClient, subscription.NewManager(), time.Millisecond, time.Hour)
+		m.On(bspc.EventTypeNodeAdd, func(interface{}) error {
+			called <- bspc.EventTypeNodeAdd
+			return nil
+		})
+
+		cancel, err := m.Start()
+		require.NoError(t, err)
+		defer cancel()
+
+		m.On(bspc.EventTypeNodeRemove, func(interface{}) error {
+			called <- bspc.EventTypeNodeRemove
+			return nil
+		})
+
+		evCh <- bspc.Event{Type: bspc.EventTypeNodeRemove, Payload: bspc.EventNodeRemove{}}
+
+		select {
+		case got := <-called:
+			assert.Equal(t, bspc.EventTypeNodeRemove, got)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for callback")
+		}
+
+		assert.Empty(t, called)
+	})
+}
+
+func TestManager_Off(t *testing.T) {
+	t.Run("should stop calling removed callbacks", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockClient = bspwmevent.NewMockClient(ctrl)
+			evCh       = make(chan bspc.Event)
+			called     = make(chan string, 2)
+		)
+
+		mockClient.EXPECT().
+			SubscribeEvents(gomock.Any(), gomock.Any()).
+			Return(evCh, make(chan error), nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		m := bspwmevent.NewTestManager(logger, mockClient, subscription.NewManager(), time.Millisecond, time.Hour)
+
+		removed := m.On(bspc.EventTypeNodeAdd, func(interface{}) error {
+			called <- "removed"
+			return nil
+		})
+		m.On(bspc.EventTypeNodeAdd, func(interface{}) error {
+			called <- "kept"
+			return nil
+		})
+
+		cancel, err := m.Start()
+		require.NoError(t, err)
+		defer cancel()
+
+		m.Off(removed)
+
+		evCh <- bspc.Event{Type: bspc.EventTypeNodeAdd, Payload: bspc.EventNodeAdd{}}
+
+		select {
+		case got := <-called:
+			assert.Equal(t, "kept", got)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for callback")
+		}
+
+		assert.Empty(t, called)
+	})
+	t.Run("should ignore events of types without callbacks left", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockClient = bspwmevent.NewMockClient(ctrl)
+			evCh       = make(chan bspc.Event)
+			called     = make(chan bspc.EventType, 2)
+		)
+
+		mockClient.EXPECT().
+			SubscribeEvents(gomock.Any(), gomock.Any()).
+			Return(evCh, make(chan error), nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		m := bspwmevent.NewTestManager(logger, mockClient, subscription.NewManager(), time.Millisecond, time.Hour)
+		handle := m.On(bspc.EventTypeNodeAdd, func(interface{}) error {
+			called <- bspc.EventTypeNodeAdd
+			return nil
+		})
+		m.On(bspc.EventTypeNodeRemove, func(interface{}) error {
+			called <- bspc.EventTypeNodeRemove
+			return nil
+		})
+
+		cancel, err := m.Start()
+		require.NoError(t, err)
+		defer cancel()
+
+		m.Off(handle)
+
+		evCh <- bspc.Event{Type: bspc.EventTypeNodeAdd, Payload: bspc.EventNodeAdd{}}
+		evCh <- bspc.Event{Type: bspc.EventTypeNodeRemove, Payload: bspc.EventNodeRemove{}}
+
+		select {
+		case got := <-called:
+			assert.Equal(t, bspc.EventTypeNodeRemove, got)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for callback")
+		}
+
+		assert.Empty(t, called)
+	})
+}
+
+func TestManager_Use(t *testing.T) {
+	t.Run("should wrap callbacks registered before and after", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockClient = bspwmevent.NewMockClient(ctrl)
+			evCh       = make(chan bspc.Event)
+			called     = make(chan struct{}, 2)
+			timings    = bspwmevent.NewTimings()
+		)
+
+		mockClient.EXPECT().
+			SubscribeEvents(gomock.Any(), gomock.Any()).
+			Return(evCh, make(chan error), nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		m := bspwmevent.NewTestManager(logger, mockClient, subscription.NewManager(), time.Millisecond, time.Hour)
+
+		callback := func(interface{}) error {
+			called <- struct{}{}
+			return nil
+		}
+
+		m.On(bspc.EventTypeNodeAdd, callback)
+		m.Use(bspwmevent.Timing(timings.Observe))
+		m.On(bspc.EventTypeNodeAdd, callback)
+
+		cancel, err := m.Start()
+		require.NoError(t, err)
+		defer cancel()
+
+		evCh <- bspc.Event{Type: bspc.EventTypeNodeAdd, Payload: bspc.EventNodeAdd{}}
+
+		for i := 0; i < 2; i++ {
+			select {
+			case <-called:
+			case <-time.After(time.Second):
+				t.Fatal("timed out waiting for callback")
+			}
+		}
+
+		assert.Eventually(t, func() bool {
+			return timings.Snapshot()[bspc.EventTypeNodeAdd].Calls == 2
+		}, time.Second, time.Millisecond)
+	})
+	t.Run("should keep handling events after a callback panics", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockClient = bspwmevent.NewMockClient(ctrl)
+			evCh       = make(chan bspc.Event)
+			called     = make(chan struct{}, 1)
+		)
+
+		mockClient.EXPECT().
+			SubscribeEvents(gomock.Any(), gomock.Any()).
+			Return(evCh, make(chan error), nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		m := bspwmevent.NewTestManager(logger, mockClient, subscription.NewManager(), time.Millisecond, time.Hour)
+
+		var panicked bool
+		m.On(bspc.EventTypeNodeAdd, func(interface{}) error {
+			if !panicked {
+				panicked = true
+				panic("oops")
+			}
+
+			called <- struct{}{}
+			return nil
+		})
+
+		cancel, err := m.Start()
+		require.NoError(t, err)
+		defer cancel()
+
+		evCh <- bspc.Event{Type: bspc.EventTypeNodeAdd, Payload: bspc.EventNodeAdd{}}
+		evCh <- bspc.Event{Type: bspc.EventTypeNodeAdd, Payload: bspc.EventNodeAdd{}}
+
+		select {
+		case <-called:
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for callback")
+		}
+	})
+}
@@ -0,0 +1,178 @@
+package bspwmevent
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/diogox/bspc-go"
+	"go.uber.org/zap"
+
+	"github.com/diogox/bspm/internal/log"
+)
+
+// Middleware wraps the callback for events of the given type, to run code around it.
+type Middleware func(eventType bspc.EventType, next Callback) Callback
+
+// chain composes the middleware into one, with the first one being the outermost.
+func chain(middleware ...Middleware) Middleware {
+	return func(eventType bspc.EventType, next Callback) Callback {
+		for i := len(middleware) - 1; i >= 0; i-- {
+			next = middleware[i](eventType, next)
+		}
+
+		return next
+	}
+}
+
+// Logging logs the errors returned by the callback, along with the event that caused them.
+func Logging(logger *log.Logger) Middleware {
+	return func(eventType bspc.EventType, next Callback) Callback {
+		return func(eventPayload interface{}) error {
+			err := next(eventPayload)
+			if err != nil {
+				logger.Error("error running event callback",
+					zap.String("event_type", string(eventType)),
+					zap.Any("event_payload", eventPayload),
+					zap.Error(err),
+				)
+			}
+
+			return err
+		}
+	}
+}
+
+// Recover turns a panicking callback into one returning an error, so it doesn't bring down the event loop.
+func Recover() Middleware {
+	return func(eventType bspc.EventType, next Callback) Callback {
+		return func(eventPayload interface{}) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("%s event callback panicked: %v\n%s", eventType, r, debug.Stack())
+				}
+			}()
+
+			return next(eventPayload)
+		}
+	}
+}
+
+// Timing reports how long the callback took to run, every time it's called.
+func Timing(observe func(eventType bspc.EventType, elapsed time.Duration)) Middleware {
+	return func(eventType bspc.EventType, next Callback) Callback {
+		return func(eventPayload interface{}) error {
+			start := time.Now()
+			defer func() {
+				observe(eventType, time.Since(start))
+			}()
+
+			return next(eventPayload)
+		}
+	}
+}
+
+// DesktopFilter only calls the callback for events on desktops the match function returns true for.
+// Events referring to more than one desktop (e.g. node_transfer) only need one of them to match, and
+// events that don't refer to any desktop (e.g. monitor_add) always get through.
+func DesktopFilter(match func(desktopID bspc.ID) bool) Middleware {
+	return func(_ bspc.EventType, next Callback) Callback {
+		return func(eventPayload interface{}) error {
+			ids := desktopIDs(eventPayload)
+			if len(ids) == 0 {
+				return next(eventPayload)
+			}
+
+			for _, id := range ids {
+				if match(id) {
+					return next(eventPayload)
+				}
+			}
+
+			return nil
+		}
+	}
+}
+
+// Debounce only calls the callback once events stop coming for the given duration, with the latest one.
+// The callback is then called from its own goroutine, so it needs to be safe to run alongside the other ones.
+// Its errors are left to the middleware it's wrapped in, since the event that caused them was already handled.
+func Debounce(wait time.Duration) Middleware {
+	return func(_ bspc.EventType, next Callback) Callback {
+		var (
+			mutex  sync.Mutex
+			timer  *time.Timer
+			latest interface{}
+		)
+
+		return func(eventPayload interface{}) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			latest = eventPayload
+
+			if timer != nil {
+				timer.Stop()
+			}
+
+			timer = time.AfterFunc(wait, func() {
+				mutex.Lock()
+				payload := latest
+				mutex.Unlock()
+
+				_ = next(payload)
+			})
+
+			return nil
+		}
+	}
+}
+
+// desktopIDs returns the ids of the desktops referred to in the event payload.
+func desktopIDs(eventPayload interface{}) []bspc.ID {
+	switch p := eventPayload.(type) {
+	case bspc.EventDesktopAdd:
+		return []bspc.ID{p.DesktopID}
+	case bspc.EventDesktopRename:
+		return []bspc.ID{p.DesktopID}
+	case bspc.EventDesktopRemove:
+		return []bspc.ID{p.DesktopID}
+	case bspc.EventDesktopSwap:
+		return []bspc.ID{p.SourceDesktopID, p.DestinationDesktopID}
+	case bspc.EventDesktopTransfer:
+		return []bspc.ID{p.SourceDesktopID, p.DestinationDesktopID}
+	case bspc.EventDesktopFocus:
+		return []bspc.ID{p.DesktopID}
+	case bspc.EventDesktopActivate:
+		return []bspc.ID{p.DesktopID}
+	case bspc.EventDesktopLayout:
+		return []bspc.ID{p.DesktopID}
+	case bspc.EventNodeAdd:
+		return []bspc.ID{p.DesktopID}
+	case bspc.EventNodeRemove:
+		return []bspc.ID{p.DesktopID}
+	case bspc.EventNodeSwap:
+		return []bspc.ID{p.SourceDesktopID, p.DestinationDesktopID}
+	case bspc.EventNodeTransfer:
+		return []bspc.ID{p.SourceDesktopID, p.DestinationDesktopID}
+	case bspc.EventNodeFocus:
+		return []bspc.ID{p.DesktopID}
+	case bspc.EventNodeActivate:
+		return []bspc.ID{p.DesktopID}
+	case bspc.EventNodePreselect:
+		return []bspc.ID{p.DesktopID}
+	case bspc.EventNodeGeometry:
+		return []bspc.ID{p.DesktopID}
+	case bspc.EventNodeState:
+		return []bspc.ID{p.DesktopID}
+	case bspc.EventNodeFlag:
+		return []bspc.ID{p.DesktopID}
+	case bspc.EventNodeLayer:
+		return []bspc.ID{p.DesktopID}
+	case bspc.EventPointerAction:
+		return []bspc.ID{p.DesktopID}
+	default:
+		return nil
+	}
+}
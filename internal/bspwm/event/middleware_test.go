@@ -0,0 +1,126 @@
+package bspwmevent_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/diogox/bspc-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	"github.com/diogox/bspm/internal/log"
+)
+
+func TestLogging(t *testing.T) {
+	t.Run("should return the callback's error", func(t *testing.T) {
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		callbackErr := errors.New("error")
+		callback := bspwmevent.Logging(logger)(bspc.EventTypeNodeAdd, func(interface{}) error {
+			return callbackErr
+		})
+
+		assert.Equal(t, callbackErr, callback(bspc.EventNodeAdd{}))
+	})
+}
+
+func TestRecover(t *testing.T) {
+	t.Run("should turn panics into errors", func(t *testing.T) {
+		callback := bspwmevent.Recover()(bspc.EventTypeNodeAdd, func(interface{}) error {
+			panic("oops")
+		})
+
+		err := callback(bspc.EventNodeAdd{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "oops")
+	})
+	t.Run("should return the callback's error", func(t *testing.T) {
+		callbackErr := errors.New("error")
+		callback := bspwmevent.Recover()(bspc.EventTypeNodeAdd, func(interface{}) error {
+			return callbackErr
+		})
+
+		assert.Equal(t, callbackErr, callback(bspc.EventNodeAdd{}))
+	})
+}
+
+func TestTiming(t *testing.T) {
+	t.Run("should observe every call", func(t *testing.T) {
+		timings := bspwmevent.NewTimings()
+		callback := bspwmevent.Timing(timings.Observe)(bspc.EventTypeNodeAdd, func(interface{}) error {
+			time.Sleep(time.Millisecond)
+			return nil
+		})
+
+		require.NoError(t, callback(bspc.EventNodeAdd{}))
+		require.NoError(t, callback(bspc.EventNodeAdd{}))
+
+		timing, ok := timings.Snapshot()[bspc.EventTypeNodeAdd]
+		require.True(t, ok)
+
+		assert.Equal(t, uint64(2), timing.Calls)
+		assert.GreaterOrEqual(t, timing.Total, 2*time.Millisecond)
+		assert.GreaterOrEqual(t, timing.Max, time.Millisecond)
+	})
+}
+
+func TestDesktopFilter(t *testing.T) {
+	monocled := func(desktopID bspc.ID) bool {
+		return desktopID == bspc.ID(1)
+	}
+
+	for name, tc := range map[string]struct {
+		payload        interface{}
+		shouldBeCalled bool
+	}{
+		"matching desktop":     {payload: bspc.EventNodeAdd{DesktopID: 1}, shouldBeCalled: true},
+		"other desktop":        {payload: bspc.EventNodeAdd{DesktopID: 2}, shouldBeCalled: false},
+		"matching source":      {payload: bspc.EventNodeSwap{SourceDesktopID: 1, DestinationDesktopID: 2}, shouldBeCalled: true},
+		"matching destination": {payload: bspc.EventNodeTransfer{SourceDesktopID: 2, DestinationDesktopID: 1}, shouldBeCalled: true},
+		"no desktop":           {payload: bspc.EventMonitorAdd{}, shouldBeCalled: true},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			var called bool
+			callback := bspwmevent.DesktopFilter(monocled)(bspc.EventTypeNodeAdd, func(interface{}) error {
+				called = true
+				return nil
+			})
+
+			require.NoError(t, callback(tc.payload))
+			assert.Equal(t, tc.shouldBeCalled, called)
+		})
+	}
+}
+
+func TestDebounce(t *testing.T) {
+	t.Run("should only call the callback with the latest payload", func(t *testing.T) {
+		called := make(chan interface{}, 3)
+		callback := bspwmevent.Debounce(20*time.Millisecond)(bspc.EventTypeNodeAdd, func(eventPayload interface{}) error {
+			called <- eventPayload
+			return nil
+		})
+
+		for i := 1; i <= 3; i++ {
+			require.NoError(t, callback(bspc.EventNodeAdd{NodeID: bspc.ID(i)}))
+		}
+
+		select {
+		case got := <-called:
+			assert.Equal(t, bspc.EventNodeAdd{NodeID: bspc.ID(3)}, got)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for callback")
+		}
+
+		select {
+		case got := <-called:
+			t.Fatalf("callback called again with %v", got)
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+}
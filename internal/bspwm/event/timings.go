@@ -0,0 +1,59 @@
+package bspwmevent
+
+import (
+	"sync"
+	"time"
+
+	"github.com/diogox/bspc-go"
+)
+
+type (
+	// Timings collects how long callbacks take to run, per event type. Its Observe method is meant to be
+	// passed to the Timing middleware.
+	Timings struct {
+		mutex   sync.Mutex
+		timings map[bspc.EventType]CallbackTiming
+	}
+
+	// CallbackTiming sums up how long the callbacks for an event type took to run.
+	CallbackTiming struct {
+		Calls uint64
+		Total time.Duration
+		Max   time.Duration
+	}
+)
+
+func NewTimings() *Timings {
+	return &Timings{
+		timings: make(map[bspc.EventType]CallbackTiming),
+	}
+}
+
+// Observe records a callback run for the event type.
+func (t *Timings) Observe(eventType bspc.EventType, elapsed time.Duration) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	timing := t.timings[eventType]
+	timing.Calls++
+	timing.Total += elapsed
+
+	if elapsed > timing.Max {
+		timing.Max = elapsed
+	}
+
+	t.timings[eventType] = timing
+}
+
+// Snapshot returns the timings recorded so far, per event type.
+func (t *Timings) Snapshot() map[bspc.EventType]CallbackTiming {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	snapshot := make(map[bspc.EventType]CallbackTiming, len(t.timings))
+	for eventType, timing := range t.timings {
+		snapshot[eventType] = timing
+	}
+
+	return snapshot
+}
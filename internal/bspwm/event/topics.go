@@ -0,0 +1,13 @@
+package bspwmevent
+
+import "github.com/diogox/bspm/internal/subscription"
+
+// ReconnectedTopic is published whenever the subscription to bspwm's events is renewed,
+// after the reconnect callbacks have run.
+const ReconnectedTopic subscription.Topic[Reconnected] = "bspwm/reconnected"
+
+// Reconnected is the payload of ReconnectedTopic.
+type Reconnected struct {
+	// Attempts is the number of attempts it took to resubscribe.
+	Attempts int
+}
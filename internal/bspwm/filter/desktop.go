@@ -1,7 +1,17 @@
 package filter
 
+import (
+	"fmt"
+
+	"github.com/diogox/bspc-go"
+)
+
 type DesktopFilter string
 
 const (
 	DesktopFocused DesktopFilter = "focused"
 )
+
+func DesktopID(id bspc.ID) DesktopFilter {
+	return DesktopFilter(fmt.Sprintf("%d", id))
+}
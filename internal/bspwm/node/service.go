@@ -5,25 +5,49 @@ package bspwmnode
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/diogox/bspc-go"
 
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
 	"github.com/diogox/bspm/internal/bspwm/filter"
 )
 
+// maxConcurrentCommands caps how many commands a batch sends to bspwm at once.
+const maxConcurrentCommands = 8
+
 type (
 	Service interface {
 		Get(filter filter.NodeFilter) (bspc.Node, error)
 		SetVisibility(id bspc.ID, isVisible bool) error
+		SetVisibilities(changes ...Visibility) error
+		SetState(id bspc.ID, state bspc.StateType) error
+		MoveToDesktop(id bspc.ID, desktop filter.DesktopFilter) error
+		Move(id bspc.ID, dx, dy int) error
+		Focus(id bspc.ID) error
+		Swap(id bspc.ID, targetID bspc.ID) error
+		Presel(id bspc.ID, direction bspc.DirectionType, ratio float64) error
+		MoveToNode(id bspc.ID, targetID bspc.ID) error
+		SetRatio(id bspc.ID, ratio float64) error
+	}
+
+	// Visibility is a change to a node's visibility.
+	Visibility struct {
+		NodeID    bspc.ID
+		IsVisible bool
 	}
+
 	service struct {
 		client bspc.Client
+		echoes *bspwmevent.Echoes
 	}
 )
 
-func NewService(client bspc.Client) Service {
+// NewService returns a service that registers the events its commands cause as echoes.
+func NewService(client bspc.Client, echoes *bspwmevent.Echoes) Service {
 	return service{
 		client: client,
+		echoes: echoes,
 	}
 }
 
@@ -58,9 +82,213 @@ func (s service) SetVisibility(id bspc.ID, isVisible bool) error {
 
 	cmd := fmt.Sprintf(descriptor, id, action)
 
+	withdraw := bspwmevent.ExpectEcho(s.echoes, func(payload bspc.EventNodeFlag) bool {
+		return payload.NodeID == id && payload.Flag == bspc.FlagTypeHidden && payload.WasEnabled == !isVisible
+	})
+
 	if err := s.client.Query(cmd, nil); err != nil {
+		withdraw()
 		return fmt.Errorf("failed to set visibility: %w", err)
 	}
 
 	return nil
 }
+
+// SetVisibilities applies all the changes, with far fewer round-trips to bspwm than calling SetVisibility for each.
+// bspwm only takes one command per connection, so they're sent concurrently instead.
+// Nodes are shown before others are hidden, so there's never a moment without any of them on screen.
+func (s service) SetVisibilities(changes ...Visibility) error {
+	var (
+		shown  []bspc.ID
+		hidden []bspc.ID
+	)
+
+	for _, c := range changes {
+		if c.IsVisible {
+			shown = append(shown, c.NodeID)
+			continue
+		}
+
+		hidden = append(hidden, c.NodeID)
+	}
+
+	if err := s.setVisibilities(shown, true); err != nil {
+		return err
+	}
+
+	return s.setVisibilities(hidden, false)
+}
+
+// setVisibilities sets the visibility of every node, concurrently. It returns the first error, once they're all done.
+func (s service) setVisibilities(ids []bspc.ID, isVisible bool) error {
+	var (
+		wg        sync.WaitGroup
+		semaphore = make(chan struct{}, maxConcurrentCommands)
+		errCh     = make(chan error, len(ids))
+	)
+
+	for _, id := range ids {
+		id := id
+
+		wg.Add(1)
+		semaphore <- struct{}{}
+
+		go func() {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			if err := s.SetVisibility(id, isVisible); err != nil {
+				errCh <- fmt.Errorf("node %d: %w", id, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errCh)
+
+	if err, ok := <-errCh; ok {
+		return fmt.Errorf("failed to set visibility of %d nodes: %w", len(errCh)+1, err)
+	}
+
+	return nil
+}
+
+func (s service) SetState(id bspc.ID, state bspc.StateType) error {
+	const descriptor = "node %d --state %s"
+
+	cmd := fmt.Sprintf(descriptor, id, state)
+
+	withdraw := bspwmevent.ExpectEcho(s.echoes, func(payload bspc.EventNodeState) bool {
+		return payload.NodeID == id && payload.State == state && payload.WasEnabled
+	})
+
+	if err := s.client.Query(cmd, nil); err != nil {
+		withdraw()
+		return fmt.Errorf("failed to set node state: %w", err)
+	}
+
+	return nil
+}
+
+// MoveToDesktop sends the node to the given desktop, without following it there.
+func (s service) MoveToDesktop(id bspc.ID, desktop filter.DesktopFilter) error {
+	const descriptor = "node %d --to-desktop %s"
+
+	cmd := fmt.Sprintf(descriptor, id, desktop)
+
+	withdraw := bspwmevent.ExpectEcho(s.echoes, func(payload bspc.EventNodeTransfer) bool {
+		return payload.SourceNodeID == id
+	})
+
+	if err := s.client.Query(cmd, nil); err != nil {
+		withdraw()
+		return fmt.Errorf("failed to move node to desktop: %w", err)
+	}
+
+	return nil
+}
+
+// Move moves a floating node by the given number of pixels.
+func (s service) Move(id bspc.ID, dx, dy int) error {
+	const descriptor = "node %d --move %d %d"
+
+	cmd := fmt.Sprintf(descriptor, id, dx, dy)
+
+	withdraw := bspwmevent.ExpectEcho(s.echoes, func(payload bspc.EventNodeGeometry) bool {
+		return payload.NodeID == id
+	})
+
+	if err := s.client.Query(cmd, nil); err != nil {
+		withdraw()
+		return fmt.Errorf("failed to move node: %w", err)
+	}
+
+	return nil
+}
+
+func (s service) Focus(id bspc.ID) error {
+	const descriptor = "node %d --focus"
+
+	cmd := fmt.Sprintf(descriptor, id)
+
+	withdraw := bspwmevent.ExpectEcho(s.echoes, func(payload bspc.EventNodeFocus) bool {
+		return payload.NodeID == id
+	})
+
+	if err := s.client.Query(cmd, nil); err != nil {
+		withdraw()
+		return fmt.Errorf("failed to focus node: %w", err)
+	}
+
+	return nil
+}
+
+// Swap swaps the node with the target node, each taking the other's place.
+func (s service) Swap(id bspc.ID, targetID bspc.ID) error {
+	const descriptor = "node %d --swap %d"
+
+	cmd := fmt.Sprintf(descriptor, id, targetID)
+
+	withdraw := bspwmevent.ExpectEcho(s.echoes, func(payload bspc.EventNodeSwap) bool {
+		return payload.SourceNodeID == id && payload.DestinationNodeID == targetID
+	})
+
+	if err := s.client.Query(cmd, nil); err != nil {
+		withdraw()
+		return fmt.Errorf("failed to swap nodes: %w", err)
+	}
+
+	return nil
+}
+
+// Presel preselects the area the next node inserted into the node goes to, and the share of it the node keeps.
+func (s service) Presel(id bspc.ID, direction bspc.DirectionType, ratio float64) error {
+	const descriptor = "node %d --presel-dir %s --presel-ratio %f"
+
+	cmd := fmt.Sprintf(descriptor, id, direction, ratio)
+
+	withdraw := bspwmevent.ExpectEcho(s.echoes, func(payload bspc.EventNodePreselect) bool {
+		return payload.NodeID == id
+	})
+
+	if err := s.client.Query(cmd, nil); err != nil {
+		withdraw()
+		return fmt.Errorf("failed to preselect node: %w", err)
+	}
+
+	return nil
+}
+
+// MoveToNode moves the node to the target node's preselection, or splits the target node if it has none.
+func (s service) MoveToNode(id bspc.ID, targetID bspc.ID) error {
+	const descriptor = "node %d --to-node %d"
+
+	cmd := fmt.Sprintf(descriptor, id, targetID)
+
+	withdraw := bspwmevent.ExpectEcho(s.echoes, func(payload bspc.EventNodeTransfer) bool {
+		return payload.SourceNodeID == id
+	})
+
+	if err := s.client.Query(cmd, nil); err != nil {
+		withdraw()
+		return fmt.Errorf("failed to move node to node: %w", err)
+	}
+
+	return nil
+}
+
+// SetRatio sets the share of an internal node's area that its first child gets.
+// Changing it doesn't cause any event, so there's no echo to expect.
+func (s service) SetRatio(id bspc.ID, ratio float64) error {
+	const descriptor = "node %d --ratio %f"
+
+	cmd := fmt.Sprintf(descriptor, id, ratio)
+
+	if err := s.client.Query(cmd, nil); err != nil {
+		return fmt.Errorf("failed to set split ratio: %w", err)
+	}
+
+	return nil
+}
@@ -3,7 +3,11 @@ package bspwmnode_test
 import (
 	"errors"
 	"fmt"
+	"net"
+	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/diogox/bspc-go"
 	"github.com/diogox/bspc-go/bspctest"
@@ -11,6 +15,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
 	"github.com/diogox/bspm/internal/bspwm/filter"
 	bspwmnode "github.com/diogox/bspm/internal/bspwm/node"
 )
@@ -59,7 +64,7 @@ func TestService_Get(t *testing.T) {
 					Query(expectedCmd, bspctest.QueryResponse(t, expectedNode)).
 					Return(nil)
 
-				got, err := bspwmnode.NewService(mockClient).Get(tc.getFilter)
+				got, err := bspwmnode.NewService(mockClient, bspwmevent.NewEchoes()).Get(tc.getFilter)
 				require.NoError(t, err)
 
 				assert.Equal(t, expectedNode, got)
@@ -77,7 +82,7 @@ func TestService_Get(t *testing.T) {
 			Query(gomock.Any(), gomock.Any()).
 			Return(expectedErr)
 
-		_, err := bspwmnode.NewService(mockClient).Get(filter.NodeFocused)
+		_, err := bspwmnode.NewService(mockClient, bspwmevent.NewEchoes()).Get(filter.NodeFocused)
 		require.Error(t, err)
 
 		assert.True(t, errors.Is(err, expectedErr))
@@ -118,8 +123,16 @@ func TestService_SetVisibility(t *testing.T) {
 					Query(expectedCmd, nil).
 					Return(nil)
 
-				err := bspwmnode.NewService(mockClient).SetVisibility(id, tc.isVisible)
+				echoes := bspwmevent.NewEchoes()
+
+				err := bspwmnode.NewService(mockClient, echoes).SetVisibility(id, tc.isVisible)
 				require.NoError(t, err)
+
+				assert.True(t, echoes.IsEcho(bspc.EventTypeNodeFlag, bspc.EventNodeFlag{
+					NodeID:     id,
+					Flag:       bspc.FlagTypeHidden,
+					WasEnabled: !tc.isVisible,
+				}))
 			})
 		}
 	})
@@ -135,9 +148,249 @@ func TestService_SetVisibility(t *testing.T) {
 			Query(gomock.Any(), gomock.Any()).
 			Return(expectedErr)
 
-		err := bspwmnode.NewService(mockClient).SetVisibility(bspc.ID(3), true)
+		echoes := bspwmevent.NewEchoes()
+
+		err := bspwmnode.NewService(mockClient, echoes).SetVisibility(bspc.ID(3), true)
 		require.Error(t, err)
 
 		assert.True(t, errors.Is(err, expectedErr))
+		assert.False(t, echoes.IsEcho(bspc.EventTypeNodeFlag, bspc.EventNodeFlag{
+			NodeID: bspc.ID(3),
+			Flag:   bspc.FlagTypeHidden,
+		}))
+	})
+}
+
+func TestService_SetVisibilities(t *testing.T) {
+	t.Run("should show nodes before hiding the others", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mu       sync.Mutex
+			commands []string
+		)
+
+		mockClient := bspwmnode.NewMockClient(ctrl)
+		mockClient.EXPECT().
+			Query(gomock.Any(), nil).
+			Do(func(cmd string, _ bspc.QueryResponseResolver) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				commands = append(commands, cmd)
+			}).
+			Return(nil).
+			Times(4)
+
+		err := bspwmnode.NewService(mockClient, bspwmevent.NewEchoes()).SetVisibilities(
+			bspwmnode.Visibility{NodeID: 1, IsVisible: false},
+			bspwmnode.Visibility{NodeID: 2, IsVisible: true},
+			bspwmnode.Visibility{NodeID: 3, IsVisible: false},
+			bspwmnode.Visibility{NodeID: 4, IsVisible: true},
+		)
+		require.NoError(t, err)
+
+		require.Len(t, commands, 4)
+		assert.ElementsMatch(t, []string{"node 2 --flag hidden=off", "node 4 --flag hidden=off"}, commands[:2])
+		assert.ElementsMatch(t, []string{"node 1 --flag hidden=on", "node 3 --flag hidden=on"}, commands[2:])
+	})
+
+	t.Run("should not hide nodes when showing others fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+
+		mockClient := bspwmnode.NewMockClient(ctrl)
+		mockClient.EXPECT().
+			Query("node 2 --flag hidden=off", nil).
+			Return(expectedErr)
+
+		err := bspwmnode.NewService(mockClient, bspwmevent.NewEchoes()).SetVisibilities(
+			bspwmnode.Visibility{NodeID: 1, IsVisible: false},
+			bspwmnode.Visibility{NodeID: 2, IsVisible: true},
+		)
+		require.Error(t, err)
+
+		assert.True(t, errors.Is(err, expectedErr))
+	})
+}
+
+func TestService_Commands(t *testing.T) {
+	const id = bspc.ID(3)
+
+	tt := []struct {
+		name        string
+		run         func(s bspwmnode.Service) error
+		expectedCmd string
+		eventType   bspc.EventType
+		echo        interface{}
+	}{
+		{
+			name:        "set state",
+			run:         func(s bspwmnode.Service) error { return s.SetState(id, bspc.StateTypeFloating) },
+			expectedCmd: "node 3 --state floating",
+			eventType:   bspc.EventTypeNodeState,
+			echo:        bspc.EventNodeState{NodeID: id, State: bspc.StateTypeFloating, WasEnabled: true},
+		},
+		{
+			name:        "move to desktop",
+			run:         func(s bspwmnode.Service) error { return s.MoveToDesktop(id, filter.DesktopFocused) },
+			expectedCmd: "node 3 --to-desktop focused",
+			eventType:   bspc.EventTypeNodeTransfer,
+			echo:        bspc.EventNodeTransfer{SourceNodeID: id},
+		},
+		{
+			name:        "move",
+			run:         func(s bspwmnode.Service) error { return s.Move(id, -10, 20) },
+			expectedCmd: "node 3 --move -10 20",
+			eventType:   bspc.EventTypeNodeGeometry,
+			echo:        bspc.EventNodeGeometry{NodeID: id},
+		},
+		{
+			name:        "swap",
+			run:         func(s bspwmnode.Service) error { return s.Swap(id, 5) },
+			expectedCmd: "node 3 --swap 5",
+			eventType:   bspc.EventTypeNodeSwap,
+			echo:        bspc.EventNodeSwap{SourceNodeID: id, DestinationNodeID: 5},
+		},
+		{
+			name:        "focus",
+			run:         func(s bspwmnode.Service) error { return s.Focus(id) },
+			expectedCmd: "node 3 --focus",
+			eventType:   bspc.EventTypeNodeFocus,
+			echo:        bspc.EventNodeFocus{NodeID: id},
+		},
+		{
+			name:        "presel",
+			run:         func(s bspwmnode.Service) error { return s.Presel(id, bspc.DirectionTypeRight, 0.6) },
+			expectedCmd: "node 3 --presel-dir east --presel-ratio 0.600000",
+			eventType:   bspc.EventTypeNodePreselect,
+			echo:        bspc.EventNodePreselect{NodeID: id},
+		},
+		{
+			name:        "move to node",
+			run:         func(s bspwmnode.Service) error { return s.MoveToNode(id, 5) },
+			expectedCmd: "node 3 --to-node 5",
+			eventType:   bspc.EventTypeNodeTransfer,
+			echo:        bspc.EventNodeTransfer{SourceNodeID: id, DestinationNodeID: 5},
+		},
+		{
+			name:        "set ratio",
+			run:         func(s bspwmnode.Service) error { return s.SetRatio(id, 0.25) },
+			expectedCmd: "node 3 --ratio 0.250000",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run("should "+tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := bspwmnode.NewMockClient(ctrl)
+			mockClient.EXPECT().
+				Query(tc.expectedCmd, nil).
+				Return(nil)
+
+			echoes := bspwmevent.NewEchoes()
+
+			require.NoError(t, tc.run(bspwmnode.NewService(mockClient, echoes)))
+
+			if tc.echo != nil {
+				assert.True(t, echoes.IsEcho(tc.eventType, tc.echo))
+			}
+		})
+
+		t.Run("should fail to "+tc.name+" when bspc returns an error", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			expectedErr := errors.New("error")
+
+			mockClient := bspwmnode.NewMockClient(ctrl)
+			mockClient.EXPECT().
+				Query(tc.expectedCmd, nil).
+				Return(expectedErr)
+
+			echoes := bspwmevent.NewEchoes()
+
+			err := tc.run(bspwmnode.NewService(mockClient, echoes))
+			require.Error(t, err)
+
+			assert.True(t, errors.Is(err, expectedErr))
+			assert.False(t, echoes.IsEcho(tc.eventType, tc.echo))
+		})
+	}
+}
+
+// BenchmarkService_SetVisibilities compares toggling a large desktop node by node with doing it in a batch,
+// against a fake bspwm socket that takes a while to respond.
+func BenchmarkService_SetVisibilities(b *testing.B) {
+	const nodeCount = 20
+
+	service := bspwmnode.NewService(newFakeBspwm(b, 100*time.Microsecond), bspwmevent.NewEchoes())
+
+	changes := make([]bspwmnode.Visibility, 0, nodeCount)
+	for i := 0; i < nodeCount; i++ {
+		changes = append(changes, bspwmnode.Visibility{NodeID: bspc.ID(i + 1), IsVisible: i%2 == 0})
+	}
+
+	b.Run("one by one", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, c := range changes {
+				if err := service.SetVisibility(c.NodeID, c.IsVisible); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run("batched", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := service.SetVisibilities(changes...); err != nil {
+				b.Fatal(err)
+			}
+		}
 	})
 }
+
+// newFakeBspwm listens on a unix socket like bspwm's, and replies to every command with an empty response
+// after the given delay.
+func newFakeBspwm(b *testing.B, delay time.Duration) bspc.Client {
+	socketPath := filepath.Join(b.TempDir(), "bspwm_0_0-socket")
+
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(b, err)
+	b.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+
+				buffer := make([]byte, 512)
+				if _, err := conn.Read(buffer); err != nil {
+					return
+				}
+
+				time.Sleep(delay)
+			}()
+		}
+	}()
+
+	client, err := bspc.NewWithSocketPath(socketPath, noopLogger{})
+	require.NoError(b, err)
+
+	return client
+}
+
+type noopLogger struct{}
+
+func (noopLogger) Info(string) {}
+func (noopLogger) Warn(string) {}
@@ -0,0 +1,70 @@
+package bspwmtree
+
+import "github.com/diogox/bspc-go"
+
+// The tree is changed in place, so everything it hands out is a deep copy.
+
+func copyState(st bspc.State) bspc.State {
+	cp := st
+
+	cp.Monitors = nil
+	if st.Monitors != nil {
+		cp.Monitors = make([]bspc.Monitor, 0, len(st.Monitors))
+		for _, m := range st.Monitors {
+			cp.Monitors = append(cp.Monitors, copyMonitor(m))
+		}
+	}
+
+	if st.FocusHistory != nil {
+		cp.FocusHistory = append(make([]bspc.StateFocusHistoryEntry, 0, len(st.FocusHistory)), st.FocusHistory...)
+	}
+
+	if st.StackedNodesList != nil {
+		cp.StackedNodesList = append(make([]bspc.ID, 0, len(st.StackedNodesList)), st.StackedNodesList...)
+	}
+
+	return cp
+}
+
+func copyMonitor(m bspc.Monitor) bspc.Monitor {
+	cp := m
+
+	cp.Desktops = nil
+	if m.Desktops != nil {
+		cp.Desktops = make([]bspc.Desktop, 0, len(m.Desktops))
+		for _, d := range m.Desktops {
+			cp.Desktops = append(cp.Desktops, copyDesktop(d))
+		}
+	}
+
+	return cp
+}
+
+func copyDesktop(d bspc.Desktop) bspc.Desktop {
+	d.Root = copyNode(d.Root)
+	return d
+}
+
+func copyNode(n bspc.Node) bspc.Node {
+	if n.Preselect != nil {
+		preselect := *n.Preselect
+		n.Preselect = &preselect
+	}
+
+	if n.Client != nil {
+		client := *n.Client
+		n.Client = &client
+	}
+
+	if n.FirstChild != nil {
+		child := copyNode(*n.FirstChild)
+		n.FirstChild = &child
+	}
+
+	if n.SecondChild != nil {
+		child := copyNode(*n.SecondChild)
+		n.SecondChild = &child
+	}
+
+	return n
+}
@@ -0,0 +1,449 @@
+//go:generate mockgen -package bspwmtree -destination ./tree_mock.go -self_package github.com/diogox/bspm/internal/bspwm/tree github.com/diogox/bspm/internal/bspwm/tree Tree
+
+package bspwmtree
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/diogox/bspc-go"
+	"go.uber.org/zap"
+
+	"github.com/diogox/bspm/internal/bspwm"
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	"github.com/diogox/bspm/internal/bspwm/filter"
+	"github.com/diogox/bspm/internal/log"
+)
+
+// resyncInterval is how often the whole tree is fetched from bspwm again. It's only a safety net, for changes that
+// bspwm doesn't emit an event the tree is updated from (e.g. a node's split ratio, or a preselection).
+const resyncInterval = 30 * time.Second
+
+// resyncEventTypes are the events that change the tree in ways that are rare, and not worth updating incrementally.
+var resyncEventTypes = []bspc.EventType{
+	bspc.EventTypeMonitorAdd,
+	bspc.EventTypeMonitorRemove,
+	bspc.EventTypeMonitorSwap,
+	bspc.EventTypeMonitorGeometry,
+	bspc.EventTypeDesktopAdd,
+	bspc.EventTypeDesktopRemove,
+	bspc.EventTypeDesktopSwap,
+	bspc.EventTypeDesktopTransfer,
+}
+
+type (
+	// Tree is a model of bspwm's monitors, desktops and nodes, kept up to date from its events,
+	// so it can be queried without talking to bspwm. What it doesn't know about is queried from bspwm instead.
+	// It trails bspwm by the events that haven't been handled yet, except for FocusedDesktop.
+	Tree interface {
+		State() bspc.State
+		FocusedDesktop() (bspc.Desktop, error)
+		Desktop(id bspc.ID) (bspc.Desktop, error)
+		Node(id bspc.ID) (bspc.Node, error)
+	}
+
+	tree struct {
+		logger  *log.Logger
+		service bspwm.Service
+
+		// updateMutex serialises updates, so a resync can't overwrite the changes of events handled while it ran.
+		updateMutex sync.Mutex
+		rwMutex     sync.RWMutex
+		state       bspc.State
+	}
+)
+
+// Start seeds the tree with bspwm's state, and keeps it up to date until the returned function is called.
+func Start(logger *log.Logger, service bspwm.Service) (Tree, func(), error) {
+	return start(logger, service, resyncInterval)
+}
+
+func start(logger *log.Logger, service bspwm.Service, interval time.Duration) (Tree, func(), error) {
+	t := &tree{
+		logger:  logger,
+		service: service,
+	}
+
+	if err := t.resync(); err != nil {
+		return nil, nil, fmt.Errorf("failed to seed bspwm tree: %w", err)
+	}
+
+	handles := t.handleEvents(service.Events())
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Events might have been missed while the subscription was broken.
+	service.Events().OnReconnect(func() error {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		return t.resync()
+	})
+
+	go t.resyncPeriodically(ctx, interval)
+
+	cancelFunc := func() {
+		cancel()
+
+		for _, h := range handles {
+			service.Events().Off(h)
+		}
+	}
+
+	return t, cancelFunc, nil
+}
+
+// State returns a copy of the whole tree.
+func (t *tree) State() bspc.State {
+	t.rwMutex.RLock()
+	defer t.rwMutex.RUnlock()
+
+	return copyState(t.state)
+}
+
+// FocusedDesktop returns the focused desktop, in the focused monitor. It's what commands act on, usually right
+// after others that changed it (e.g. focusing a window, or adding one), so it's fetched from bspwm rather than
+// trusted to the events, and replaced in the tree.
+func (t *tree) FocusedDesktop() (bspc.Desktop, error) {
+	t.updateMutex.Lock()
+	defer t.updateMutex.Unlock()
+
+	desktop, err := t.service.Desktops().Get(filter.DesktopFocused)
+	if err != nil {
+		return bspc.Desktop{}, fmt.Errorf("failed to get focused desktop: %w", err)
+	}
+
+	// A desktop the tree doesn't know of yet is left to the event that adds it.
+	t.rwMutex.Lock()
+	if m, d := findDesktop(&t.state, desktop.ID); d != nil {
+		*d = copyDesktop(desktop)
+		m.FocusedDesktopID = d.ID
+		t.state.FocusedMonitorID = m.ID
+	}
+	t.rwMutex.Unlock()
+
+	return desktop, nil
+}
+
+// Desktop returns the desktop with the given id.
+func (t *tree) Desktop(id bspc.ID) (bspc.Desktop, error) {
+	t.rwMutex.RLock()
+	if _, d := findDesktop(&t.state, id); d != nil {
+		desktop := copyDesktop(*d)
+		t.rwMutex.RUnlock()
+
+		return desktop, nil
+	}
+	t.rwMutex.RUnlock()
+
+	return t.service.Desktops().Get(filter.DesktopID(id))
+}
+
+// Node returns the node with the given id, in whichever desktop it is.
+func (t *tree) Node(id bspc.ID) (bspc.Node, error) {
+	t.rwMutex.RLock()
+	for i := range t.state.Monitors {
+		for j := range t.state.Monitors[i].Desktops {
+			if n := findNode(&t.state.Monitors[i].Desktops[j].Root, id); n != nil {
+				node := copyNode(*n)
+				t.rwMutex.RUnlock()
+
+				return node, nil
+			}
+		}
+	}
+	t.rwMutex.RUnlock()
+
+	return t.service.Nodes().Get(filter.NodeID(id))
+}
+
+func (t *tree) handleEvents(events bspwmevent.Manager) []bspwmevent.Handle {
+	handles := make([]bspwmevent.Handle, 0, len(resyncEventTypes))
+	for _, eventType := range resyncEventTypes {
+		handles = append(handles, events.On(eventType, func(interface{}) error {
+			return t.resync()
+		}))
+	}
+
+	return append(handles,
+		bspwmevent.On(events, func(payload bspc.EventMonitorRename) error {
+			return t.updateMonitor(payload.MonitorID, func(m *bspc.Monitor) {
+				m.Name = payload.MonitorNewName
+			})
+		}),
+		bspwmevent.On(events, func(payload bspc.EventMonitorFocus) error {
+			return t.updateMonitor(payload.MonitorID, func(m *bspc.Monitor) {
+				t.state.FocusedMonitorID = m.ID
+			})
+		}),
+		bspwmevent.On(events, func(payload bspc.EventDesktopRename) error {
+			return t.updateDesktop(payload.DesktopID, func(_ *bspc.Monitor, d *bspc.Desktop) {
+				d.Name = payload.DesktopNewName
+			})
+		}),
+		bspwmevent.On(events, func(payload bspc.EventDesktopFocus) error {
+			return t.updateDesktop(payload.DesktopID, func(m *bspc.Monitor, d *bspc.Desktop) {
+				t.state.FocusedMonitorID = m.ID
+				m.FocusedDesktopID = d.ID
+			})
+		}),
+		bspwmevent.On(events, func(payload bspc.EventDesktopActivate) error {
+			return t.updateDesktop(payload.DesktopID, func(m *bspc.Monitor, d *bspc.Desktop) {
+				m.FocusedDesktopID = d.ID
+			})
+		}),
+		bspwmevent.On(events, func(payload bspc.EventDesktopLayout) error {
+			return t.updateDesktop(payload.DesktopID, func(_ *bspc.Monitor, d *bspc.Desktop) {
+				d.Layout = payload.DesktopLayout
+			})
+		}),
+		// Node events don't say where in the desktop's tree bspwm inserted the node, or how the nodes around it were
+		// rearranged, since that depends on its settings (e.g. the automatic scheme or a preselection). So the
+		// desktops are fetched again instead, at the cost of a round-trip per event. That also means they end up
+		// like bspwm's, regardless of the order the events are handled in.
+		bspwmevent.On(events, func(payload bspc.EventNodeAdd) error {
+			return t.refreshDesktops(payload.DesktopID)
+		}),
+		bspwmevent.On(events, func(payload bspc.EventNodeRemove) error {
+			return t.refreshDesktops(payload.DesktopID)
+		}),
+		bspwmevent.On(events, func(payload bspc.EventNodeSwap) error {
+			return t.refreshDesktops(payload.SourceDesktopID, payload.DestinationDesktopID)
+		}),
+		bspwmevent.On(events, func(payload bspc.EventNodeTransfer) error {
+			return t.refreshDesktops(payload.SourceDesktopID, payload.DestinationDesktopID)
+		}),
+		// Changing a node's state moves the nodes around it as well.
+		bspwmevent.On(events, func(payload bspc.EventNodeState) error {
+			return t.refreshDesktops(payload.DesktopID)
+		}),
+		bspwmevent.On(events, func(payload bspc.EventNodeFocus) error {
+			return t.updateNode(payload.DesktopID, payload.NodeID, func(m *bspc.Monitor, d *bspc.Desktop, _ *bspc.Node) {
+				t.state.FocusedMonitorID = m.ID
+				m.FocusedDesktopID = d.ID
+				d.FocusedNodeID = payload.NodeID
+
+				t.state.FocusHistory = append(t.state.FocusHistory, bspc.StateFocusHistoryEntry{
+					MonitorID: m.ID,
+					DesktopID: d.ID,
+					NodeID:    payload.NodeID,
+				})
+			})
+		}),
+		bspwmevent.On(events, func(payload bspc.EventNodeFlag) error {
+			return t.updateNode(payload.DesktopID, payload.NodeID, func(_ *bspc.Monitor, _ *bspc.Desktop, n *bspc.Node) {
+				setFlag(n, payload.Flag, payload.WasEnabled)
+			})
+		}),
+		bspwmevent.On(events, func(payload bspc.EventNodeLayer) error {
+			return t.updateNode(payload.DesktopID, payload.NodeID, func(_ *bspc.Monitor, _ *bspc.Desktop, n *bspc.Node) {
+				if n.Client != nil {
+					n.Client.LastLayer = n.Client.Layer
+					n.Client.Layer = payload.Layer
+				}
+			})
+		}),
+		bspwmevent.On(events, func(payload bspc.EventNodeGeometry) error {
+			return t.updateNode(payload.DesktopID, payload.NodeID, func(_ *bspc.Monitor, _ *bspc.Desktop, n *bspc.Node) {
+				n.Rectangle = payload.NodeGeometry
+			})
+		}),
+	)
+}
+
+func (t *tree) resyncPeriodically(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := t.resync(); err != nil {
+				t.logger.Error("failed to resync bspwm tree", zap.Error(err))
+			}
+		}
+	}
+}
+
+// resync replaces the whole tree with bspwm's current state.
+func (t *tree) resync() error {
+	t.updateMutex.Lock()
+	defer t.updateMutex.Unlock()
+
+	return t.resyncLocked()
+}
+
+func (t *tree) resyncLocked() error {
+	st, err := t.service.State()
+	if err != nil {
+		return err
+	}
+
+	t.rwMutex.Lock()
+	t.state = st
+	t.rwMutex.Unlock()
+
+	return nil
+}
+
+// refreshDesktops fetches the given desktops from bspwm again, and replaces them in the tree.
+func (t *tree) refreshDesktops(ids ...bspc.ID) error {
+	t.updateMutex.Lock()
+	defer t.updateMutex.Unlock()
+
+	fetched := make(map[bspc.ID]bspc.Desktop, len(ids))
+	for _, id := range ids {
+		if _, ok := fetched[id]; ok {
+			continue
+		}
+
+		desktop, err := t.service.Desktops().Get(filter.DesktopID(id))
+		if err != nil {
+			return fmt.Errorf("failed to refresh desktop %d: %w", id, err)
+		}
+
+		fetched[id] = desktop
+	}
+
+	t.rwMutex.Lock()
+	isKnown := true
+	for id, desktop := range fetched {
+		_, d := findDesktop(&t.state, id)
+		if d == nil {
+			isKnown = false
+			break
+		}
+
+		*d = desktop
+	}
+	t.rwMutex.Unlock()
+
+	if !isKnown {
+		return t.resyncLocked()
+	}
+
+	return nil
+}
+
+// updateMonitor applies the change to the given monitor. If it isn't in the tree yet, it resyncs instead.
+func (t *tree) updateMonitor(id bspc.ID, apply func(m *bspc.Monitor)) error {
+	return t.update(func() bool {
+		m := findMonitor(&t.state, id)
+		if m == nil {
+			return false
+		}
+
+		apply(m)
+		return true
+	})
+}
+
+// updateDesktop applies the change to the given desktop. If it isn't in the tree yet, it resyncs instead.
+func (t *tree) updateDesktop(id bspc.ID, apply func(m *bspc.Monitor, d *bspc.Desktop)) error {
+	return t.update(func() bool {
+		m, d := findDesktop(&t.state, id)
+		if d == nil {
+			return false
+		}
+
+		apply(m, d)
+		return true
+	})
+}
+
+// updateNode applies the change to the given node. If it isn't in the tree yet, it resyncs instead.
+func (t *tree) updateNode(desktopID, nodeID bspc.ID, apply func(m *bspc.Monitor, d *bspc.Desktop, n *bspc.Node)) error {
+	return t.update(func() bool {
+		m, d := findDesktop(&t.state, desktopID)
+		if d == nil {
+			return false
+		}
+
+		n := findNode(&d.Root, nodeID)
+		if n == nil {
+			return false
+		}
+
+		apply(m, d, n)
+		return true
+	})
+}
+
+func (t *tree) update(apply func() bool) error {
+	t.updateMutex.Lock()
+	defer t.updateMutex.Unlock()
+
+	t.rwMutex.Lock()
+	ok := apply()
+	t.rwMutex.Unlock()
+
+	if !ok {
+		return t.resyncLocked()
+	}
+
+	return nil
+}
+
+func setFlag(n *bspc.Node, flag bspc.FlagType, isEnabled bool) {
+	switch flag {
+	case bspc.FlagTypeHidden:
+		n.Hidden = isEnabled
+	case bspc.FlagTypeSticky:
+		n.Sticky = isEnabled
+	case bspc.FlagTypePrivate:
+		n.Private = isEnabled
+	case bspc.FlagTypeLocked:
+		n.Locked = isEnabled
+	case bspc.FlagTypeMarked:
+		n.Marked = isEnabled
+	case bspc.FlagTypeUrgent:
+		if n.Client != nil {
+			n.Client.Urgent = isEnabled
+		}
+	}
+}
+
+func findMonitor(st *bspc.State, id bspc.ID) *bspc.Monitor {
+	for i := range st.Monitors {
+		if st.Monitors[i].ID == id {
+			return &st.Monitors[i]
+		}
+	}
+
+	return nil
+}
+
+func findDesktop(st *bspc.State, id bspc.ID) (*bspc.Monitor, *bspc.Desktop) {
+	for i := range st.Monitors {
+		for j := range st.Monitors[i].Desktops {
+			if st.Monitors[i].Desktops[j].ID == id {
+				return &st.Monitors[i], &st.Monitors[i].Desktops[j]
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func findNode(n *bspc.Node, id bspc.ID) *bspc.Node {
+	if n.ID == id {
+		return n
+	}
+
+	if n.FirstChild != nil {
+		if found := findNode(n.FirstChild, id); found != nil {
+			return found
+		}
+	}
+
+	if n.SecondChild != nil {
+		return findNode(n.SecondChild, id)
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package bspwmtree
+
+import (
+	"time"
+
+	"github.com/diogox/bspm/internal/bspwm"
+	"github.com/diogox/bspm/internal/log"
+)
+
+// StartWithResyncInterval starts a tree that resyncs at the given interval, so tests don't have to wait for the default one.
+func StartWithResyncInterval(logger *log.Logger, service bspwm.Service, interval time.Duration) (Tree, func(), error) {
+	return start(logger, service, interval)
+}
@@ -0,0 +1,604 @@
+package bspwmtree_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/diogox/bspc-go"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/diogox/bspm/internal/bspwm"
+	bspwmdesktop "github.com/diogox/bspm/internal/bspwm/desktop"
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	"github.com/diogox/bspm/internal/bspwm/filter"
+	bspwmnode "github.com/diogox/bspm/internal/bspwm/node"
+	bspwmtree "github.com/diogox/bspm/internal/bspwm/tree"
+	"github.com/diogox/bspm/internal/log"
+)
+
+const (
+	monitorID = bspc.ID(1)
+	desktopID = bspc.ID(2)
+	otherID   = bspc.ID(3)
+	nodeID    = bspc.ID(4)
+)
+
+func newState() bspc.State {
+	tiled := &bspc.NodeClient{State: bspc.StateTypeTiled}
+
+	return bspc.State{
+		FocusedMonitorID: monitorID,
+		Monitors: []bspc.Monitor{
+			{
+				ID:               monitorID,
+				FocusedDesktopID: desktopID,
+				Desktops: []bspc.Desktop{
+					{
+						ID:            desktopID,
+						FocusedNodeID: nodeID,
+						Layout:        bspc.LayoutTypeTiled,
+						Root:          bspc.Node{ID: nodeID, Client: tiled},
+					},
+					{
+						ID:     otherID,
+						Layout: bspc.LayoutTypeTiled,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestStart(t *testing.T) {
+	t.Run("should seed the tree with bspwm's state", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			OnReconnect(gomock.Any()).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			Off(gomock.Any()).
+			AnyTimes()
+		mockService.EXPECT().
+			State().
+			Return(newState(), nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		tree, cancel, err := bspwmtree.Start(logger, mockService)
+		require.NoError(t, err)
+		defer cancel()
+
+		assert.Equal(t, newState(), tree.State())
+
+		node, err := tree.Node(nodeID)
+		require.NoError(t, err)
+		assert.Equal(t, nodeID, node.ID)
+	})
+
+	t.Run("should fail when bspwm's state can't be fetched", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+
+		mockService := bspwm.NewMockService(ctrl)
+		mockService.EXPECT().
+			State().
+			Return(bspc.State{}, expectedErr)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, _, err = bspwmtree.Start(logger, mockService)
+		require.Error(t, err)
+
+		assert.True(t, errors.Is(err, expectedErr))
+	})
+
+	t.Run("should resync periodically", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		resynced := newState()
+		resynced.Monitors[0].FocusedDesktopID = otherID
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			OnReconnect(gomock.Any()).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			Off(gomock.Any()).
+			AnyTimes()
+		mockService.EXPECT().
+			State().
+			Return(newState(), nil)
+		mockService.EXPECT().
+			State().
+			Return(resynced, nil).
+			MinTimes(1)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		tree, cancel, err := bspwmtree.StartWithResyncInterval(logger, mockService, time.Millisecond)
+		require.NoError(t, err)
+		defer cancel()
+
+		assert.Eventually(t, func() bool {
+			return tree.State().Monitors[0].FocusedDesktopID == otherID
+		}, time.Second, time.Millisecond)
+	})
+}
+
+func TestTree_Events(t *testing.T) {
+	t.Run("should update the tree in place", func(t *testing.T) {
+		tt := []struct {
+			name      string
+			eventType bspc.EventType
+			payload   interface{}
+			assert    func(t *testing.T, st bspc.State)
+		}{
+			{
+				name:      "on desktop focus",
+				eventType: bspc.EventTypeDesktopFocus,
+				payload:   bspc.EventDesktopFocus{MonitorID: monitorID, DesktopID: otherID},
+				assert: func(t *testing.T, st bspc.State) {
+					assert.Equal(t, otherID, st.Monitors[0].FocusedDesktopID)
+				},
+			},
+			{
+				name:      "on desktop rename",
+				eventType: bspc.EventTypeDesktopRename,
+				payload:   bspc.EventDesktopRename{MonitorID: monitorID, DesktopID: desktopID, DesktopNewName: "web"},
+				assert: func(t *testing.T, st bspc.State) {
+					assert.Equal(t, "web", st.Monitors[0].Desktops[0].Name)
+				},
+			},
+			{
+				name:      "on desktop layout",
+				eventType: bspc.EventTypeDesktopLayout,
+				payload: bspc.EventDesktopLayout{
+					MonitorID:     monitorID,
+					DesktopID:     desktopID,
+					DesktopLayout: bspc.LayoutTypeMonocle,
+				},
+				assert: func(t *testing.T, st bspc.State) {
+					assert.Equal(t, bspc.LayoutTypeMonocle, st.Monitors[0].Desktops[0].Layout)
+				},
+			},
+			{
+				name:      "on node flag",
+				eventType: bspc.EventTypeNodeFlag,
+				payload: bspc.EventNodeFlag{
+					MonitorID:  monitorID,
+					DesktopID:  desktopID,
+					NodeID:     nodeID,
+					Flag:       bspc.FlagTypeHidden,
+					WasEnabled: true,
+				},
+				assert: func(t *testing.T, st bspc.State) {
+					assert.True(t, st.Monitors[0].Desktops[0].Root.Hidden)
+				},
+			},
+			{
+				name:      "on node layer",
+				eventType: bspc.EventTypeNodeLayer,
+				payload: bspc.EventNodeLayer{
+					MonitorID: monitorID,
+					DesktopID: desktopID,
+					NodeID:    nodeID,
+					Layer:     bspc.LayerTypeAbove,
+				},
+				assert: func(t *testing.T, st bspc.State) {
+					assert.Equal(t, bspc.LayerTypeAbove, st.Monitors[0].Desktops[0].Root.Client.Layer)
+				},
+			},
+		}
+
+		for _, tc := range tt {
+			t.Run(tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				var (
+					mockService      = bspwm.NewMockService(ctrl)
+					mockEventManager = bspwmevent.NewMockManager(ctrl)
+					callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+				)
+
+				mockService.EXPECT().
+					Events().
+					Return(mockEventManager).
+					AnyTimes()
+				mockEventManager.EXPECT().
+					On(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+						callbacks[eventType] = cb
+						return bspwmevent.Handle{}
+					}).
+					AnyTimes()
+				mockEventManager.EXPECT().
+					OnReconnect(gomock.Any()).
+					AnyTimes()
+				mockEventManager.EXPECT().
+					Off(gomock.Any()).
+					AnyTimes()
+				mockService.EXPECT().
+					State().
+					Return(newState(), nil)
+
+				logger, err := log.New(zaptest.NewLogger(t), false)
+				require.NoError(t, err)
+
+				tree, cancel, err := bspwmtree.Start(logger, mockService)
+				require.NoError(t, err)
+				defer cancel()
+
+				require.NoError(t, callbacks[tc.eventType](tc.payload))
+
+				tc.assert(t, tree.State())
+			})
+		}
+	})
+
+	t.Run("should refresh the desktop when its nodes change", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		const addedNodeID = bspc.ID(5)
+
+		refreshed := bspc.Desktop{
+			ID:            desktopID,
+			FocusedNodeID: addedNodeID,
+			Root: bspc.Node{
+				FirstChild:  &bspc.Node{ID: nodeID, Client: &bspc.NodeClient{}},
+				SecondChild: &bspc.Node{ID: addedNodeID, Client: &bspc.NodeClient{}},
+			},
+		}
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockDesktops     = bspwmdesktop.NewMockService(ctrl)
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Desktops().
+			Return(mockDesktops).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			OnReconnect(gomock.Any()).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			Off(gomock.Any()).
+			AnyTimes()
+		mockService.EXPECT().
+			State().
+			Return(newState(), nil)
+		mockDesktops.EXPECT().
+			Get(filter.DesktopID(desktopID)).
+			Return(refreshed, nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		tree, cancel, err := bspwmtree.Start(logger, mockService)
+		require.NoError(t, err)
+		defer cancel()
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeAdd](bspc.EventNodeAdd{
+			MonitorID: monitorID,
+			DesktopID: desktopID,
+			NodeID:    addedNodeID,
+		}))
+
+		desktop, err := tree.Desktop(desktopID)
+		require.NoError(t, err)
+		assert.Equal(t, refreshed, desktop)
+
+		node, err := tree.Node(addedNodeID)
+		require.NoError(t, err)
+		assert.Equal(t, addedNodeID, node.ID)
+	})
+
+	t.Run("should resync when the event refers to something it doesn't know of", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		const unknownNodeID = bspc.ID(5)
+
+		resynced := newState()
+		resynced.Monitors[0].Desktops[1].Root = bspc.Node{ID: unknownNodeID, Client: &bspc.NodeClient{}}
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			OnReconnect(gomock.Any()).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			Off(gomock.Any()).
+			AnyTimes()
+		gomock.InOrder(
+			mockService.EXPECT().
+				State().
+				Return(newState(), nil),
+			mockService.EXPECT().
+				State().
+				Return(resynced, nil),
+		)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		tree, cancel, err := bspwmtree.Start(logger, mockService)
+		require.NoError(t, err)
+		defer cancel()
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeFlag](bspc.EventNodeFlag{
+			MonitorID: monitorID,
+			DesktopID: otherID,
+			NodeID:    unknownNodeID,
+			Flag:      bspc.FlagTypeMarked,
+		}))
+
+		assert.Equal(t, resynced, tree.State())
+	})
+}
+
+func TestTree_FocusedDesktop(t *testing.T) {
+	t.Run("should fetch the focused desktop from bspwm, and replace it in the tree", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		focused := bspc.Desktop{
+			ID:            otherID,
+			FocusedNodeID: nodeID + 1,
+			Layout:        bspc.LayoutTypeTiled,
+			Root:          bspc.Node{ID: nodeID + 1},
+		}
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockDesktops     = bspwmdesktop.NewMockService(ctrl)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Desktops().
+			Return(mockDesktops).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			OnReconnect(gomock.Any()).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			Off(gomock.Any()).
+			AnyTimes()
+		mockService.EXPECT().
+			State().
+			Return(newState(), nil)
+		mockDesktops.EXPECT().
+			Get(filter.DesktopFocused).
+			Return(focused, nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		tree, cancel, err := bspwmtree.Start(logger, mockService)
+		require.NoError(t, err)
+		defer cancel()
+
+		desktop, err := tree.FocusedDesktop()
+		require.NoError(t, err)
+		assert.Equal(t, focused, desktop)
+
+		st := tree.State()
+		assert.Equal(t, otherID, st.Monitors[0].FocusedDesktopID)
+		assert.Equal(t, focused, st.Monitors[0].Desktops[1])
+	})
+
+	t.Run("should return error when bspwm can't be queried", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockDesktops     = bspwmdesktop.NewMockService(ctrl)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Desktops().
+			Return(mockDesktops).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			OnReconnect(gomock.Any()).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			Off(gomock.Any()).
+			AnyTimes()
+		mockService.EXPECT().
+			State().
+			Return(newState(), nil)
+		mockDesktops.EXPECT().
+			Get(filter.DesktopFocused).
+			Return(bspc.Desktop{}, expectedErr)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		tree, cancel, err := bspwmtree.Start(logger, mockService)
+		require.NoError(t, err)
+		defer cancel()
+
+		_, err = tree.FocusedDesktop()
+		assert.True(t, errors.Is(err, expectedErr))
+	})
+}
+
+func TestTree_Node(t *testing.T) {
+	t.Run("should query bspwm for nodes not in the tree", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		const unknownNodeID = bspc.ID(5)
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			OnReconnect(gomock.Any()).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			Off(gomock.Any()).
+			AnyTimes()
+		mockService.EXPECT().
+			State().
+			Return(newState(), nil)
+		mockNodes.EXPECT().
+			Get(filter.NodeID(unknownNodeID)).
+			Return(bspc.Node{ID: unknownNodeID}, nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		tree, cancel, err := bspwmtree.Start(logger, mockService)
+		require.NoError(t, err)
+		defer cancel()
+
+		node, err := tree.Node(unknownNodeID)
+		require.NoError(t, err)
+
+		assert.Equal(t, unknownNodeID, node.ID)
+	})
+
+	t.Run("should return a copy", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			OnReconnect(gomock.Any()).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			Off(gomock.Any()).
+			AnyTimes()
+		mockService.EXPECT().
+			State().
+			Return(newState(), nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		tree, cancel, err := bspwmtree.Start(logger, mockService)
+		require.NoError(t, err)
+		defer cancel()
+
+		node, err := tree.Node(nodeID)
+		require.NoError(t, err)
+
+		node.Client.State = bspc.StateTypeFloating
+
+		node, err = tree.Node(nodeID)
+		require.NoError(t, err)
+
+		assert.Equal(t, bspc.StateTypeTiled, node.Client.State)
+	})
+}
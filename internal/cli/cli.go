@@ -1,18 +1,28 @@
 package cli
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/diogox/bspm/internal/subscription"
 
+	"github.com/diogox/bspc-go"
 	"github.com/fatih/color"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/urfave/cli/v2"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
 
+	desktoplabels "github.com/diogox/bspm/internal/feature/desktop_labels"
+	dynamicdesktops "github.com/diogox/bspm/internal/feature/dynamic_desktops"
+	eventforwarding "github.com/diogox/bspm/internal/feature/event_forwarding"
+	"github.com/diogox/bspm/internal/feature/layout"
+	"github.com/diogox/bspm/internal/feature/swallow"
 	"github.com/diogox/bspm/internal/grpc"
 	"github.com/diogox/bspm/internal/grpc/bspm"
 	"github.com/diogox/bspm/internal/log"
@@ -21,12 +31,47 @@ import (
 const (
 	flagKeyDaemon                    = "daemon"
 	flagKeyVerbose                   = "verbose"
+	flagKeyDBus                      = "dbus"
 	flagKeyMonocleToggle             = "toggle"
 	flagKeyMonocleNext               = "next"
 	flagKeyMonoclePrev               = "prev"
 	flagKeyMonocleSubscribeNodeCount = "subscribe-node-count"
+	flagKeySubscribeDesktop          = "desktop"
+	flagKeyPlaceholder               = "placeholder"
+	flagKeyScratchpadClass           = "class"
+	flagKeyScratchpadCommand         = "command"
+	flagKeyLayoutInc                 = "inc"
+	flagKeyLayoutDec                 = "dec"
+	flagKeySwallow                   = "swallow"
+	flagKeyNoSwallow                 = "no-swallow"
+	flagKeyDynamicDesktops           = "dynamic-desktops"
+	flagKeyDesktopNaming             = "desktop-naming"
+	flagKeyPinnedDesktop             = "pinned-desktop"
+	flagKeyDesktopLabels             = "desktop-labels"
+	flagKeyDesktopIcon               = "desktop-icon"
+	flagKeyRestoreHere               = "here"
+	flagKeyRestorePicker             = "picker"
 )
 
+// layoutRatioStep is how much the master area grows or shrinks at a time.
+const layoutRatioStep = 0.05
+
+var layoutDeltaFlags = []cli.Flag{
+	&cli.BoolFlag{
+		Name:  flagKeyLayoutInc,
+		Usage: "Increase it",
+	},
+	&cli.BoolFlag{
+		Name:  flagKeyLayoutDec,
+		Usage: "Decrease it",
+	},
+}
+
+var placeholderFlag = &cli.StringFlag{
+	Name:  flagKeyPlaceholder,
+	Usage: "Printed whenever the daemon becomes unavailable, until the subscription resumes",
+}
+
 type app struct {
 	cli *cli.App
 }
@@ -49,6 +94,39 @@ func New(logger *zap.Logger, version string) app {
 					Name:  flagKeyVerbose,
 					Usage: "Verbose logging",
 				},
+				&cli.BoolFlag{
+					Name:  flagKeyDBus,
+					Usage: "Expose the daemon on the D-Bus session bus",
+				},
+				&cli.StringSliceFlag{
+					Name:  flagKeySwallow,
+					Usage: "Class of the windows (e.g. terminals) to hide while a window launched from them is open",
+				},
+				&cli.StringSliceFlag{
+					Name:  flagKeyNoSwallow,
+					Usage: "Class of the windows that never hide the window they're launched from",
+				},
+				&cli.BoolFlag{
+					Name:  flagKeyDynamicDesktops,
+					Usage: "Keep a single empty desktop at the end of each monitor, adding and removing desktops as needed",
+				},
+				&cli.StringFlag{
+					Name: flagKeyDesktopNaming,
+					Usage: "Name dynamic desktops after their position, with one of: " +
+						strings.Join(dynamicdesktops.NamingNames(), ", ") + " (they keep their names by default)",
+				},
+				&cli.StringSliceFlag{
+					Name:  flagKeyPinnedDesktop,
+					Usage: "Name of a desktop dynamic desktops never remove or rename",
+				},
+				&cli.BoolFlag{
+					Name:  flagKeyDesktopLabels,
+					Usage: "Label desktops' names with their windows (e.g. 3:firefox)",
+				},
+				&cli.StringSliceFlag{
+					Name:  flagKeyDesktopIcon,
+					Usage: "What windows of a class are shown as in desktop labels, as <class>=<icon>",
+				},
 			},
 			ExitErrHandler: func(context *cli.Context, err error) {
 				color.Red("Failed: %v", err)
@@ -75,16 +153,14 @@ func New(logger *zap.Logger, version string) app {
 							Name:  flagKeyMonocleSubscribeNodeCount,
 							Usage: "Returns the number of nodes in the transparent monocle workflow, every time it changes",
 						},
+						placeholderFlag,
 					},
 					Action: func(ctx *cli.Context) error {
 						c, err := grpc.NewClient()
 						if err != nil {
 							return err
 						}
-
-						if ctx.NumFlags() != 1 {
-							return errors.New("only one flag is expected")
-						}
+						defer c.Close()
 
 						var (
 							isToggle             = ctx.Bool(flagKeyMonocleToggle)
@@ -93,6 +169,17 @@ func New(logger *zap.Logger, version string) app {
 							isSubscribeNodeCount = ctx.Bool(flagKeyMonocleSubscribeNodeCount)
 						)
 
+						var numActions int
+						for _, isSet := range []bool{isToggle, isNext, isPrev, isSubscribeNodeCount} {
+							if isSet {
+								numActions++
+							}
+						}
+
+						if numActions != 1 {
+							return errors.New("only one action flag is expected")
+						}
+
 						switch {
 						case isToggle:
 							if _, err := c.MonocleModeToggle(ctx.Context, &empty.Empty{}); err != nil {
@@ -119,26 +206,652 @@ func New(logger *zap.Logger, version string) app {
 								Type: bspm.MonocleModeSubscriptionType_MONOCLE_MODE_SUBSCRIPTION_TYPE_NODE_COUNT,
 							}
 
-							subscription, err := c.MonocleModeSubscribe(ctx.Context, req)
+							return streamSubscription(ctx.Context, placeholder(ctx), func(ctx context.Context, c grpc.Client) (recvFunc, error) {
+								subscription, err := c.MonocleModeSubscribe(ctx, req)
+								if err != nil {
+									return nil, fmt.Errorf("failed to subscribe to node count in monocle mode: %w", err)
+								}
+
+								return func() (string, error) {
+									msg, err := subscription.Recv()
+									if err != nil {
+										return "", fmt.Errorf("failed to receive message from monocle mode node count subscription: %w", err)
+									}
+
+									return strconv.Itoa(int(msg.GetNodeCount())), nil
+								}, nil
+							})
+						default:
+							return errors.New("unexpected error")
+						}
+
+						return nil
+					},
+				},
+				{
+					Name:  "scratchpad",
+					Usage: "Manages scratchpads: named windows that can be summoned to, and dismissed from, any desktop",
+					Subcommands: []*cli.Command{
+						{
+							Name:      "toggle",
+							Usage:     "Shows the scratchpad in the focused desktop, or hides it if it's already there",
+							ArgsUsage: "<name>",
+							Flags: []cli.Flag{
+								&cli.StringFlag{
+									Name:  flagKeyScratchpadClass,
+									Usage: "Class of the scratchpad's window (only needed the first time)",
+								},
+								&cli.StringFlag{
+									Name:  flagKeyScratchpadCommand,
+									Usage: "Command that launches the scratchpad's window, if there isn't one (only needed the first time)",
+								},
+							},
+							Action: func(ctx *cli.Context) error {
+								if ctx.NArg() != 1 {
+									return errors.New("expected the scratchpad's name")
+								}
+
+								c, err := grpc.NewClient()
+								if err != nil {
+									return err
+								}
+								defer c.Close()
+
+								req := &bspm.ScratchpadToggleRequest{
+									Name:      ctx.Args().First(),
+									ClassName: ctx.String(flagKeyScratchpadClass),
+									Command:   ctx.String(flagKeyScratchpadCommand),
+								}
+
+								if _, err := c.ScratchpadToggle(ctx.Context, req); err != nil {
+									return fmt.Errorf("failed to toggle scratchpad: %w", err)
+								}
+
+								return nil
+							},
+						},
+					},
+				},
+				{
+					Name:  "layout",
+					Usage: "Arranges the focused desktop's windows, and keeps them arranged as they come and go",
+					Subcommands: []*cli.Command{
+						{
+							Name:      "set",
+							Usage:     "Arranges the desktop with the given layout",
+							ArgsUsage: "<" + strings.Join(layout.Names(), "|") + ">",
+							Action: func(ctx *cli.Context) error {
+								if ctx.NArg() != 1 {
+									return errors.New("expected the layout's name")
+								}
+
+								c, err := grpc.NewClient()
+								if err != nil {
+									return err
+								}
+								defer c.Close()
+
+								req := &bspm.LayoutSetRequest{
+									Name: ctx.Args().First(),
+								}
+
+								if _, err := c.LayoutSet(ctx.Context, req); err != nil {
+									return fmt.Errorf("failed to set layout: %w", err)
+								}
+
+								return nil
+							},
+						},
+						{
+							Name:  "next",
+							Usage: "Arranges the desktop with the next layout",
+							Action: func(ctx *cli.Context) error {
+								c, err := grpc.NewClient()
+								if err != nil {
+									return err
+								}
+								defer c.Close()
+
+								if _, err := c.LayoutNext(ctx.Context, &empty.Empty{}); err != nil {
+									return fmt.Errorf("failed to cycle layout: %w", err)
+								}
+
+								return nil
+							},
+						},
+						{
+							Name:  "unset",
+							Usage: "Stops arranging the desktop, leaving its windows where they are",
+							Action: func(ctx *cli.Context) error {
+								c, err := grpc.NewClient()
+								if err != nil {
+									return err
+								}
+								defer c.Close()
+
+								if _, err := c.LayoutUnset(ctx.Context, &empty.Empty{}); err != nil {
+									return fmt.Errorf("failed to unset layout: %w", err)
+								}
+
+								return nil
+							},
+						},
+						{
+							Name:  "promote",
+							Usage: "Makes the focused window the master, or swaps it with the next one if it already is",
+							Action: func(ctx *cli.Context) error {
+								c, err := grpc.NewClient()
+								if err != nil {
+									return err
+								}
+								defer c.Close()
+
+								if _, err := c.LayoutPromote(ctx.Context, &empty.Empty{}); err != nil {
+									return fmt.Errorf("failed to promote window: %w", err)
+								}
+
+								return nil
+							},
+						},
+						{
+							Name:  "master-count",
+							Usage: "Changes how many windows go in the master area",
+							Flags: layoutDeltaFlags,
+							Action: func(ctx *cli.Context) error {
+								delta, err := layoutDelta(ctx)
+								if err != nil {
+									return err
+								}
+
+								c, err := grpc.NewClient()
+								if err != nil {
+									return err
+								}
+								defer c.Close()
+
+								req := &bspm.LayoutMasterCountChangeRequest{
+									Delta: int32(delta),
+								}
+
+								if _, err := c.LayoutMasterCountChange(ctx.Context, req); err != nil {
+									return fmt.Errorf("failed to change master count: %w", err)
+								}
+
+								return nil
+							},
+						},
+						{
+							Name:  "ratio",
+							Usage: "Changes the share of the desktop the master area gets",
+							Flags: layoutDeltaFlags,
+							Action: func(ctx *cli.Context) error {
+								delta, err := layoutDelta(ctx)
+								if err != nil {
+									return err
+								}
+
+								c, err := grpc.NewClient()
+								if err != nil {
+									return err
+								}
+								defer c.Close()
+
+								req := &bspm.LayoutRatioChangeRequest{
+									Delta: float64(delta) * layoutRatioStep,
+								}
+
+								if _, err := c.LayoutRatioChange(ctx.Context, req); err != nil {
+									return fmt.Errorf("failed to change ratio: %w", err)
+								}
+
+								return nil
+							},
+						},
+					},
+				},
+				{
+					Name:  "tabbed",
+					Usage: "Shows one window at a time in part of the focused desktop, with the others as tabs",
+					Subcommands: []*cli.Command{
+						{
+							Name:  "toggle",
+							Usage: "Tabs the focused window with the ones next to it, or brings them back if they're tabbed",
+							Action: func(ctx *cli.Context) error {
+								c, err := grpc.NewClient()
+								if err != nil {
+									return err
+								}
+								defer c.Close()
+
+								if _, err := c.TabbedToggle(ctx.Context, &empty.Empty{}); err != nil {
+									return fmt.Errorf("failed to toggle tabbed container: %w", err)
+								}
+
+								return nil
+							},
+						},
+						{
+							Name:  "next",
+							Usage: "Shows the next tab of the focused window's container",
+							Action: func(ctx *cli.Context) error {
+								return cycleTabs(ctx, bspm.CycleDir_CYCLE_DIR_NEXT)
+							},
+						},
+						{
+							Name:  "prev",
+							Usage: "Shows the previous tab of the focused window's container",
+							Action: func(ctx *cli.Context) error {
+								return cycleTabs(ctx, bspm.CycleDir_CYCLE_DIR_PREV)
+							},
+						},
+						{
+							Name:      "select",
+							Usage:     "Shows, and focuses, the given tab",
+							ArgsUsage: "<node id>",
+							Action: func(ctx *cli.Context) error {
+								if ctx.NArg() != 1 {
+									return errors.New("expected the tab's node id")
+								}
+
+								nodeID, err := strconv.ParseUint(ctx.Args().First(), 0, 32)
+								if err != nil {
+									return fmt.Errorf("invalid node id %q: %w", ctx.Args().First(), err)
+								}
+
+								c, err := grpc.NewClient()
+								if err != nil {
+									return err
+								}
+								defer c.Close()
+
+								req := &bspm.TabbedSelectRequest{
+									NodeId: uint32(nodeID),
+								}
+
+								if _, err := c.TabbedSelect(ctx.Context, req); err != nil {
+									return fmt.Errorf("failed to select tab: %w", err)
+								}
+
+								return nil
+							},
+						},
+					},
+				},
+				{
+					Name:  "mark",
+					Usage: "Labels windows with a letter, to jump back to them from anywhere",
+					Subcommands: []*cli.Command{
+						{
+							Name:      "set",
+							Usage:     "Labels the focused window with the mark",
+							ArgsUsage: "<letter>",
+							Action: func(ctx *cli.Context) error {
+								if ctx.NArg() != 1 {
+									return errors.New("expected the mark")
+								}
+
+								c, err := grpc.NewClient()
+								if err != nil {
+									return err
+								}
+								defer c.Close()
+
+								req := &bspm.MarkSetRequest{
+									Mark: ctx.Args().First(),
+								}
+
+								if _, err := c.MarkSet(ctx.Context, req); err != nil {
+									return fmt.Errorf("failed to set mark: %w", err)
+								}
+
+								return nil
+							},
+						},
+						{
+							Name:      "jump",
+							Usage:     "Focuses the window with the mark, wherever it is",
+							ArgsUsage: "<letter>",
+							Action: func(ctx *cli.Context) error {
+								if ctx.NArg() != 1 {
+									return errors.New("expected the mark")
+								}
+
+								c, err := grpc.NewClient()
+								if err != nil {
+									return err
+								}
+								defer c.Close()
+
+								req := &bspm.MarkJumpRequest{
+									Mark: ctx.Args().First(),
+								}
+
+								if _, err := c.MarkJump(ctx.Context, req); err != nil {
+									return fmt.Errorf("failed to jump to mark: %w", err)
+								}
+
+								return nil
+							},
+						},
+					},
+				},
+				{
+					Name:  "focus",
+					Usage: "Goes back to the windows focused before, across every desktop",
+					Subcommands: []*cli.Command{
+						{
+							Name:  "back",
+							Usage: "Focuses the window focused before the current one, going further back each time",
+							Action: func(ctx *cli.Context) error {
+								c, err := grpc.NewClient()
+								if err != nil {
+									return err
+								}
+								defer c.Close()
+
+								if _, err := c.FocusBack(ctx.Context, &empty.Empty{}); err != nil {
+									return fmt.Errorf("failed to go back in focus history: %w", err)
+								}
+
+								return nil
+							},
+						},
+						{
+							Name:  "forward",
+							Usage: "Undoes going back",
+							Action: func(ctx *cli.Context) error {
+								c, err := grpc.NewClient()
+								if err != nil {
+									return err
+								}
+								defer c.Close()
+
+								if _, err := c.FocusForward(ctx.Context, &empty.Empty{}); err != nil {
+									return fmt.Errorf("failed to go forward in focus history: %w", err)
+								}
+
+								return nil
+							},
+						},
+						{
+							Name:  "list",
+							Usage: "Prints the windows in the history, the most recently focused first (e.g. for a picker)",
+							Action: func(ctx *cli.Context) error {
+								c, err := grpc.NewClient()
+								if err != nil {
+									return err
+								}
+								defer c.Close()
+
+								res, err := c.FocusHistoryList(ctx.Context, &empty.Empty{})
+								if err != nil {
+									return fmt.Errorf("failed to list focus history: %w", err)
+								}
+
+								for _, e := range res.GetEntries() {
+									fmt.Printf("0x%08X %s: %s\n", e.GetNodeId(), e.GetClassName(), e.GetTitle())
+								}
+
+								return nil
+							},
+						},
+						{
+							Name:  "cycle",
+							Usage: "Cycles through the windows alt-tab style, only reordering them once committed",
+							Subcommands: []*cli.Command{
+								{
+									Name:  "start",
+									Usage: "Starts a cycle from the most recently focused window (e.g. when alt is pressed)",
+									Action: func(ctx *cli.Context) error {
+										c, err := grpc.NewClient()
+										if err != nil {
+											return err
+										}
+										defer c.Close()
+
+										if _, err := c.FocusCycleStart(ctx.Context, &empty.Empty{}); err != nil {
+											return fmt.Errorf("failed to start focus cycle: %w", err)
+										}
+
+										return nil
+									},
+								},
+								{
+									Name:  "next",
+									Usage: "Focuses the next window in the cycle, starting one if needed",
+									Action: func(ctx *cli.Context) error {
+										return cycleFocus(ctx, bspm.CycleDir_CYCLE_DIR_NEXT)
+									},
+								},
+								{
+									Name:  "prev",
+									Usage: "Focuses the previous window in the cycle, starting one if needed",
+									Action: func(ctx *cli.Context) error {
+										return cycleFocus(ctx, bspm.CycleDir_CYCLE_DIR_PREV)
+									},
+								},
+								{
+									Name:  "commit",
+									Usage: "Ends the cycle on the focused window (e.g. when alt is released)",
+									Action: func(ctx *cli.Context) error {
+										c, err := grpc.NewClient()
+										if err != nil {
+											return err
+										}
+										defer c.Close()
+
+										if _, err := c.FocusCycleCommit(ctx.Context, &empty.Empty{}); err != nil {
+											return fmt.Errorf("failed to commit focus cycle: %w", err)
+										}
+
+										return nil
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					Name:  "minimize",
+					Usage: "Hides the focused window away, until it's restored",
+					Action: func(ctx *cli.Context) error {
+						c, err := grpc.NewClient()
+						if err != nil {
+							return err
+						}
+						defer c.Close()
+
+						if _, err := c.MinimizeFocused(ctx.Context, &empty.Empty{}); err != nil {
+							return fmt.Errorf("failed to minimise window: %w", err)
+						}
+
+						return nil
+					},
+				},
+				{
+					Name:      "restore",
+					Usage:     "Brings back a minimised window (the last one minimised, by default), and focuses it",
+					ArgsUsage: "[<node id>|" + restoreLast + "|" + restorePick + "]",
+					Flags: []cli.Flag{
+						&cli.BoolFlag{
+							Name:  flagKeyRestoreHere,
+							Usage: "Bring it to the focused desktop, instead of the spot it was minimised from",
+						},
+						&cli.StringFlag{
+							Name:  flagKeyRestorePicker,
+							Usage: "Command that picks one of the minimised windows, given one per line, when using " + restorePick,
+							Value: "dmenu -l 10 -p restore",
+						},
+					},
+					Action: func(ctx *cli.Context) error {
+						if ctx.NArg() > 1 {
+							return errors.New("expected a single window to restore")
+						}
+
+						c, err := grpc.NewClient()
+						if err != nil {
+							return err
+						}
+						defer c.Close()
+
+						nodeID, err := restoreNodeID(ctx.Context, c, ctx.Args().First(), ctx.String(flagKeyRestorePicker))
+						if err != nil {
+							return err
+						}
+
+						req := &bspm.MinimizeRestoreRequest{
+							NodeId:           nodeID,
+							ToFocusedDesktop: ctx.Bool(flagKeyRestoreHere),
+						}
+
+						if _, err := c.MinimizeRestore(ctx.Context, req); err != nil {
+							return fmt.Errorf("failed to restore window: %w", err)
+						}
+
+						return nil
+					},
+				},
+				{
+					Name:      "subscribe",
+					Usage:     "Streams bspm's events as JSON, one per line. Streams all topics if none are given",
+					ArgsUsage: "[topic or pattern (e.g. monocle/*)...]",
+					Flags: []cli.Flag{
+						&cli.StringSliceFlag{
+							Name:  flagKeySubscribeDesktop,
+							Usage: "Only stream events for the given desktop id (can be repeated)",
+						},
+						placeholderFlag,
+					},
+					Action: func(ctx *cli.Context) error {
+						req := &bspm.SubscribeRequest{}
+
+						// Patterns might overlap, and each topic should only be streamed once.
+						requested := make(map[bspm.Topic]struct{})
+						for _, name := range ctx.Args().Slice() {
+							topics := grpc.TopicsMatching(name)
+							if len(topics) == 0 {
+								return fmt.Errorf("invalid topic: %s", name)
+							}
+
+							for _, t := range topics {
+								if _, ok := requested[t]; !ok {
+									requested[t] = struct{}{}
+									req.Topics = append(req.Topics, t)
+								}
+							}
+						}
+
+						for _, rawID := range ctx.StringSlice(flagKeySubscribeDesktop) {
+							// Base 0 accepts both the hexadecimal ids returned by bspc and decimal ones.
+							id, err := strconv.ParseUint(rawID, 0, 32)
 							if err != nil {
-								return fmt.Errorf("failed to subscribe to node count in monocle mode: %w", err)
+								return fmt.Errorf("invalid desktop id %s: %w", rawID, err)
 							}
 
-							// TODO: Graceful shutdown
-							for {
+							req.DesktopIds = append(req.DesktopIds, uint32(id))
+						}
+
+						return streamSubscription(ctx.Context, placeholder(ctx), func(ctx context.Context, c grpc.Client) (recvFunc, error) {
+							subscription, err := c.Subscribe(ctx, req)
+							if err != nil {
+								return nil, fmt.Errorf("failed to subscribe: %w", err)
+							}
+
+							return func() (string, error) {
 								msg, err := subscription.Recv()
 								if err != nil {
-									if errors.Is(err, io.EOF) {
-										return nil
-									}
+									return "", fmt.Errorf("failed to receive message from subscription: %w", err)
+								}
 
-									return fmt.Errorf("failed to receive message from monocle mode node count subscription: %w", err)
+								bb, err := protojson.Marshal(msg)
+								if err != nil {
+									return "", fmt.Errorf("failed to encode subscription message: %w", err)
 								}
 
-								fmt.Println(msg.GetNodeCount())
+								return string(bb), nil
+							}, nil
+						})
+					},
+				},
+				{
+					Name:      "events",
+					Usage:     "Streams bspwm events as JSON, one per line, with the class and title of the nodes involved",
+					ArgsUsage: "[event_type...]",
+					Flags: []cli.Flag{
+						placeholderFlag,
+					},
+					Action: func(ctx *cli.Context) error {
+						req := &bspm.EventsRequest{}
+						for _, t := range ctx.Args().Slice() {
+							if !eventforwarding.IsForwarded(bspc.EventType(t)) {
+								return fmt.Errorf("invalid event type: %s", t)
 							}
-						default:
-							return errors.New("unexpected error")
+
+							req.EventTypes = append(req.EventTypes, t)
+						}
+
+						return streamSubscription(ctx.Context, placeholder(ctx), func(ctx context.Context, c grpc.Client) (recvFunc, error) {
+							events, err := c.Events(ctx, req)
+							if err != nil {
+								return nil, fmt.Errorf("failed to subscribe to events: %w", err)
+							}
+
+							return func() (string, error) {
+								msg, err := events.Recv()
+								if err != nil {
+									return "", fmt.Errorf("failed to receive event: %w", err)
+								}
+
+								ev := eventforwarding.Event{
+									Type:    bspc.EventType(msg.GetEventType()),
+									Payload: json.RawMessage(msg.GetPayload()),
+								}
+
+								for _, n := range msg.GetNodes() {
+									ev.Nodes = append(ev.Nodes, eventforwarding.Node{
+										ID:           bspc.ID(n.GetId()),
+										ClassName:    n.GetClassName(),
+										InstanceName: n.GetInstanceName(),
+										Title:        n.GetTitle(),
+									})
+								}
+
+								bb, err := json.Marshal(ev)
+								if err != nil {
+									return "", fmt.Errorf("failed to encode event: %w", err)
+								}
+
+								return string(bb), nil
+							}, nil
+						})
+					},
+				},
+				{
+					Name:  "metrics",
+					Usage: "Prints how many messages were dropped, per topic, because subscribers weren't keeping up, and how long bspwm event callbacks take",
+					Action: func(ctx *cli.Context) error {
+						c, err := grpc.NewClient()
+						if err != nil {
+							return err
+						}
+						defer c.Close()
+
+						res, err := c.Metrics(ctx.Context, &empty.Empty{})
+						if err != nil {
+							return fmt.Errorf("failed to get metrics: %w", err)
+						}
+
+						for _, m := range res.GetTopics() {
+							fmt.Printf("%s\tdropped=%d\tdisconnected=%d\n", m.GetTopic(), m.GetDropped(), m.GetDisconnected())
+						}
+
+						for _, m := range res.GetCallbacks() {
+							fmt.Printf("%s\tcalls=%d\ttotal=%s\tmax=%s\n",
+								m.GetEventType(),
+								m.GetCalls(),
+								m.GetTotal().AsDuration(),
+								m.GetMax().AsDuration(),
+							)
 						}
 
 						return nil
@@ -157,7 +870,20 @@ func New(logger *zap.Logger, version string) app {
 						return fmt.Errorf("failed to initialize logger: %v", err)
 					}
 
-					return runDaemon(l, subscriptionManager)
+					icons, err := desktopIcons(ctx)
+					if err != nil {
+						return err
+					}
+
+					return runDaemon(l, subscriptionManager, ctx.Bool(flagKeyDBus), swallow.Config{
+						SwallowClasses:   ctx.StringSlice(flagKeySwallow),
+						NoSwallowClasses: ctx.StringSlice(flagKeyNoSwallow),
+					}, ctx.Bool(flagKeyDynamicDesktops), dynamicdesktops.Config{
+						Naming: ctx.String(flagKeyDesktopNaming),
+						Pinned: ctx.StringSlice(flagKeyPinnedDesktop),
+					}, ctx.Bool(flagKeyDesktopLabels), desktoplabels.Config{
+						Icons: icons,
+					})
 				}
 
 				return errors.New("invalid arguments")
@@ -166,6 +892,86 @@ func New(logger *zap.Logger, version string) app {
 	}
 }
 
+// cycleTabs shows the tab next to the selected one, in the given direction.
+func cycleTabs(ctx *cli.Context, direction bspm.CycleDir) error {
+	c, err := grpc.NewClient()
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	req := &bspm.TabbedCycleRequest{
+		CycleDirection: direction,
+	}
+
+	if _, err := c.TabbedCycle(ctx.Context, req); err != nil {
+		return fmt.Errorf("failed to cycle tabs: %w", err)
+	}
+
+	return nil
+}
+
+// cycleFocus focuses the window next to the current one in the alt-tab cycle, in the given direction.
+func cycleFocus(ctx *cli.Context, direction bspm.CycleDir) error {
+	c, err := grpc.NewClient()
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	req := &bspm.FocusCycleStepRequest{
+		CycleDirection: direction,
+	}
+
+	if _, err := c.FocusCycleStep(ctx.Context, req); err != nil {
+		return fmt.Errorf("failed to cycle focus: %w", err)
+	}
+
+	return nil
+}
+
+// layoutDelta returns 1 or -1, depending on whether the layout setting is to be increased or decreased.
+func layoutDelta(ctx *cli.Context) (int, error) {
+	var (
+		isInc = ctx.Bool(flagKeyLayoutInc)
+		isDec = ctx.Bool(flagKeyLayoutDec)
+	)
+
+	switch {
+	case isInc && !isDec:
+		return 1, nil
+	case isDec && !isInc:
+		return -1, nil
+	default:
+		return 0, fmt.Errorf("expected either --%s or --%s", flagKeyLayoutInc, flagKeyLayoutDec)
+	}
+}
+
+// placeholder returns the placeholder to print while the daemon is unavailable, if one was given.
+// desktopIcons returns what windows are shown as in desktop labels, by class.
+func desktopIcons(ctx *cli.Context) (map[string]string, error) {
+	icons := make(map[string]string)
+	for _, v := range ctx.StringSlice(flagKeyDesktopIcon) {
+		class, icon, ok := strings.Cut(v, "=")
+		if !ok || class == "" {
+			return nil, fmt.Errorf("invalid desktop icon %q, expected <class>=<icon>", v)
+		}
+
+		icons[class] = icon
+	}
+
+	return icons, nil
+}
+
+func placeholder(ctx *cli.Context) *string {
+	if !ctx.IsSet(flagKeyPlaceholder) {
+		return nil
+	}
+
+	p := ctx.String(flagKeyPlaceholder)
+	return &p
+}
+
 func (a app) Run() error {
 	if err := a.cli.Run(os.Args); err != nil {
 		return err
@@ -8,33 +8,105 @@ import (
 
 	"github.com/diogox/bspc-go"
 	"github.com/fatih/color"
+	godbus "github.com/godbus/dbus/v5"
+	"go.uber.org/zap"
 
 	"github.com/diogox/bspm/internal/bspwm"
 	bspwmdesktop "github.com/diogox/bspm/internal/bspwm/desktop"
 	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
 	bspwmnode "github.com/diogox/bspm/internal/bspwm/node"
+	bspwmtree "github.com/diogox/bspm/internal/bspwm/tree"
+	"github.com/diogox/bspm/internal/dbus"
+	desktoplabels "github.com/diogox/bspm/internal/feature/desktop_labels"
+	dynamicdesktops "github.com/diogox/bspm/internal/feature/dynamic_desktops"
+	eventforwarding "github.com/diogox/bspm/internal/feature/event_forwarding"
+	focushistory "github.com/diogox/bspm/internal/feature/focus_history"
+	"github.com/diogox/bspm/internal/feature/layout"
+	"github.com/diogox/bspm/internal/feature/mark"
+	"github.com/diogox/bspm/internal/feature/minimize"
+	"github.com/diogox/bspm/internal/feature/scratchpad"
+	"github.com/diogox/bspm/internal/feature/swallow"
+	"github.com/diogox/bspm/internal/feature/tabbed"
 	transparentmonocle "github.com/diogox/bspm/internal/feature/transparent_monocle"
 	"github.com/diogox/bspm/internal/feature/transparent_monocle/state"
 	"github.com/diogox/bspm/internal/grpc"
 	"github.com/diogox/bspm/internal/log"
+	"github.com/diogox/bspm/internal/process"
 	"github.com/diogox/bspm/internal/subscription"
+	"github.com/diogox/bspm/internal/x11"
 )
 
-func runDaemon(logger *log.Logger, subscriptionManager subscription.Manager) error {
+func runDaemon(
+	logger *log.Logger,
+	subscriptionManager subscription.Manager,
+	isDBus bool,
+	swallowConfig swallow.Config,
+	isDynamicDesktops bool,
+	dynamicDesktopsConfig dynamicdesktops.Config,
+	isDesktopLabels bool,
+	desktopLabelsConfig desktoplabels.Config,
+) error {
 	bspwmClient, err := bspc.New(logger.WithoutFields())
 	if err != nil {
 		return fmt.Errorf("failed to initialise bspwm client: %v", err)
 	}
 
+	var (
+		echoes       = bspwmevent.NewEchoes()
+		eventManager = bspwmevent.NewManager(logger, bspwmClient, subscriptionManager, echoes)
+		timings      = bspwmevent.NewTimings()
+	)
+
+	eventManager.Use(bspwmevent.Timing(timings.Observe))
+
+	service := bspwm.NewService(
+		bspwmClient,
+		bspwmdesktop.NewService(bspwmClient, echoes),
+		bspwmnode.NewService(bspwmClient, echoes),
+		eventManager,
+	)
+
+	tree, cancelTree, err := bspwmtree.Start(logger, service)
+	if err != nil {
+		return err
+	}
+	defer cancelTree()
+
+	windows, err := x11.NewProperties()
+	if err != nil {
+		// Events can still be forwarded, just without window titles.
+		logger.Warning("failed to read window properties", zap.Error(err))
+	}
+
+	events := eventforwarding.Start(logger, service, tree, subscriptionManager, windows)
+
+	scratchpads, cancelScratchpads := scratchpad.Start(logger, service, tree, subscriptionManager)
+	defer cancelScratchpads()
+
+	layouts, cancelLayouts := layout.Start(logger, service, tree, echoes, subscriptionManager)
+	defer cancelLayouts()
+
+	tabbedContainers, cancelTabbed := tabbed.Start(logger, service, tree, windows, echoes, subscriptionManager)
+	defer cancelTabbed()
+
+	minimizer, cancelMinimize := minimize.Start(logger, service, tree, windows, subscriptionManager)
+	defer cancelMinimize()
+
+	if len(swallowConfig.SwallowClasses) > 0 {
+		if windows == nil {
+			// Without window properties, there's no telling which process a window belongs to.
+			logger.Warning("swallowing disabled, since window properties can't be read")
+		} else {
+			cancelSwallow := swallow.Start(logger, service, tree, windows, process.NewTree(), swallowConfig)
+			defer cancelSwallow()
+		}
+	}
+
 	monocle, cancel, err := transparentmonocle.Start(
 		logger,
 		state.NewTransparentMonocle(subscriptionManager),
-		bspwm.NewService(
-			bspwmClient,
-			bspwmdesktop.NewService(bspwmClient),
-			bspwmnode.NewService(bspwmClient),
-			bspwmevent.NewManager(logger, bspwmClient),
-		),
+		service,
+		tree,
 		subscriptionManager,
 	)
 	if err != nil {
@@ -42,10 +114,57 @@ func runDaemon(logger *log.Logger, subscriptionManager subscription.Manager) err
 	}
 	defer cancel()
 
+	marks, cancelMarks := mark.Start(logger, service, tree, monocle, mark.NewFileStore(mark.DefaultPath()))
+	defer cancelMarks()
+
+	focusHistory, cancelFocusHistory := focushistory.Start(logger, service, tree, windows, monocle)
+	defer cancelFocusHistory()
+
+	var labels desktoplabels.Labels
+	if isDesktopLabels {
+		var cancelDesktopLabels func()
+		labels, cancelDesktopLabels = desktoplabels.Start(logger, service, tree, desktopLabelsConfig)
+		defer cancelDesktopLabels()
+	}
+
+	if isDynamicDesktops {
+		cancelDynamicDesktops, err := dynamicdesktops.Start(logger, service, labels, dynamicDesktopsConfig)
+		if err != nil {
+			return fmt.Errorf("failed to start dynamic desktops: %w", err)
+		}
+		defer cancelDynamicDesktops()
+	}
+
+	if isDBus {
+		conn, err := godbus.ConnectSessionBus()
+		if err != nil {
+			return fmt.Errorf("failed to connect to session bus: %w", err)
+		}
+		defer conn.Close()
+
+		cancelDBus, err := dbus.Start(logger, conn, monocle, subscriptionManager)
+		if err != nil {
+			return fmt.Errorf("failed to start dbus service: %w", err)
+		}
+		defer cancelDBus()
+	}
+
 	color.Blue("Daemon Running...")
 	logger.Info("daemon started")
 
-	startServer, stopServer := grpc.NewServer(logger, monocle)
+	startServer, stopServer := grpc.NewServer(
+		logger,
+		monocle,
+		events,
+		scratchpads,
+		layouts,
+		tabbedContainers,
+		minimizer,
+		marks,
+		focusHistory,
+		subscriptionManager,
+		timings,
+	)
 
 	go func() {
 		exitCh := make(chan os.Signal, 1)
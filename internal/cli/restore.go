@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/diogox/bspm/internal/grpc"
+	"github.com/diogox/bspm/internal/grpc/bspm"
+)
+
+const (
+	restoreLast = "last"
+	restorePick = "pick"
+)
+
+// restoreNodeID returns the id of the minimised window to restore, or zero for the last one minimised.
+// Picking one lists the minimised windows, one per line, on the picker's stdin and takes the one it prints.
+func restoreNodeID(ctx context.Context, c grpc.Client, selector string, picker string) (uint32, error) {
+	switch selector {
+	case "", restoreLast:
+		return 0, nil
+	case restorePick:
+		return pickMinimized(ctx, c, picker)
+	default:
+		// Base 0 accepts both the hexadecimal ids returned by bspc and decimal ones.
+		id, err := strconv.ParseUint(selector, 0, 32)
+		if err != nil {
+			return 0, fmt.Errorf("invalid node id %q: %w", selector, err)
+		}
+
+		return uint32(id), nil
+	}
+}
+
+func pickMinimized(ctx context.Context, c grpc.Client, picker string) (uint32, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// The minimised windows are retained, so they're the first message received.
+	subscription, err := c.Subscribe(ctx, &bspm.SubscribeRequest{
+		Topics: []bspm.Topic{bspm.Topic_TOPIC_MINIMIZED_CHANGED},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to subscribe to minimised windows: %w", err)
+	}
+
+	msg, err := subscription.Recv()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get minimised windows: %w", err)
+	}
+
+	windows := msg.GetMinimizedState().GetWindows()
+	if len(windows) == 0 {
+		return 0, errors.New("no window is minimised")
+	}
+
+	var lines strings.Builder
+	for i := len(windows) - 1; i >= 0; i-- {
+		w := windows[i]
+		fmt.Fprintf(&lines, "0x%08X %s: %s\n", w.GetNodeId(), w.GetClassName(), w.GetTitle())
+	}
+
+	var out bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", picker)
+	cmd.Stdin = strings.NewReader(lines.String())
+	cmd.Stdout = &out
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return 0, fmt.Errorf("failed to run picker: %w", err)
+	}
+
+	fields := strings.Fields(out.String())
+	if len(fields) == 0 {
+		return 0, errors.New("no window picked")
+	}
+
+	id, err := strconv.ParseUint(fields[0], 0, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid window picked %q: %w", fields[0], err)
+	}
+
+	return uint32(id), nil
+}
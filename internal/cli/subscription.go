@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/diogox/bspm/internal/grpc"
+)
+
+const (
+	minReconnectBackoff = 250 * time.Millisecond
+	maxReconnectBackoff = 5 * time.Second
+)
+
+type (
+	// recvFunc returns the next line to print from a subscription.
+	recvFunc func() (string, error)
+
+	// subscribeFunc opens a subscription with the daemon.
+	subscribeFunc func(ctx context.Context, c grpc.Client) (recvFunc, error)
+
+	// subscriptionStream prints the lines received from a subscription, subscribing again whenever the daemon
+	// becomes unavailable.
+	subscriptionStream struct {
+		out         io.Writer
+		placeholder *string
+		subscribe   subscribeFunc
+		connect     func() (grpc.Client, error)
+
+		// after is what the backoff is waited for with.
+		after      func(d time.Duration) <-chan time.Time
+		minBackoff time.Duration
+		maxBackoff time.Duration
+	}
+)
+
+// streamSubscription prints every line received from the subscription until it's interrupted by SIGINT or SIGTERM.
+// If the daemon becomes unavailable, it prints the placeholder (if there is one) and keeps trying to
+// subscribe again, with an exponential backoff.
+func streamSubscription(ctx context.Context, placeholder *string, subscribe subscribeFunc) error {
+	s := subscriptionStream{
+		out:         os.Stdout,
+		placeholder: placeholder,
+		subscribe:   subscribe,
+		connect:     grpc.NewClient,
+		after:       time.After,
+		minBackoff:  minReconnectBackoff,
+		maxBackoff:  maxReconnectBackoff,
+	}
+
+	return s.run(ctx)
+}
+
+func (s subscriptionStream) run(ctx context.Context) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var (
+		backoff       = s.minBackoff
+		isUnavailable bool
+	)
+
+	for {
+		err := s.receive(ctx, func(line string) {
+			backoff = s.minBackoff
+			isUnavailable = false
+
+			fmt.Fprintln(s.out, line)
+		})
+		if ctx.Err() != nil {
+			// Interrupted
+			return nil
+		}
+
+		if !isDaemonUnavailable(err) {
+			return err
+		}
+
+		if !isUnavailable && s.placeholder != nil {
+			fmt.Fprintln(s.out, *s.placeholder)
+		}
+
+		isUnavailable = true
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.after(backoff):
+		}
+
+		if backoff *= 2; backoff > s.maxBackoff {
+			backoff = s.maxBackoff
+		}
+	}
+}
+
+// receive opens a new connection on every call, so it doesn't have to wait for an existing one to reconnect.
+func (s subscriptionStream) receive(ctx context.Context, print func(line string)) error {
+	c, err := s.connect()
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	recv, err := s.subscribe(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	for {
+		line, err := recv()
+		if err != nil {
+			return err
+		}
+
+		print(line)
+	}
+}
+
+// isDaemonUnavailable returns true if the error means the daemon went away, or isn't running.
+func isDaemonUnavailable(err error) bool {
+	if errors.Is(err, io.EOF) {
+		// The daemon closed the stream, because it's shutting down.
+		return true
+	}
+
+	for ; err != nil; err = errors.Unwrap(err) {
+		if s, ok := status.FromError(err); ok && s.Code() == codes.Unavailable {
+			return true
+		}
+	}
+
+	return false
+}
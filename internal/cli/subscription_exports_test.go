@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/diogox/bspm/internal/grpc"
+)
+
+// testClient stands in for the daemon's client, for subscriptions that don't use it.
+type testClient struct {
+	grpc.Client
+}
+
+func (testClient) Close() error {
+	return nil
+}
+
+// StreamSubscriptionWith streams the subscription to out, waiting for the backoff with after, so tests neither need
+// a daemon nor have to wait for the backoff.
+func StreamSubscriptionWith(
+	ctx context.Context,
+	out io.Writer,
+	placeholder *string,
+	subscribe func(ctx context.Context) (func() (string, error), error),
+	after func(d time.Duration) <-chan time.Time,
+) error {
+	s := subscriptionStream{
+		out:         out,
+		placeholder: placeholder,
+		subscribe: func(ctx context.Context, _ grpc.Client) (recvFunc, error) {
+			return subscribe(ctx)
+		},
+		connect: func() (grpc.Client, error) {
+			return testClient{}, nil
+		},
+		after:      after,
+		minBackoff: minReconnectBackoff,
+		maxBackoff: maxReconnectBackoff,
+	}
+
+	return s.run(ctx)
+}
@@ -0,0 +1,223 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/diogox/bspm/internal/cli"
+)
+
+var errUnavailable = status.Error(codes.Unavailable, "daemon is not running")
+
+// attempt is how subscribing goes: the lines received, and the error that ends it. Without lines, subscribing fails.
+type attempt struct {
+	lines []string
+	err   error
+}
+
+// fakeDaemon goes through the attempts in order, and stops the stream once they're over.
+type fakeDaemon struct {
+	attempts []attempt
+	waits    []time.Duration
+	cancel   context.CancelFunc
+}
+
+func newFakeDaemon(cancel context.CancelFunc, attempts ...attempt) *fakeDaemon {
+	return &fakeDaemon{
+		attempts: attempts,
+		cancel:   cancel,
+	}
+}
+
+func (fd *fakeDaemon) subscribe(context.Context) (func() (string, error), error) {
+	if len(fd.attempts) == 0 {
+		fd.cancel()
+		return nil, errUnavailable
+	}
+
+	a := fd.attempts[0]
+	fd.attempts = fd.attempts[1:]
+
+	if len(a.lines) == 0 {
+		return nil, a.err
+	}
+
+	lines := a.lines
+
+	return func() (string, error) {
+		if len(lines) == 0 {
+			return "", a.err
+		}
+
+		line := lines[0]
+		lines = lines[1:]
+
+		return line, nil
+	}, nil
+}
+
+// after records the backoff, without waiting for it.
+func (fd *fakeDaemon) after(d time.Duration) <-chan time.Time {
+	fd.waits = append(fd.waits, d)
+
+	ch := make(chan time.Time, 1)
+	ch <- time.Now()
+
+	return ch
+}
+
+func TestStreamSubscription(t *testing.T) {
+	t.Run("should print every line received", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			out bytes.Buffer
+			fd  = newFakeDaemon(cancel, attempt{lines: []string{"1", "2"}, err: errUnavailable})
+		)
+
+		err := cli.StreamSubscriptionWith(ctx, &out, nil, fd.subscribe, fd.after)
+		require.NoError(t, err)
+
+		assert.Equal(t, "1\n2\n", out.String())
+	})
+	t.Run("should return error when it isn't the daemon being unavailable", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			out         bytes.Buffer
+			expectedErr = errors.New("error")
+			fd          = newFakeDaemon(cancel, attempt{err: expectedErr})
+		)
+
+		err := cli.StreamSubscriptionWith(ctx, &out, nil, fd.subscribe, fd.after)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, expectedErr))
+	})
+	t.Run("should back off exponentially while daemon is unavailable", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			out      bytes.Buffer
+			attempts = make([]attempt, 8)
+		)
+
+		for i := range attempts {
+			attempts[i] = attempt{err: errUnavailable}
+		}
+
+		fd := newFakeDaemon(cancel, attempts...)
+
+		err := cli.StreamSubscriptionWith(ctx, &out, nil, fd.subscribe, fd.after)
+		require.NoError(t, err)
+
+		assert.Equal(t, []time.Duration{
+			250 * time.Millisecond,
+			500 * time.Millisecond,
+			time.Second,
+			2 * time.Second,
+			4 * time.Second,
+			5 * time.Second,
+			5 * time.Second,
+			5 * time.Second,
+		}, fd.waits)
+	})
+	t.Run("should reset backoff once a line is received", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			out bytes.Buffer
+			fd  = newFakeDaemon(cancel,
+				attempt{err: errUnavailable},
+				attempt{err: errUnavailable},
+				attempt{lines: []string{"1"}, err: io.EOF},
+				attempt{err: errUnavailable},
+			)
+		)
+
+		err := cli.StreamSubscriptionWith(ctx, &out, nil, fd.subscribe, fd.after)
+		require.NoError(t, err)
+
+		assert.Equal(t, []time.Duration{
+			250 * time.Millisecond,
+			500 * time.Millisecond,
+			250 * time.Millisecond,
+			500 * time.Millisecond,
+		}, fd.waits)
+	})
+	t.Run("should print placeholder once every time daemon becomes unavailable", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			out         bytes.Buffer
+			placeholder = "-"
+			fd          = newFakeDaemon(cancel,
+				attempt{err: errUnavailable},
+				attempt{err: errUnavailable},
+				attempt{lines: []string{"1"}, err: io.EOF},
+				attempt{err: errUnavailable},
+			)
+		)
+
+		err := cli.StreamSubscriptionWith(ctx, &out, &placeholder, fd.subscribe, fd.after)
+		require.NoError(t, err)
+
+		assert.Equal(t, "-\n1\n-\n", out.String())
+	})
+	t.Run("should stop when context is done while waiting to subscribe again", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			out bytes.Buffer
+			fd  = newFakeDaemon(cancel, attempt{err: errUnavailable})
+		)
+
+		err := cli.StreamSubscriptionWith(ctx, &out, nil, fd.subscribe, func(time.Duration) <-chan time.Time {
+			cancel()
+			return nil
+		})
+		require.NoError(t, err)
+
+		assert.Empty(t, fd.attempts)
+	})
+	t.Run("should stop when interrupted", func(t *testing.T) {
+		var out bytes.Buffer
+
+		subscribe := func(ctx context.Context) (func() (string, error), error) {
+			assert.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGTERM))
+
+			return func() (string, error) {
+				<-ctx.Done()
+				return "", ctx.Err()
+			}, nil
+		}
+
+		done := make(chan error, 1)
+		go func() {
+			done <- cli.StreamSubscriptionWith(context.Background(), &out, nil, subscribe, time.After)
+		}()
+
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for the stream to stop")
+		}
+	})
+}
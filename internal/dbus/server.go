@@ -0,0 +1,227 @@
+package dbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/diogox/bspc-go"
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/introspect"
+	"go.uber.org/zap"
+
+	transparentmonocle "github.com/diogox/bspm/internal/feature/transparent_monocle"
+	"github.com/diogox/bspm/internal/feature/transparent_monocle/state"
+	"github.com/diogox/bspm/internal/feature/transparent_monocle/topic"
+	"github.com/diogox/bspm/internal/log"
+	"github.com/diogox/bspm/internal/subscription"
+)
+
+const (
+	BusName       = "com.github.diogox.bspm"
+	ObjectPath    = dbus.ObjectPath("/com/github/diogox/bspm")
+	InterfaceName = BusName + ".Monocle"
+)
+
+const (
+	SignalMonocleEnabled      = "MonocleEnabled"
+	SignalMonocleDisabled     = "MonocleDisabled"
+	SignalMonocleStateChanged = "MonocleStateChanged"
+	SignalDesktopFocusChanged = "DesktopFocusChanged"
+	SignalNodeCountChanged    = "NodeCountChanged"
+)
+
+const errNameFeatureNotEnabled = BusName + ".Error.FeatureNotEnabled"
+
+var stateSignalArgs = []introspect.Arg{
+	{Name: "desktop_id", Type: "u"},
+	{Name: "selected_node_id", Type: "u"},
+	{Name: "hidden_node_ids", Type: "au"},
+}
+
+var introspection = introspect.Node{
+	Name: string(ObjectPath),
+	Interfaces: []introspect.Interface{
+		introspect.IntrospectData,
+		{
+			Name: InterfaceName,
+			Methods: []introspect.Method{
+				{Name: "ToggleCurrentDesktop"},
+				{Name: "FocusPreviousHiddenNode"},
+				{Name: "FocusNextHiddenNode"},
+			},
+			Signals: []introspect.Signal{
+				{Name: SignalMonocleEnabled, Args: stateSignalArgs},
+				{Name: SignalMonocleDisabled, Args: stateSignalArgs},
+				{Name: SignalMonocleStateChanged, Args: stateSignalArgs},
+				{Name: SignalDesktopFocusChanged, Args: []introspect.Arg{{Name: "monitor_id", Type: "u"}, {Name: "desktop_id", Type: "u"}}},
+				{Name: SignalNodeCountChanged, Args: []introspect.Arg{{Name: "node_count", Type: "i"}}},
+			},
+		},
+	},
+}
+
+// Start exports the transparent monocle feature on the given bus connection, under the bspm bus name,
+// and starts emitting signals for the subscription topics it publishes.
+func Start(
+	logger *log.Logger,
+	conn *dbus.Conn,
+	monocleService transparentmonocle.Feature,
+	subscriptions subscription.Manager,
+) (func(), error) {
+	s := &server{
+		logger:         logger,
+		conn:           conn,
+		monocleService: monocleService,
+	}
+
+	if err := conn.Export(s, ObjectPath, InterfaceName); err != nil {
+		return nil, fmt.Errorf("failed to export monocle interface: %w", err)
+	}
+
+	if err := conn.Export(introspect.NewIntrospectable(&introspection), ObjectPath, introspect.IntrospectData.Name); err != nil {
+		return nil, fmt.Errorf("failed to export introspection data: %w", err)
+	}
+
+	reply, err := conn.RequestName(BusName, dbus.NameFlagDoNotQueue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request bus name: %w", err)
+	}
+
+	if reply != dbus.RequestNameReplyPrimaryOwner {
+		return nil, fmt.Errorf("bus name %s already taken", BusName)
+	}
+
+	ctx, cancelSubscriptions := context.WithCancel(context.Background())
+
+	var (
+		stateCh        = state.ChangedTopic.Subscribe(ctx, subscriptions)
+		enabledCh      = state.EnabledTopic.Subscribe(ctx, subscriptions)
+		disabledCh     = state.DisabledTopic.Subscribe(ctx, subscriptions)
+		desktopFocusCh = topic.MonocleDesktopFocusChanged.Subscribe(ctx, subscriptions)
+		countCh        = monocleService.SubscribeNodeCount(ctx)
+	)
+
+	// Subscriptions are closed once the context is done, so receiving from a closed one means it's time to stop.
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case ev, ok := <-stateCh:
+				if !ok {
+					return
+				}
+
+				s.emitState(SignalMonocleStateChanged, ev)
+
+			case ev, ok := <-enabledCh:
+				if !ok {
+					return
+				}
+
+				s.emitState(SignalMonocleEnabled, ev)
+
+			case ev, ok := <-disabledCh:
+				if !ok {
+					return
+				}
+
+				s.emitState(SignalMonocleDisabled, ev)
+
+			case ev, ok := <-desktopFocusCh:
+				if !ok {
+					return
+				}
+
+				s.emitDesktopFocus(ev)
+
+			case count, ok := <-countCh:
+				if !ok {
+					return
+				}
+
+				s.emit(SignalNodeCountChanged, int32(count))
+			}
+		}
+	}()
+
+	cancel := func() {
+		cancelSubscriptions()
+
+		if _, err := conn.ReleaseName(BusName); err != nil {
+			logger.Error("failed to release bus name", zap.Error(err))
+		}
+	}
+
+	return cancel, nil
+}
+
+type server struct {
+	logger         *log.Logger
+	conn           *dbus.Conn
+	monocleService transparentmonocle.Feature
+}
+
+func (s *server) ToggleCurrentDesktop() *dbus.Error {
+	s.logger.Info("Toggling transparent monocle mode")
+
+	if err := s.monocleService.ToggleCurrentDesktop(); err != nil {
+		s.logger.Error("failed to toggle transparent monocle mode", zap.Error(err))
+		return toDBusError(err)
+	}
+
+	return nil
+}
+
+func (s *server) FocusPreviousHiddenNode() *dbus.Error {
+	if err := s.monocleService.FocusPreviousHiddenNode(); err != nil {
+		return toDBusError(err)
+	}
+
+	return nil
+}
+
+func (s *server) FocusNextHiddenNode() *dbus.Error {
+	if err := s.monocleService.FocusNextHiddenNode(); err != nil {
+		return toDBusError(err)
+	}
+
+	return nil
+}
+
+func (s *server) emitState(signal string, ev state.Event) {
+	selectedNodeID := uint32(bspc.NilID)
+	if ev.State.SelectedNodeID != nil {
+		selectedNodeID = uint32(*ev.State.SelectedNodeID)
+	}
+
+	hiddenNodeIDs := make([]uint32, 0, len(ev.State.HiddenNodeIDs))
+	for _, id := range ev.State.HiddenNodeIDs {
+		hiddenNodeIDs = append(hiddenNodeIDs, uint32(id))
+	}
+
+	s.emit(signal, uint32(ev.DesktopID), selectedNodeID, hiddenNodeIDs)
+}
+
+func (s *server) emitDesktopFocus(ev bspc.EventDesktopFocus) {
+	s.emit(SignalDesktopFocusChanged, uint32(ev.MonitorID), uint32(ev.DesktopID))
+}
+
+func (s *server) emit(signal string, values ...interface{}) {
+	if err := s.conn.Emit(ObjectPath, InterfaceName+"."+signal, values...); err != nil {
+		s.logger.Error("failed to emit signal",
+			zap.String("signal", signal),
+			zap.Error(err),
+		)
+	}
+}
+
+func toDBusError(err error) *dbus.Error {
+	if errors.Is(err, transparentmonocle.ErrFeatureNotEnabled) {
+		return dbus.NewError(errNameFeatureNotEnabled, []interface{}{err.Error()})
+	}
+
+	return dbus.MakeFailedError(err)
+}
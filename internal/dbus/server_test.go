@@ -0,0 +1,269 @@
+package dbus_test
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/diogox/bspc-go"
+	"github.com/godbus/dbus/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	bspmdbus "github.com/diogox/bspm/internal/dbus"
+	transparentmonocle "github.com/diogox/bspm/internal/feature/transparent_monocle"
+	"github.com/diogox/bspm/internal/feature/transparent_monocle/state"
+	"github.com/diogox/bspm/internal/log"
+	"github.com/diogox/bspm/internal/subscription"
+)
+
+const busConfig = `<!DOCTYPE busconfig PUBLIC "-//freedesktop//DTD D-Bus Bus Configuration 1.0//EN"
+ "http://www.freedesktop.org/standards/dbus/1.0/busconfig.dtd">
+<busconfig>
+  <type>session</type>
+  <listen>unix:path=%s</listen>
+  <auth>EXTERNAL</auth>
+  <policy context="default">
+    <allow send_destination="*" eavesdrop="true"/>
+    <allow eavesdrop="true"/>
+    <allow own="*"/>
+  </policy>
+</busconfig>
+`
+
+// startPrivateBus launches a dbus-daemon instance private to the test and returns its address.
+func startPrivateBus(t *testing.T) string {
+	t.Helper()
+
+	daemonPath, err := exec.LookPath("dbus-daemon")
+	if err != nil {
+		t.Skip("dbus-daemon not available")
+	}
+
+	dir := t.TempDir()
+
+	configPath := filepath.Join(dir, "bus.conf")
+	err = os.WriteFile(configPath, []byte(fmt.Sprintf(busConfig, filepath.Join(dir, "bus.socket"))), 0o600)
+	require.NoError(t, err)
+
+	cmd := exec.Command(daemonPath, "--config-file="+configPath, "--nofork", "--print-address")
+
+	stdout, err := cmd.StdoutPipe()
+	require.NoError(t, err)
+	require.NoError(t, cmd.Start())
+
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	})
+
+	address, err := bufio.NewReader(stdout).ReadString('\n')
+	require.NoError(t, err)
+
+	return strings.TrimSpace(address)
+}
+
+func connect(t *testing.T, address string) *dbus.Conn {
+	t.Helper()
+
+	conn, err := dbus.Connect(address)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+func startServer(t *testing.T, mockService *transparentmonocle.MockFeature, subscriptions subscription.Manager) *dbus.Conn {
+	t.Helper()
+
+	address := startPrivateBus(t)
+
+	mockService.EXPECT().
+		SubscribeNodeCount(gomock.Any()).
+		Return(make(chan int))
+
+	logger, err := log.New(zaptest.NewLogger(t), false)
+	require.NoError(t, err)
+
+	cancel, err := bspmdbus.Start(logger, connect(t, address), mockService, subscriptions)
+	require.NoError(t, err)
+	t.Cleanup(cancel)
+
+	return connect(t, address)
+}
+
+func TestStart(t *testing.T) {
+	t.Run("should call feature methods", func(t *testing.T) {
+		tt := []struct {
+			method string
+			expect func(m *transparentmonocle.MockFeature) *gomock.Call
+		}{
+			{
+				method: "ToggleCurrentDesktop",
+				expect: func(m *transparentmonocle.MockFeature) *gomock.Call { return m.EXPECT().ToggleCurrentDesktop() },
+			},
+			{
+				method: "FocusPreviousHiddenNode",
+				expect: func(m *transparentmonocle.MockFeature) *gomock.Call { return m.EXPECT().FocusPreviousHiddenNode() },
+			},
+			{
+				method: "FocusNextHiddenNode",
+				expect: func(m *transparentmonocle.MockFeature) *gomock.Call { return m.EXPECT().FocusNextHiddenNode() },
+			},
+		}
+
+		for _, tc := range tt {
+			t.Run(tc.method, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				mockService := transparentmonocle.NewMockFeature(ctrl)
+				tc.expect(mockService).Return(nil)
+
+				client := startServer(t, mockService, subscription.NewManager())
+
+				err := client.
+					Object(bspmdbus.BusName, bspmdbus.ObjectPath).
+					Call(bspmdbus.InterfaceName+"."+tc.method, 0).
+					Store()
+				assert.NoError(t, err)
+			})
+		}
+	})
+	t.Run("should return error when", func(t *testing.T) {
+		t.Run("feature is not enabled", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := transparentmonocle.NewMockFeature(ctrl)
+			mockService.EXPECT().
+				FocusNextHiddenNode().
+				Return(transparentmonocle.ErrFeatureNotEnabled)
+
+			client := startServer(t, mockService, subscription.NewManager())
+
+			err := client.
+				Object(bspmdbus.BusName, bspmdbus.ObjectPath).
+				Call(bspmdbus.InterfaceName+".FocusNextHiddenNode", 0).
+				Store()
+			require.Error(t, err)
+
+			var dbusErr dbus.Error
+			require.True(t, errors.As(err, &dbusErr))
+			assert.Equal(t, bspmdbus.BusName+".Error.FeatureNotEnabled", dbusErr.Name)
+		})
+		t.Run("feature returns an error", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := transparentmonocle.NewMockFeature(ctrl)
+			mockService.EXPECT().
+				ToggleCurrentDesktop().
+				Return(errors.New("error"))
+
+			client := startServer(t, mockService, subscription.NewManager())
+
+			err := client.
+				Object(bspmdbus.BusName, bspmdbus.ObjectPath).
+				Call(bspmdbus.InterfaceName+".ToggleCurrentDesktop", 0).
+				Store()
+			assert.Error(t, err)
+		})
+	})
+	t.Run("should emit signals for published topics", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			subscriptions  = subscription.NewManager()
+			selectedNodeID = bspc.ID(2)
+			st             = state.State{
+				SelectedNodeID: &selectedNodeID,
+				HiddenNodeIDs:  []bspc.ID{3, 4},
+			}
+		)
+
+		client := startServer(t, transparentmonocle.NewMockFeature(ctrl), subscriptions)
+
+		err := client.AddMatchSignal(dbus.WithMatchInterface(bspmdbus.InterfaceName))
+		require.NoError(t, err)
+
+		signalCh := make(chan *dbus.Signal, 10)
+		client.Signal(signalCh)
+
+		state.EnabledTopic.Publish(subscriptions, state.Event{DesktopID: bspc.ID(1), State: st})
+
+		timeout := time.After(time.Second)
+		for {
+			select {
+			case sig := <-signalCh:
+				if sig.Path != bspmdbus.ObjectPath {
+					// Ignore signals from the bus itself.
+					continue
+				}
+
+				assert.Equal(t, bspmdbus.InterfaceName+"."+bspmdbus.SignalMonocleEnabled, sig.Name)
+				assert.Equal(t, []interface{}{uint32(1), uint32(2), []uint32{3, 4}}, sig.Body)
+
+				return
+			case <-timeout:
+				t.Fatal("timed out waiting for signal")
+			}
+		}
+	})
+	t.Run("should stop emitting signals once a subscription is closed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService = transparentmonocle.NewMockFeature(ctrl)
+			address     = startPrivateBus(t)
+			countCh     = make(chan int)
+		)
+
+		mockService.EXPECT().
+			SubscribeNodeCount(gomock.Any()).
+			Return(countCh)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		cancel, err := bspmdbus.Start(logger, connect(t, address), mockService, subscription.NewManager())
+		require.NoError(t, err)
+		t.Cleanup(cancel)
+
+		client := connect(t, address)
+
+		err = client.AddMatchSignal(dbus.WithMatchInterface(bspmdbus.InterfaceName))
+		require.NoError(t, err)
+
+		signalCh := make(chan *dbus.Signal, 10)
+		client.Signal(signalCh)
+
+		close(countCh)
+
+		timeout := time.After(100 * time.Millisecond)
+		for {
+			select {
+			case sig := <-signalCh:
+				if sig.Path != bspmdbus.ObjectPath {
+					// Ignore signals from the bus itself.
+					continue
+				}
+
+				t.Fatalf("unexpected signal %s", sig.Name)
+			case <-timeout:
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,357 @@
+package desktoplabels
+
+import (
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/diogox/bspc-go"
+	"go.uber.org/zap"
+
+	"github.com/diogox/bspm/internal/bspwm"
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	bspwmtree "github.com/diogox/bspm/internal/bspwm/tree"
+	"github.com/diogox/bspm/internal/log"
+)
+
+const (
+	// Separator goes between a desktop's own name and its label (e.g. 3:firefox). bspwm's commands are split on
+	// whitespace, so names can't have a space after it.
+	Separator = ":"
+
+	// labelSeparator goes between the labels of a desktop's windows (e.g. 3:firefox,alacritty).
+	labelSeparator = ","
+)
+
+// debounceDelay is how long changes are collected for before desktops are renamed, so a burst of events (e.g. a
+// layout being applied) renames each desktop once.
+const debounceDelay = 100 * time.Millisecond
+
+type (
+	// Config holds how windows are shown in the desktops' names.
+	Config struct {
+		// Icons maps window classes to what they're shown as (e.g. an icon font's glyph). Windows of other classes
+		// are shown as their class, lowercased.
+		Icons map[string]string
+	}
+
+	// Labels tells the desktops' own names apart from the labels they were given.
+	Labels interface {
+		Base(d bspc.Desktop) string
+	}
+
+	desktopLabels struct {
+		logger  *log.Logger
+		service bspwm.Service
+		tree    bspwmtree.Tree
+		icons   map[string]string
+		delay   time.Duration
+
+		mutex   sync.Mutex
+		names   map[bspc.ID]*desktopName
+		pending map[bspc.ID]bool
+		timer   *time.Timer
+		stopped bool
+	}
+
+	// desktopName is a desktop's own name, and the names it was given with a label.
+	desktopName struct {
+		base string
+
+		// current is the name the desktop was last given, here or elsewhere.
+		current string
+
+		// given are the names it was given here since it got its base, so they're known for its own even when
+		// the tree hasn't caught up yet.
+		given map[string]bool
+
+		// renames are the names it was given here, whose desktop_rename events haven't been handled yet.
+		renames []string
+	}
+)
+
+// Start labels each desktop's name with its windows, until the returned function is called, when the labels are
+// taken off again. Hidden windows aren't shown, so monocle desktops are labelled with the window that's visible.
+// Only the labels added here are taken for labels, so desktops are free to have the separator in their own names.
+func Start(logger *log.Logger, service bspwm.Service, tree bspwmtree.Tree, config Config) (Labels, func()) {
+	return start(logger, service, tree, config, debounceDelay)
+}
+
+func start(
+	logger *log.Logger,
+	service bspwm.Service,
+	tree bspwmtree.Tree,
+	config Config,
+	delay time.Duration,
+) (Labels, func()) {
+	dl := &desktopLabels{
+		logger:  logger,
+		service: service,
+		tree:    tree,
+		icons:   config.Icons,
+		delay:   delay,
+		names:   make(map[bspc.ID]*desktopName),
+		pending: make(map[bspc.ID]bool),
+	}
+
+	dl.schedule(desktopIDs(tree.State())...)
+
+	handles := []bspwmevent.Handle{
+		bspwmevent.On(service.Events(), func(payload bspc.EventNodeAdd) error {
+			dl.schedule(payload.DesktopID)
+			return nil
+		}),
+		bspwmevent.On(service.Events(), func(payload bspc.EventNodeRemove) error {
+			dl.schedule(payload.DesktopID)
+			return nil
+		}),
+		bspwmevent.On(service.Events(), func(payload bspc.EventNodeTransfer) error {
+			dl.schedule(payload.SourceDesktopID, payload.DestinationDesktopID)
+			return nil
+		}),
+		// Monocle desktops swap the window that's shown as focus moves through them.
+		bspwmevent.On(service.Events(), func(payload bspc.EventNodeFocus) error {
+			dl.schedule(payload.DesktopID)
+			return nil
+		}),
+		bspwmevent.On(service.Events(), func(payload bspc.EventNodeFlag) error {
+			if payload.Flag == bspc.FlagTypeHidden {
+				dl.schedule(payload.DesktopID)
+			}
+
+			return nil
+		}),
+		// Desktops renamed by the user (or other features) get their label back.
+		bspwmevent.On(service.Events(), func(payload bspc.EventDesktopRename) error {
+			if dl.renamed(payload.DesktopID, payload.DesktopNewName) {
+				dl.schedule(payload.DesktopID)
+			}
+
+			return nil
+		}),
+		bspwmevent.On(service.Events(), func(payload bspc.EventDesktopRemove) error {
+			dl.mutex.Lock()
+			defer dl.mutex.Unlock()
+
+			delete(dl.names, payload.DesktopID)
+
+			return nil
+		}),
+	}
+
+	return dl, func() {
+		for _, h := range handles {
+			service.Events().Off(h)
+		}
+
+		dl.stop()
+	}
+}
+
+// Base returns the desktop's own name, without the label it was given here.
+func (dl *desktopLabels) Base(d bspc.Desktop) string {
+	dl.mutex.Lock()
+	defer dl.mutex.Unlock()
+
+	n := dl.nameOf(d)
+	if n == nil {
+		return d.Name
+	}
+
+	return n.base
+}
+
+// nameOf returns what's known of the desktop's name, or nil if it's been renamed elsewhere and the event hasn't been
+// handled yet. The name a desktop is first seen with is its own, so it's taken for its base. It must be called with
+// the mutex held.
+func (dl *desktopLabels) nameOf(d bspc.Desktop) *desktopName {
+	n, ok := dl.names[d.ID]
+	if !ok {
+		n = newDesktopName(d.Name)
+		dl.names[d.ID] = n
+
+		return n
+	}
+
+	if d.Name == n.base || n.given[d.Name] {
+		return n
+	}
+
+	return nil
+}
+
+// renamed records the desktop's new name, and returns true if it wasn't given here, so its label needs to be added.
+func (dl *desktopLabels) renamed(desktopID bspc.ID, name string) bool {
+	dl.mutex.Lock()
+	defer dl.mutex.Unlock()
+
+	n, ok := dl.names[desktopID]
+	if ok && len(n.renames) > 0 && n.renames[0] == name {
+		// Events for the same desktop come in the order it was renamed in.
+		n.renames = n.renames[1:]
+		return false
+	}
+
+	renames := []string(nil)
+	if ok {
+		renames = n.renames
+	}
+
+	n = newDesktopName(name)
+	n.renames = renames
+	dl.names[desktopID] = n
+
+	return true
+}
+
+func newDesktopName(base string) *desktopName {
+	return &desktopName{
+		base:    base,
+		current: base,
+		given:   make(map[string]bool),
+	}
+}
+
+// schedule renames the desktops once the delay is up, along with any others scheduled in the meantime.
+// The Debounce middleware doesn't fit, since it only keeps the latest event of each callback, while every desktop the
+// events changed needs renaming, whichever event type changed it. It can't be stopped either, so it'd label the
+// desktops again after stop takes the labels off.
+func (dl *desktopLabels) schedule(desktopIDs ...bspc.ID) {
+	dl.mutex.Lock()
+	defer dl.mutex.Unlock()
+
+	if dl.stopped {
+		return
+	}
+
+	for _, id := range desktopIDs {
+		dl.pending[id] = true
+	}
+
+	if dl.timer == nil {
+		dl.timer = time.AfterFunc(dl.delay, dl.flush)
+	}
+}
+
+func (dl *desktopLabels) flush() {
+	dl.mutex.Lock()
+	defer dl.mutex.Unlock()
+
+	if dl.stopped {
+		return
+	}
+
+	pending := dl.pending
+	dl.pending = make(map[bspc.ID]bool)
+	dl.timer = nil
+
+	for id := range pending {
+		d, err := dl.tree.Desktop(id)
+		if err != nil {
+			// It's been removed since.
+			continue
+		}
+
+		// Otherwise, it's labelled once the rename is handled.
+		if n := dl.nameOf(d); n != nil {
+			dl.rename(d.ID, n, dl.name(n.base, d))
+		}
+	}
+}
+
+// stop takes the labels off the desktops' names, since they'd otherwise go stale.
+func (dl *desktopLabels) stop() {
+	dl.mutex.Lock()
+	defer dl.mutex.Unlock()
+
+	dl.stopped = true
+
+	if dl.timer != nil {
+		dl.timer.Stop()
+	}
+
+	for _, m := range dl.tree.State().Monitors {
+		for _, d := range m.Desktops {
+			if n := dl.nameOf(d); n != nil {
+				dl.rename(d.ID, n, n.base)
+			}
+		}
+	}
+}
+
+// rename gives the desktop the name, unless it already has it. It must be called with the mutex held.
+func (dl *desktopLabels) rename(desktopID bspc.ID, n *desktopName, name string) {
+	if n.current == name {
+		return
+	}
+
+	if err := dl.service.Desktops().Rename(desktopID, name); err != nil {
+		dl.logger.Error("failed to rename desktop",
+			zap.Uint("desktop_id", uint(desktopID)),
+			zap.String("name", name),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	n.current = name
+	n.given[name] = true
+	n.renames = append(n.renames, name)
+}
+
+// name returns the desktop's own name, labelled with the windows that are shown in it.
+func (dl *desktopLabels) name(base string, d bspc.Desktop) string {
+	var (
+		labels []string
+		seen   = make(map[string]bool)
+	)
+
+	for _, n := range d.Root.LeafNodes() {
+		if n.Hidden {
+			continue
+		}
+
+		label := dl.label(n.Client)
+		if label == "" || seen[label] {
+			continue
+		}
+
+		seen[label] = true
+		labels = append(labels, label)
+	}
+
+	if len(labels) == 0 {
+		return base
+	}
+
+	return base + Separator + strings.Join(labels, labelSeparator)
+}
+
+// label returns what the window is shown as, without any whitespace.
+func (dl *desktopLabels) label(c *bspc.NodeClient) string {
+	label, ok := dl.icons[c.ClassName]
+	if !ok {
+		label = strings.ToLower(c.ClassName)
+	}
+
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+
+		return r
+	}, label)
+}
+
+func desktopIDs(st bspc.State) []bspc.ID {
+	var ids []bspc.ID
+	for _, m := range st.Monitors {
+		for _, d := range m.Desktops {
+			ids = append(ids, d.ID)
+		}
+	}
+
+	return ids
+}
@@ -0,0 +1,20 @@
+package desktoplabels
+
+import (
+	"time"
+
+	"github.com/diogox/bspm/internal/bspwm"
+	bspwmtree "github.com/diogox/bspm/internal/bspwm/tree"
+	"github.com/diogox/bspm/internal/log"
+)
+
+// StartWithDelay starts labelling desktops with the given debounce delay, so tests don't have to wait for the default one.
+func StartWithDelay(
+	logger *log.Logger,
+	service bspwm.Service,
+	tree bspwmtree.Tree,
+	config Config,
+	delay time.Duration,
+) (Labels, func()) {
+	return start(logger, service, tree, config, delay)
+}
@@ -0,0 +1,655 @@
+package desktoplabels_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/diogox/bspc-go"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/diogox/bspm/internal/bspwm"
+	bspwmdesktop "github.com/diogox/bspm/internal/bspwm/desktop"
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	bspwmtree "github.com/diogox/bspm/internal/bspwm/tree"
+	desktoplabels "github.com/diogox/bspm/internal/feature/desktop_labels"
+	"github.com/diogox/bspm/internal/log"
+)
+
+const (
+	testDelay   = 10 * time.Millisecond
+	testTimeout = time.Second
+)
+
+// fakeDesktops is a monitor's worth of desktops, as the tree has them, changed by the tests while the feature reads
+// them.
+type fakeDesktops struct {
+	mutex      sync.Mutex
+	desktops   []bspc.Desktop
+	fetchCount int
+}
+
+func (fd *fakeDesktops) state() bspc.State {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	return bspc.State{
+		Monitors: []bspc.Monitor{
+			{Desktops: append([]bspc.Desktop(nil), fd.desktops...)},
+		},
+	}
+}
+
+// fetch returns the desktop, counting how many times desktops were fetched.
+func (fd *fakeDesktops) fetch(id bspc.ID) (bspc.Desktop, error) {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	fd.fetchCount++
+
+	for _, d := range fd.desktops {
+		if d.ID == id {
+			return d, nil
+		}
+	}
+
+	return bspc.Desktop{}, errors.New("desktop not found")
+}
+
+func (fd *fakeDesktops) desktop(id bspc.ID) bspc.Desktop {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	for _, d := range fd.desktops {
+		if d.ID == id {
+			return d
+		}
+	}
+
+	return bspc.Desktop{}
+}
+
+func (fd *fakeDesktops) setName(id bspc.ID, name string) {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	for i := range fd.desktops {
+		if fd.desktops[i].ID == id {
+			fd.desktops[i].Name = name
+		}
+	}
+}
+
+func (fd *fakeDesktops) setRoot(id bspc.ID, root bspc.Node) {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	for i := range fd.desktops {
+		if fd.desktops[i].ID == id {
+			fd.desktops[i].Root = root
+		}
+	}
+}
+
+func wait(t *testing.T, done chan struct{}) {
+	select {
+	case <-done:
+	case <-time.After(testTimeout):
+		require.FailNow(t, "desktop wasn't renamed")
+	}
+}
+
+func TestDesktopLabels(t *testing.T) {
+	t.Run("should label desktops with their windows", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockDesktops     = bspwmdesktop.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+			fd               = &fakeDesktops{desktops: []bspc.Desktop{
+				{
+					ID:   bspc.ID(1),
+					Name: "1",
+					Root: bspc.Node{
+						ID:         bspc.ID(110),
+						FirstChild: &bspc.Node{ID: 10, Client: &bspc.NodeClient{ClassName: "Firefox"}},
+						SecondChild: &bspc.Node{
+							ID:          bspc.ID(111),
+							FirstChild:  &bspc.Node{ID: 11, Client: &bspc.NodeClient{ClassName: "Alacritty"}},
+							SecondChild: &bspc.Node{ID: 12, Client: &bspc.NodeClient{ClassName: "Firefox"}},
+						},
+					},
+				},
+				{ID: bspc.ID(2), Name: "2"},
+			}}
+		)
+
+		mockService.EXPECT().
+			Desktops().
+			Return(mockDesktops).
+			AnyTimes()
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			Off(gomock.Any()).
+			AnyTimes()
+		mockTree.EXPECT().
+			State().
+			DoAndReturn(fd.state).
+			AnyTimes()
+		mockTree.EXPECT().
+			Desktop(gomock.Any()).
+			DoAndReturn(fd.fetch).
+			AnyTimes()
+
+		done := make(chan struct{})
+		mockDesktops.EXPECT().
+			Rename(bspc.ID(1), "1:firefox,alacritty").
+			Do(func(id bspc.ID, name string) {
+				fd.setName(id, name)
+				close(done)
+			}).
+			Return(nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		desktoplabels.StartWithDelay(logger, mockService, mockTree, desktoplabels.Config{}, testDelay)
+
+		wait(t, done)
+	})
+	t.Run("should only take the labels it added for labels", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockDesktops     = bspwmdesktop.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+			fd               = &fakeDesktops{desktops: []bspc.Desktop{
+				{
+					ID:   bspc.ID(1),
+					Name: "web:2",
+					Root: bspc.Node{ID: 10, Client: &bspc.NodeClient{ClassName: "Firefox"}},
+				},
+			}}
+		)
+
+		mockService.EXPECT().
+			Desktops().
+			Return(mockDesktops).
+			AnyTimes()
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			Off(gomock.Any()).
+			AnyTimes()
+		mockTree.EXPECT().
+			State().
+			DoAndReturn(fd.state).
+			AnyTimes()
+		mockTree.EXPECT().
+			Desktop(gomock.Any()).
+			DoAndReturn(fd.fetch).
+			AnyTimes()
+
+		done := make(chan struct{})
+		mockDesktops.EXPECT().
+			Rename(bspc.ID(1), "web:2:firefox").
+			Do(func(id bspc.ID, name string) {
+				fd.setName(id, name)
+				close(done)
+			}).
+			Return(nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		labels, _ := desktoplabels.StartWithDelay(logger, mockService, mockTree, desktoplabels.Config{}, testDelay)
+
+		wait(t, done)
+		fd.setName(bspc.ID(1), "web:2:firefox")
+		require.NoError(t, callbacks[bspc.EventTypeDesktopRename](bspc.EventDesktopRename{
+			DesktopID:      bspc.ID(1),
+			DesktopNewName: "web:2:firefox",
+		}))
+
+		assert.Equal(t, "web:2", labels.Base(fd.desktop(bspc.ID(1))))
+
+		done = make(chan struct{})
+		mockDesktops.EXPECT().
+			Rename(bspc.ID(1), "web:2").
+			Do(func(id bspc.ID, name string) {
+				fd.setName(id, name)
+				close(done)
+			}).
+			Return(nil)
+
+		fd.setRoot(bspc.ID(1), bspc.Node{})
+		require.NoError(t, callbacks[bspc.EventTypeNodeRemove](bspc.EventNodeRemove{DesktopID: bspc.ID(1), NodeID: bspc.ID(10)}))
+
+		wait(t, done)
+	})
+	t.Run("should label desktops renamed elsewhere again", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockDesktops     = bspwmdesktop.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+			fd               = &fakeDesktops{desktops: []bspc.Desktop{
+				{
+					ID:   bspc.ID(1),
+					Name: "1",
+					Root: bspc.Node{ID: 10, Client: &bspc.NodeClient{ClassName: "Firefox"}},
+				},
+			}}
+		)
+
+		mockService.EXPECT().
+			Desktops().
+			Return(mockDesktops).
+			AnyTimes()
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			Off(gomock.Any()).
+			AnyTimes()
+		mockTree.EXPECT().
+			State().
+			DoAndReturn(fd.state).
+			AnyTimes()
+		mockTree.EXPECT().
+			Desktop(gomock.Any()).
+			DoAndReturn(fd.fetch).
+			AnyTimes()
+
+		done := make(chan struct{})
+		mockDesktops.EXPECT().
+			Rename(bspc.ID(1), "1:firefox").
+			Do(func(id bspc.ID, name string) {
+				fd.setName(id, name)
+				close(done)
+			}).
+			Return(nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		labels, _ := desktoplabels.StartWithDelay(logger, mockService, mockTree, desktoplabels.Config{}, testDelay)
+
+		wait(t, done)
+
+		// Its own rename isn't labelled again.
+		fd.setName(bspc.ID(1), "1:firefox")
+		require.NoError(t, callbacks[bspc.EventTypeDesktopRename](bspc.EventDesktopRename{
+			DesktopID:      bspc.ID(1),
+			DesktopNewName: "1:firefox",
+		}))
+
+		done = make(chan struct{})
+		mockDesktops.EXPECT().
+			Rename(bspc.ID(1), "I:firefox").
+			Do(func(id bspc.ID, name string) {
+				fd.setName(id, name)
+				close(done)
+			}).
+			Return(nil)
+
+		fd.setName(bspc.ID(1), "I")
+		require.NoError(t, callbacks[bspc.EventTypeDesktopRename](bspc.EventDesktopRename{
+			DesktopID:      bspc.ID(1),
+			DesktopNewName: "I",
+		}))
+
+		wait(t, done)
+		assert.Equal(t, "I", labels.Base(fd.desktop(bspc.ID(1))))
+	})
+	t.Run("should show windows with their icons, unless they're hidden", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockDesktops     = bspwmdesktop.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+			fd               = &fakeDesktops{desktops: []bspc.Desktop{
+				{
+					ID:   bspc.ID(1),
+					Name: "web",
+					Root: bspc.Node{
+						ID:          bspc.ID(110),
+						FirstChild:  &bspc.Node{ID: 10, Client: &bspc.NodeClient{ClassName: "Firefox"}},
+						SecondChild: &bspc.Node{ID: 11, Hidden: true, Client: &bspc.NodeClient{ClassName: "Alacritty"}},
+					},
+				},
+			}}
+		)
+
+		mockService.EXPECT().
+			Desktops().
+			Return(mockDesktops).
+			AnyTimes()
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			Off(gomock.Any()).
+			AnyTimes()
+		mockTree.EXPECT().
+			State().
+			DoAndReturn(fd.state).
+			AnyTimes()
+		mockTree.EXPECT().
+			Desktop(gomock.Any()).
+			DoAndReturn(fd.fetch).
+			AnyTimes()
+
+		done := make(chan struct{})
+		mockDesktops.EXPECT().
+			Rename(bspc.ID(1), "web:ff").
+			Do(func(id bspc.ID, name string) {
+				fd.setName(id, name)
+				close(done)
+			}).
+			Return(nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		desktoplabels.StartWithDelay(logger, mockService, mockTree, desktoplabels.Config{
+			Icons: map[string]string{"Firefox": "ff"},
+		}, testDelay)
+
+		wait(t, done)
+	})
+	t.Run("should rename desktop once after a burst of changes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockDesktops     = bspwmdesktop.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+			fd               = &fakeDesktops{desktops: []bspc.Desktop{{ID: bspc.ID(1), Name: "1"}}}
+		)
+
+		mockService.EXPECT().
+			Desktops().
+			Return(mockDesktops).
+			AnyTimes()
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			Off(gomock.Any()).
+			AnyTimes()
+		mockTree.EXPECT().
+			State().
+			DoAndReturn(fd.state).
+			AnyTimes()
+		mockTree.EXPECT().
+			Desktop(gomock.Any()).
+			DoAndReturn(fd.fetch).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		desktoplabels.StartWithDelay(logger, mockService, mockTree, desktoplabels.Config{}, testDelay)
+
+		// Nothing to rename at first.
+		time.Sleep(2 * testDelay)
+
+		fd.mutex.Lock()
+		fd.fetchCount = 0
+		fd.mutex.Unlock()
+
+		done := make(chan struct{})
+		mockDesktops.EXPECT().
+			Rename(bspc.ID(1), "1:firefox,alacritty").
+			Do(func(id bspc.ID, name string) {
+				fd.setName(id, name)
+				close(done)
+			}).
+			Return(nil)
+
+		fd.setRoot(bspc.ID(1), bspc.Node{
+			ID:          bspc.ID(110),
+			FirstChild:  &bspc.Node{ID: 10, Client: &bspc.NodeClient{ClassName: "Firefox"}},
+			SecondChild: &bspc.Node{ID: 11, Client: &bspc.NodeClient{ClassName: "Alacritty"}},
+		})
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeAdd](bspc.EventNodeAdd{DesktopID: bspc.ID(1), NodeID: bspc.ID(10)}))
+		require.NoError(t, callbacks[bspc.EventTypeNodeAdd](bspc.EventNodeAdd{DesktopID: bspc.ID(1), NodeID: bspc.ID(11)}))
+		require.NoError(t, callbacks[bspc.EventTypeNodeFocus](bspc.EventNodeFocus{DesktopID: bspc.ID(1), NodeID: bspc.ID(11)}))
+
+		wait(t, done)
+
+		fd.mutex.Lock()
+		defer fd.mutex.Unlock()
+
+		assert.Equal(t, 1, fd.fetchCount)
+	})
+	t.Run("should label the window shown in a monocle desktop", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockDesktops     = bspwmdesktop.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+			fd               = &fakeDesktops{desktops: []bspc.Desktop{
+				{
+					ID:   bspc.ID(1),
+					Name: "1",
+					Root: bspc.Node{
+						ID:          bspc.ID(110),
+						FirstChild:  &bspc.Node{ID: 10, Client: &bspc.NodeClient{ClassName: "Firefox"}},
+						SecondChild: &bspc.Node{ID: 11, Hidden: true, Client: &bspc.NodeClient{ClassName: "Alacritty"}},
+					},
+				},
+			}}
+		)
+
+		mockService.EXPECT().
+			Desktops().
+			Return(mockDesktops).
+			AnyTimes()
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			Off(gomock.Any()).
+			AnyTimes()
+		mockTree.EXPECT().
+			State().
+			DoAndReturn(fd.state).
+			AnyTimes()
+		mockTree.EXPECT().
+			Desktop(gomock.Any()).
+			DoAndReturn(fd.fetch).
+			AnyTimes()
+
+		done := make(chan struct{})
+		mockDesktops.EXPECT().
+			Rename(bspc.ID(1), "1:firefox").
+			Do(func(id bspc.ID, name string) {
+				fd.setName(id, name)
+				close(done)
+			}).
+			Return(nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		desktoplabels.StartWithDelay(logger, mockService, mockTree, desktoplabels.Config{}, testDelay)
+
+		wait(t, done)
+
+		done = make(chan struct{})
+		mockDesktops.EXPECT().
+			Rename(bspc.ID(1), "1:alacritty").
+			Do(func(id bspc.ID, name string) {
+				fd.setName(id, name)
+				close(done)
+			}).
+			Return(nil)
+
+		fd.setRoot(bspc.ID(1), bspc.Node{
+			ID:          bspc.ID(110),
+			FirstChild:  &bspc.Node{ID: 10, Hidden: true, Client: &bspc.NodeClient{ClassName: "Firefox"}},
+			SecondChild: &bspc.Node{ID: 11, Client: &bspc.NodeClient{ClassName: "Alacritty"}},
+		})
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeFlag](bspc.EventNodeFlag{
+			DesktopID: bspc.ID(1),
+			NodeID:    bspc.ID(10),
+			Flag:      bspc.FlagTypeHidden,
+		}))
+
+		wait(t, done)
+	})
+	t.Run("should take the labels off once stopped", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockDesktops     = bspwmdesktop.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+			fd               = &fakeDesktops{desktops: []bspc.Desktop{
+				{ID: bspc.ID(1), Name: "1", Root: bspc.Node{ID: 10, Client: &bspc.NodeClient{ClassName: "Firefox"}}},
+				{ID: bspc.ID(2), Name: "2"},
+			}}
+		)
+
+		mockService.EXPECT().
+			Desktops().
+			Return(mockDesktops).
+			AnyTimes()
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			Off(gomock.Any()).
+			AnyTimes()
+		mockTree.EXPECT().
+			State().
+			DoAndReturn(fd.state).
+			AnyTimes()
+		mockTree.EXPECT().
+			Desktop(gomock.Any()).
+			DoAndReturn(fd.fetch).
+			AnyTimes()
+
+		done := make(chan struct{})
+		mockDesktops.EXPECT().
+			Rename(bspc.ID(1), "1:firefox").
+			Do(func(id bspc.ID, name string) {
+				fd.setName(id, name)
+				close(done)
+			}).
+			Return(nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, cancel := desktoplabels.StartWithDelay(logger, mockService, mockTree, desktoplabels.Config{}, testDelay)
+
+		wait(t, done)
+
+		done = make(chan struct{})
+		mockDesktops.EXPECT().
+			Rename(bspc.ID(1), "1").
+			Do(func(id bspc.ID, name string) {
+				fd.setName(id, name)
+				close(done)
+			}).
+			Return(nil)
+
+		cancel()
+
+		wait(t, done)
+	})
+}
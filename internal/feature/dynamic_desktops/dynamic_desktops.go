@@ -0,0 +1,327 @@
+package dynamicdesktops
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/diogox/bspc-go"
+
+	"github.com/diogox/bspm/internal/bspwm"
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	desktoplabels "github.com/diogox/bspm/internal/feature/desktop_labels"
+	"github.com/diogox/bspm/internal/log"
+)
+
+type (
+	// Config holds how the dynamic desktops are named, and which desktops are left alone.
+	Config struct {
+		// Naming is the scheme (see NamingNames) the dynamic desktops are named with, and renamed with as desktops
+		// come and go, so their names stay in order. Names taken on other monitors, or by pinned desktops, are
+		// skipped. If empty, desktops keep their names, and new ones are named with the lowest number not taken.
+		Naming string
+
+		// Pinned are the names of the desktops that are never removed or renamed, even when they're empty.
+		// They don't count as the empty desktop at the end of their monitor either.
+		Pinned []string
+	}
+
+	dynamicDesktops struct {
+		logger  *log.Logger
+		service bspwm.Service
+		labels  desktoplabels.Labels
+		naming  Naming
+		pinned  map[string]struct{}
+
+		// mutex serialises the arrangements, so two of them don't add a desktop each.
+		mutex sync.Mutex
+	}
+)
+
+// Start keeps a single empty desktop at the end of each monitor, adding one whenever it's taken and removing the
+// others as they're emptied, until the returned function is called. Desktops are only told apart from their labels
+// if labels is not nil.
+func Start(logger *log.Logger, service bspwm.Service, labels desktoplabels.Labels, config Config) (func(), error) {
+	dd := &dynamicDesktops{
+		logger:  logger,
+		service: service,
+		labels:  labels,
+		pinned:  make(map[string]struct{}, len(config.Pinned)),
+	}
+
+	if config.Naming != "" {
+		naming, err := GetNaming(config.Naming)
+		if err != nil {
+			return nil, err
+		}
+
+		dd.naming = naming
+	}
+
+	for _, name := range config.Pinned {
+		dd.pinned[name] = struct{}{}
+	}
+
+	if err := dd.arrangeAll(); err != nil {
+		return nil, err
+	}
+
+	handles := []bspwmevent.Handle{
+		bspwmevent.On(service.Events(), func(payload bspc.EventNodeAdd) error {
+			return dd.arrange(payload.MonitorID)
+		}),
+		bspwmevent.On(service.Events(), func(payload bspc.EventNodeRemove) error {
+			return dd.arrange(payload.MonitorID)
+		}),
+		bspwmevent.On(service.Events(), func(payload bspc.EventNodeTransfer) error {
+			return dd.arrange(payload.SourceMonitorID, payload.DestinationMonitorID)
+		}),
+		// An empty desktop is kept while it's focused, so it's only removed once it's left.
+		bspwmevent.On(service.Events(), func(payload bspc.EventDesktopFocus) error {
+			return dd.arrange(payload.MonitorID)
+		}),
+		bspwmevent.On(service.Events(), func(payload bspc.EventMonitorAdd) error {
+			return dd.arrange(payload.MonitorID)
+		}),
+	}
+
+	cancelFunc := func() {
+		for _, h := range handles {
+			service.Events().Off(h)
+		}
+	}
+
+	return cancelFunc, nil
+}
+
+// arrange adds and removes the desktops of the given monitors, so each ends with a single empty desktop. The state is
+// fetched from bspwm, rather than the tree, since the tree only catches up on added and removed desktops later.
+func (dd *dynamicDesktops) arrange(monitorIDs ...bspc.ID) error {
+	isArranged := make(map[bspc.ID]bool, len(monitorIDs))
+	for _, id := range monitorIDs {
+		isArranged[id] = true
+	}
+
+	return dd.arrangeMonitors(func(m bspc.Monitor) bool {
+		return isArranged[m.ID]
+	})
+}
+
+// arrangeAll arranges the desktops of every monitor.
+func (dd *dynamicDesktops) arrangeAll() error {
+	return dd.arrangeMonitors(func(bspc.Monitor) bool {
+		return true
+	})
+}
+
+// arrangeMonitors arranges the desktops of the monitors it should, from a single fetch of the state.
+func (dd *dynamicDesktops) arrangeMonitors(shouldArrange func(m bspc.Monitor) bool) error {
+	dd.mutex.Lock()
+	defer dd.mutex.Unlock()
+
+	st, err := dd.service.State()
+	if err != nil {
+		return fmt.Errorf("failed to get monitors: %w", err)
+	}
+
+	// Desktops are picked by name, so each monitor's are kept clear of the names on the others. Arranging a
+	// monitor can change its names, so they're kept track of for the monitors after it.
+	namesByMonitor := make(map[bspc.ID][]string, len(st.Monitors))
+	for _, m := range st.Monitors {
+		for _, d := range m.Desktops {
+			namesByMonitor[m.ID] = append(namesByMonitor[m.ID], dd.base(d))
+		}
+	}
+
+	for _, m := range st.Monitors {
+		if !shouldArrange(m) {
+			continue
+		}
+
+		// Pinned names are kept clear of as well, since a dynamic desktop with one would be pinned from then on.
+		taken := make(map[string]bool)
+		for name := range dd.pinned {
+			taken[name] = true
+		}
+
+		for id, names := range namesByMonitor {
+			if id == m.ID {
+				continue
+			}
+
+			for _, name := range names {
+				taken[name] = true
+			}
+		}
+
+		desktops, err := dd.arrangeMonitor(m, taken)
+		if err != nil {
+			return fmt.Errorf("failed to arrange desktops of monitor %s: %w", m.Name, err)
+		}
+
+		namesByMonitor[m.ID] = desktops
+	}
+
+	return nil
+}
+
+// arrangeMonitor arranges the monitor's desktops, keeping their names clear of the taken ones. It returns the names
+// the desktops end up with.
+func (dd *dynamicDesktops) arrangeMonitor(m bspc.Monitor, taken map[string]bool) ([]string, error) {
+	if len(m.Desktops) == 0 {
+		return nil, nil
+	}
+
+	var (
+		last      = m.Desktops[len(m.Desktops)-1]
+		keepIndex = -1
+		removed   = make(map[bspc.ID]bool)
+	)
+
+	if dd.isDynamic(last) && isEmpty(last) {
+		keepIndex = len(m.Desktops) - 1
+	}
+
+	for i, d := range m.Desktops[:len(m.Desktops)-1] {
+		// The focused desktop is left alone, so it doesn't go away while it's being used.
+		if !dd.isDynamic(d) || !isEmpty(d) || d.ID == m.FocusedDesktopID {
+			continue
+		}
+
+		if keepIndex < 0 {
+			keepIndex = i
+			continue
+		}
+
+		if err := dd.service.Desktops().Remove(d.ID); err != nil {
+			return nil, err
+		}
+
+		removed[d.ID] = true
+	}
+
+	desktops := make([]bspc.Desktop, 0, len(m.Desktops)+1)
+	for i, d := range m.Desktops {
+		if !removed[d.ID] && i != keepIndex {
+			desktops = append(desktops, d)
+		}
+	}
+
+	switch {
+	case keepIndex < 0:
+		name := dd.newName(desktops, taken)
+		if err := dd.service.Desktops().Add(m.ID, name); err != nil {
+			return nil, err
+		}
+
+		// Its id isn't known, but it already has the name it should have.
+		desktops = append(desktops, bspc.Desktop{Name: name})
+	case keepIndex == len(m.Desktops)-1:
+		desktops = append(desktops, last)
+	default:
+		desktops = append(desktops, m.Desktops[keepIndex])
+
+		if err := dd.service.Desktops().Reorder(m.ID, names(desktops)); err != nil {
+			return nil, err
+		}
+	}
+
+	return dd.rename(desktops, taken)
+}
+
+// rename gives the dynamic desktops the names of their positions, if there's a naming scheme. It returns the names
+// the desktops end up with.
+func (dd *dynamicDesktops) rename(desktops []bspc.Desktop, taken map[string]bool) ([]string, error) {
+	var (
+		renamed = make([]string, 0, len(desktops))
+		next    = dd.namer(taken)
+	)
+
+	for _, d := range desktops {
+		name := dd.base(d)
+		if dd.naming != nil && dd.isDynamic(d) {
+			name = next()
+		}
+
+		renamed = append(renamed, name)
+
+		// Desktop labels come back on their own once it's renamed.
+		if d.ID == bspc.NilID || dd.base(d) == name {
+			continue
+		}
+
+		if err := dd.service.Desktops().Rename(d.ID, name); err != nil {
+			return nil, err
+		}
+	}
+
+	return renamed, nil
+}
+
+// newName returns the name of a desktop added after the given ones.
+func (dd *dynamicDesktops) newName(desktops []bspc.Desktop, taken map[string]bool) string {
+	if dd.naming != nil {
+		next := dd.namer(taken)
+		for _, d := range desktops {
+			if dd.isDynamic(d) {
+				next()
+			}
+		}
+
+		return next()
+	}
+
+	isOwn := make(map[string]bool, len(desktops))
+	for _, d := range desktops {
+		isOwn[dd.base(d)] = true
+	}
+
+	for n := 1; ; n++ {
+		if name := strconv.Itoa(n); !taken[name] && !isOwn[name] {
+			return name
+		}
+	}
+}
+
+// namer returns a function that names the dynamic desktops after their positions, one after the other, skipping the
+// taken names. It must only be called if there's a naming scheme.
+func (dd *dynamicDesktops) namer(taken map[string]bool) func() string {
+	position := 0
+
+	return func() string {
+		for {
+			position++
+			if name := dd.naming(position); !taken[name] {
+				return name
+			}
+		}
+	}
+}
+
+func (dd *dynamicDesktops) isDynamic(d bspc.Desktop) bool {
+	_, ok := dd.pinned[dd.base(d)]
+	return !ok
+}
+
+// base returns the desktop's own name, without its label.
+func (dd *dynamicDesktops) base(d bspc.Desktop) string {
+	if dd.labels == nil {
+		return d.Name
+	}
+
+	return dd.labels.Base(d)
+}
+
+// isEmpty is false for desktops with hidden windows, like minimised ones, since they're still there.
+func isEmpty(d bspc.Desktop) bool {
+	return d.Root.ID == bspc.NilID
+}
+
+func names(desktops []bspc.Desktop) []string {
+	n := make([]string, 0, len(desktops))
+	for _, d := range desktops {
+		n = append(n, d.Name)
+	}
+
+	return n
+}
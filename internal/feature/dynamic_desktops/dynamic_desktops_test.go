@@ -0,0 +1,836 @@
+package dynamicdesktops_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/diogox/bspc-go"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/diogox/bspm/internal/bspwm"
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	"github.com/diogox/bspm/internal/bspwm/filter"
+	dynamicdesktops "github.com/diogox/bspm/internal/feature/dynamic_desktops"
+	"github.com/diogox/bspm/internal/log"
+)
+
+const (
+	monitorID      = bspc.ID(1)
+	otherMonitorID = bspc.ID(2)
+)
+
+// fakeDesktops is a monitor's worth of bspwm desktops, changed by the commands it's sent, along with those of another
+// monitor, if it has any. The desktops named in bases are labelled.
+type fakeDesktops struct {
+	bases          map[string]string
+	desktops       []bspc.Desktop
+	focusedID      bspc.ID
+	otherDesktops  []bspc.Desktop
+	nextID         bspc.ID
+	commandCount   int
+	stateCount     int
+	reorderedNames []string
+}
+
+// newFakeDesktops returns a monitor with desktops named 1, 2, 3, etc., the first one focused. Only the ones at the
+// given indexes have a window.
+func newFakeDesktops(count int, withWindows ...int) *fakeDesktops {
+	fd := &fakeDesktops{
+		nextID: 100,
+	}
+
+	for i := 0; i < count; i++ {
+		fd.add(bspc.Desktop{Name: string(rune('1' + i))})
+	}
+
+	for _, i := range withWindows {
+		fd.desktops[i].Root = bspc.Node{ID: bspc.ID(1000 + i)}
+	}
+
+	fd.focusedID = fd.desktops[0].ID
+
+	return fd
+}
+
+func (fd *fakeDesktops) add(d bspc.Desktop) bspc.ID {
+	return fd.addTo(&fd.desktops, d)
+}
+
+// addOther adds desktops to the other monitor, with the given names. Only the ones at the given indexes have a window.
+func (fd *fakeDesktops) addOther(names []string, withWindows ...int) {
+	for _, name := range names {
+		fd.addTo(&fd.otherDesktops, bspc.Desktop{Name: name})
+	}
+
+	for _, i := range withWindows {
+		fd.otherDesktops[i].Root = bspc.Node{ID: bspc.ID(3000 + i)}
+	}
+}
+
+func (fd *fakeDesktops) addTo(desktops *[]bspc.Desktop, d bspc.Desktop) bspc.ID {
+	d.ID = fd.nextID
+	fd.nextID++
+	*desktops = append(*desktops, d)
+
+	return d.ID
+}
+
+// desktopsOf returns the desktops of the monitor, or nil if there's no such monitor.
+func (fd *fakeDesktops) desktopsOf(id bspc.ID) *[]bspc.Desktop {
+	switch {
+	case id == monitorID:
+		return &fd.desktops
+	case id == otherMonitorID && fd.otherDesktops != nil:
+		return &fd.otherDesktops
+	default:
+		return nil
+	}
+}
+
+func (fd *fakeDesktops) state() (bspc.State, error) {
+	fd.stateCount++
+
+	st := bspc.State{
+		FocusedMonitorID: monitorID,
+		Monitors: []bspc.Monitor{
+			{
+				ID:               monitorID,
+				Name:             "eDP-1",
+				FocusedDesktopID: fd.focusedID,
+				Desktops:         append([]bspc.Desktop(nil), fd.desktops...),
+			},
+		},
+	}
+
+	if fd.otherDesktops != nil {
+		st.Monitors = append(st.Monitors, bspc.Monitor{
+			ID:               otherMonitorID,
+			Name:             "HDMI-1",
+			FocusedDesktopID: fd.otherDesktops[0].ID,
+			Desktops:         append([]bspc.Desktop(nil), fd.otherDesktops...),
+		})
+	}
+
+	return st, nil
+}
+
+func (fd *fakeDesktops) names() []string {
+	return names(fd.desktops)
+}
+
+func names(desktops []bspc.Desktop) []string {
+	var names []string
+	for _, d := range desktops {
+		names = append(names, d.Name)
+	}
+
+	return names
+}
+
+func (fd *fakeDesktops) Base(d bspc.Desktop) string {
+	if base, ok := fd.bases[d.Name]; ok {
+		return base
+	}
+
+	return d.Name
+}
+
+func (fd *fakeDesktops) Get(filter.DesktopFilter) (bspc.Desktop, error) {
+	return bspc.Desktop{}, errors.New("not implemented")
+}
+
+func (fd *fakeDesktops) SetLayout(filter.DesktopFilter, bspc.LayoutType) error {
+	return errors.New("not implemented")
+}
+
+func (fd *fakeDesktops) Add(id bspc.ID, name string) error {
+	desktops := fd.desktopsOf(id)
+	if desktops == nil {
+		return errors.New("unknown monitor")
+	}
+
+	fd.commandCount++
+	fd.addTo(desktops, bspc.Desktop{Name: name})
+
+	return nil
+}
+
+func (fd *fakeDesktops) Remove(id bspc.ID) error {
+	fd.commandCount++
+
+	for _, desktops := range []*[]bspc.Desktop{&fd.desktops, &fd.otherDesktops} {
+		for i, d := range *desktops {
+			if d.ID == id {
+				*desktops = append((*desktops)[:i], (*desktops)[i+1:]...)
+				return nil
+			}
+		}
+	}
+
+	return errors.New("unknown desktop")
+}
+
+func (fd *fakeDesktops) Rename(id bspc.ID, name string) error {
+	fd.commandCount++
+
+	for _, desktops := range [][]bspc.Desktop{fd.desktops, fd.otherDesktops} {
+		for i, d := range desktops {
+			if d.ID == id {
+				desktops[i].Name = name
+				return nil
+			}
+		}
+	}
+
+	return errors.New("unknown desktop")
+}
+
+func (fd *fakeDesktops) Reorder(id bspc.ID, names []string) error {
+	desktops := fd.desktopsOf(id)
+	if desktops == nil {
+		return errors.New("unknown monitor")
+	}
+
+	fd.commandCount++
+	fd.reorderedNames = names
+
+	var (
+		reordered []bspc.Desktop
+		isTaken   = make(map[bspc.ID]bool)
+	)
+
+	for _, name := range names {
+		for _, d := range *desktops {
+			if d.Name == name && !isTaken[d.ID] {
+				isTaken[d.ID] = true
+				reordered = append(reordered, d)
+
+				break
+			}
+		}
+	}
+
+	*desktops = reordered
+
+	return nil
+}
+
+func TestDynamicDesktops(t *testing.T) {
+	t.Run("should add an empty desktop when there is none", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fd := newFakeDesktops(2, 0, 1)
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+		)
+
+		mockService.EXPECT().
+			State().
+			DoAndReturn(fd.state).
+			AnyTimes()
+		mockService.EXPECT().
+			Desktops().
+			Return(fd).
+			AnyTimes()
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = dynamicdesktops.Start(logger, mockService, fd, dynamicdesktops.Config{})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"1", "2", "3"}, fd.names())
+	})
+	t.Run("should remove the empty desktops but the last one", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fd := newFakeDesktops(5, 0, 2)
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+		)
+
+		mockService.EXPECT().
+			State().
+			DoAndReturn(fd.state).
+			AnyTimes()
+		mockService.EXPECT().
+			Desktops().
+			Return(fd).
+			AnyTimes()
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = dynamicdesktops.Start(logger, mockService, fd, dynamicdesktops.Config{})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"1", "3", "5"}, fd.names())
+	})
+	t.Run("should rename the desktops to keep them in order", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fd := newFakeDesktops(5, 0, 2)
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+		)
+
+		mockService.EXPECT().
+			State().
+			DoAndReturn(fd.state).
+			AnyTimes()
+		mockService.EXPECT().
+			Desktops().
+			Return(fd).
+			AnyTimes()
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = dynamicdesktops.Start(logger, mockService, fd, dynamicdesktops.Config{Naming: dynamicdesktops.NamingRoman})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"I", "II", "III"}, fd.names())
+	})
+	t.Run("should do nothing when there is a single empty desktop at the end", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fd := newFakeDesktops(3, 0, 1)
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			State().
+			DoAndReturn(fd.state).
+			AnyTimes()
+		mockService.EXPECT().
+			Desktops().
+			Return(fd).
+			AnyTimes()
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = dynamicdesktops.Start(logger, mockService, fd, dynamicdesktops.Config{Naming: dynamicdesktops.NamingNumeric})
+		require.NoError(t, err)
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeAdd](bspc.EventNodeAdd{MonitorID: monitorID}))
+
+		assert.Equal(t, []string{"1", "2", "3"}, fd.names())
+		assert.Zero(t, fd.commandCount)
+	})
+	t.Run("should add an empty desktop once the last one gets a window", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fd := newFakeDesktops(2, 0)
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			State().
+			DoAndReturn(fd.state).
+			AnyTimes()
+		mockService.EXPECT().
+			Desktops().
+			Return(fd).
+			AnyTimes()
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = dynamicdesktops.Start(logger, mockService, fd, dynamicdesktops.Config{})
+		require.NoError(t, err)
+
+		fd.desktops[1].Root = bspc.Node{ID: bspc.ID(2000)}
+		require.NoError(t, callbacks[bspc.EventTypeNodeAdd](bspc.EventNodeAdd{MonitorID: monitorID}))
+
+		assert.Equal(t, []string{"1", "2", "3"}, fd.names())
+	})
+	t.Run("should remove a desktop once its last window is moved away", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fd := newFakeDesktops(3, 0, 1)
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			State().
+			DoAndReturn(fd.state).
+			AnyTimes()
+		mockService.EXPECT().
+			Desktops().
+			Return(fd).
+			AnyTimes()
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = dynamicdesktops.Start(logger, mockService, fd, dynamicdesktops.Config{Naming: dynamicdesktops.NamingNumeric})
+		require.NoError(t, err)
+
+		fd.desktops[0].Root = bspc.Node{}
+		fd.desktops[1].Root = bspc.Node{ID: bspc.ID(2000)}
+		fd.focusedID = fd.desktops[1].ID
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeTransfer](bspc.EventNodeTransfer{
+			SourceMonitorID:      monitorID,
+			DestinationMonitorID: monitorID,
+		}))
+
+		assert.Equal(t, []string{"1", "2"}, fd.names())
+		assert.Equal(t, fd.focusedID, fd.desktops[0].ID)
+	})
+	t.Run("should fetch the state once per arrangement", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fd := newFakeDesktops(3, 0, 1)
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			State().
+			DoAndReturn(fd.state).
+			AnyTimes()
+		mockService.EXPECT().
+			Desktops().
+			Return(fd).
+			AnyTimes()
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = dynamicdesktops.Start(logger, mockService, fd, dynamicdesktops.Config{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, fd.stateCount)
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeTransfer](bspc.EventNodeTransfer{
+			SourceMonitorID:      monitorID,
+			DestinationMonitorID: monitorID + 1,
+		}))
+
+		assert.Equal(t, 2, fd.stateCount)
+	})
+	t.Run("should keep the focused desktop until it's left", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fd := newFakeDesktops(3, 0, 2)
+		fd.focusedID = fd.desktops[1].ID
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			State().
+			DoAndReturn(fd.state).
+			AnyTimes()
+		mockService.EXPECT().
+			Desktops().
+			Return(fd).
+			AnyTimes()
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = dynamicdesktops.Start(logger, mockService, fd, dynamicdesktops.Config{})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"1", "2", "3", "4"}, fd.names())
+
+		fd.focusedID = fd.desktops[3].ID
+		require.NoError(t, callbacks[bspc.EventTypeDesktopFocus](bspc.EventDesktopFocus{MonitorID: monitorID}))
+
+		assert.Equal(t, []string{"1", "3", "4"}, fd.names())
+	})
+	t.Run("should move an empty desktop to the end rather than add one", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fd := newFakeDesktops(3, 0, 2)
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+		)
+
+		mockService.EXPECT().
+			State().
+			DoAndReturn(fd.state).
+			AnyTimes()
+		mockService.EXPECT().
+			Desktops().
+			Return(fd).
+			AnyTimes()
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = dynamicdesktops.Start(logger, mockService, fd, dynamicdesktops.Config{})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"1", "3", "2"}, fd.names())
+		assert.Equal(t, []string{"1", "3", "2"}, fd.reorderedNames)
+	})
+	t.Run("should leave pinned desktops alone", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fd := newFakeDesktops(2, 1)
+		fd.desktops[0].Name = "chat"
+		fd.desktops[1].Name = "web"
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+		)
+
+		mockService.EXPECT().
+			State().
+			DoAndReturn(fd.state).
+			AnyTimes()
+		mockService.EXPECT().
+			Desktops().
+			Return(fd).
+			AnyTimes()
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = dynamicdesktops.Start(logger, mockService, fd, dynamicdesktops.Config{
+			Naming: dynamicdesktops.NamingNumeric,
+			Pinned: []string{"chat"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"chat", "1", "2"}, fd.names())
+	})
+	t.Run("should leave desktop labels out of names", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fd := newFakeDesktops(3, 1, 2)
+		fd.desktops[0].Name = "chat:slack"
+		fd.desktops[1].Name = "3:firefox"
+		fd.desktops[2].Name = "2:alacritty"
+		fd.bases = map[string]string{
+			"chat:slack":  "chat",
+			"3:firefox":   "3",
+			"2:alacritty": "2",
+		}
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+		)
+
+		mockService.EXPECT().
+			State().
+			DoAndReturn(fd.state).
+			AnyTimes()
+		mockService.EXPECT().
+			Desktops().
+			Return(fd).
+			AnyTimes()
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = dynamicdesktops.Start(logger, mockService, fd, dynamicdesktops.Config{
+			Naming: dynamicdesktops.NamingNumeric,
+			Pinned: []string{"chat"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"chat:slack", "1", "2:alacritty", "3"}, fd.names())
+	})
+	t.Run("should pick a name that isn't taken when not renaming", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fd := newFakeDesktops(2, 0, 1)
+		fd.desktops[0].Name = "3"
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+		)
+
+		mockService.EXPECT().
+			State().
+			DoAndReturn(fd.state).
+			AnyTimes()
+		mockService.EXPECT().
+			Desktops().
+			Return(fd).
+			AnyTimes()
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = dynamicdesktops.Start(logger, mockService, fd, dynamicdesktops.Config{})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"3", "2", "1"}, fd.names())
+	})
+	t.Run("should keep the names on each monitor clear of the others'", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fd := newFakeDesktops(2, 0)
+		fd.addOther([]string{"3", "4"}, 0)
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			State().
+			DoAndReturn(fd.state).
+			AnyTimes()
+		mockService.EXPECT().
+			Desktops().
+			Return(fd).
+			AnyTimes()
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = dynamicdesktops.Start(logger, mockService, fd, dynamicdesktops.Config{Naming: dynamicdesktops.NamingRoman})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"I", "II"}, fd.names())
+		assert.Equal(t, []string{"III", "IV"}, names(fd.otherDesktops))
+
+		fd.otherDesktops[1].Root = bspc.Node{ID: bspc.ID(4000)}
+		require.NoError(t, callbacks[bspc.EventTypeNodeAdd](bspc.EventNodeAdd{MonitorID: otherMonitorID}))
+
+		assert.Equal(t, []string{"I", "II"}, fd.names())
+		assert.Equal(t, []string{"III", "IV", "V"}, names(fd.otherDesktops))
+	})
+	t.Run("should keep the names clear of pinned desktops", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fd := newFakeDesktops(2, 0)
+		fd.addOther([]string{"3", "4"}, 0)
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+		)
+
+		mockService.EXPECT().
+			State().
+			DoAndReturn(fd.state).
+			AnyTimes()
+		mockService.EXPECT().
+			Desktops().
+			Return(fd).
+			AnyTimes()
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = dynamicdesktops.Start(logger, mockService, fd, dynamicdesktops.Config{
+			Naming: dynamicdesktops.NamingRoman,
+			Pinned: []string{"II"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"I", "III"}, fd.names())
+		assert.Equal(t, []string{"IV", "V"}, names(fd.otherDesktops))
+	})
+	t.Run("should fail to start with unknown naming scheme", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fd := newFakeDesktops(1)
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+		)
+
+		mockService.EXPECT().
+			State().
+			DoAndReturn(fd.state).
+			AnyTimes()
+		mockService.EXPECT().
+			Desktops().
+			Return(fd).
+			AnyTimes()
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = dynamicdesktops.Start(logger, mockService, fd, dynamicdesktops.Config{Naming: "unknown"})
+		require.Error(t, err)
+
+		assert.True(t, errors.Is(err, dynamicdesktops.ErrUnknownNaming))
+	})
+}
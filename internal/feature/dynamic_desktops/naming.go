@@ -0,0 +1,93 @@
+package dynamicdesktops
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	NamingNumeric    = "numeric"
+	NamingRoman      = "roman"
+	NamingAlphabetic = "alphabetic"
+)
+
+// maxRoman is the highest number that can be written in roman numerals without special symbols.
+const maxRoman = 3999
+
+var ErrUnknownNaming = errors.New("unknown naming scheme")
+
+// Naming names a monitor's dynamic desktops after their position among them, starting at 1.
+type Naming func(position int) string
+
+// namings are the schemes desktops can be named with, by name.
+var namings = map[string]Naming{
+	NamingNumeric:    strconv.Itoa,
+	NamingRoman:      roman,
+	NamingAlphabetic: alphabetic,
+}
+
+// NamingNames returns the names of the naming schemes, sorted.
+func NamingNames() []string {
+	names := make([]string, 0, len(namings))
+	for name := range namings {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// GetNaming returns the naming scheme with the given name.
+func GetNaming(name string) (Naming, error) {
+	n, ok := namings[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownNaming, name)
+	}
+
+	return n, nil
+}
+
+// roman names desktops I, II, III, etc. Past what roman numerals can hold, it falls back to numbers.
+func roman(position int) string {
+	if position < 1 || position > maxRoman {
+		return strconv.Itoa(position)
+	}
+
+	numerals := []struct {
+		value  int
+		symbol string
+	}{
+		{1000, "M"}, {900, "CM"}, {500, "D"}, {400, "CD"},
+		{100, "C"}, {90, "XC"}, {50, "L"}, {40, "XL"},
+		{10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"},
+		{1, "I"},
+	}
+
+	var sb strings.Builder
+	for _, n := range numerals {
+		for position >= n.value {
+			sb.WriteString(n.symbol)
+			position -= n.value
+		}
+	}
+
+	return sb.String()
+}
+
+// alphabetic names desktops a, b, c, etc., and then aa, ab, ac, etc., like spreadsheet columns.
+func alphabetic(position int) string {
+	if position < 1 {
+		return strconv.Itoa(position)
+	}
+
+	var name []byte
+	for ; position > 0; position = (position - 1) / 26 {
+		name = append([]byte{byte('a' + (position-1)%26)}, name...)
+	}
+
+	return string(name)
+}
@@ -0,0 +1,38 @@
+package dynamicdesktops_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dynamicdesktops "github.com/diogox/bspm/internal/feature/dynamic_desktops"
+)
+
+func TestGetNaming(t *testing.T) {
+	tt := []struct {
+		naming   string
+		position int
+		want     string
+	}{
+		{naming: dynamicdesktops.NamingNumeric, position: 1, want: "1"},
+		{naming: dynamicdesktops.NamingNumeric, position: 12, want: "12"},
+		{naming: dynamicdesktops.NamingRoman, position: 4, want: "IV"},
+		{naming: dynamicdesktops.NamingRoman, position: 14, want: "XIV"},
+		{naming: dynamicdesktops.NamingRoman, position: 1994, want: "MCMXCIV"},
+		{naming: dynamicdesktops.NamingRoman, position: 4000, want: "4000"},
+		{naming: dynamicdesktops.NamingAlphabetic, position: 1, want: "a"},
+		{naming: dynamicdesktops.NamingAlphabetic, position: 26, want: "z"},
+		{naming: dynamicdesktops.NamingAlphabetic, position: 27, want: "aa"},
+		{naming: dynamicdesktops.NamingAlphabetic, position: 703, want: "aaa"},
+	}
+
+	for _, tc := range tt {
+		t.Run("should name position "+tc.want+" with "+tc.naming, func(t *testing.T) {
+			naming, err := dynamicdesktops.GetNaming(tc.naming)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.want, naming(tc.position))
+		})
+	}
+}
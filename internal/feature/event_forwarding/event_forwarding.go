@@ -0,0 +1,212 @@
+//go:generate mockgen -package eventforwarding -destination ./event_forwarding_mock.go -self_package github.com/diogox/bspm/internal/feature/event_forwarding github.com/diogox/bspm/internal/feature/event_forwarding Feature
+
+package eventforwarding
+
+import (
+	"context"
+
+	"github.com/diogox/bspc-go"
+	"go.uber.org/zap"
+
+	"github.com/diogox/bspm/internal/bspwm"
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	bspwmtree "github.com/diogox/bspm/internal/bspwm/tree"
+	"github.com/diogox/bspm/internal/feature/event_forwarding/topic"
+	"github.com/diogox/bspm/internal/log"
+	"github.com/diogox/bspm/internal/subscription"
+	"github.com/diogox/bspm/internal/x11"
+)
+
+// eventBufferSize is larger than the default, since bspwm events tend to come in bursts (e.g. when switching desktops).
+const eventBufferSize = 64
+
+// EventTypes are all the bspwm event types that get forwarded.
+var EventTypes = bspwmevent.EventTypes
+
+type (
+	Feature interface {
+		Subscribe(ctx context.Context, eventTypes []bspc.EventType) chan Event
+	}
+
+	// Event is a bspwm event, along with information on the nodes it refers to.
+	Event struct {
+		Type    bspc.EventType `json:"type"`
+		Payload interface{}    `json:"payload"`
+		Nodes   []Node         `json:"nodes,omitempty"`
+	}
+
+	// Node holds the information bspc subscribe doesn't provide about a node referred to in an event.
+	Node struct {
+		ID           bspc.ID `json:"id"`
+		ClassName    string  `json:"class_name"`
+		InstanceName string  `json:"instance_name"`
+		Title        string  `json:"title"`
+	}
+
+	eventForwarding struct {
+		logger        *log.Logger
+		service       bspwm.Service
+		tree          bspwmtree.Tree
+		subscriptions subscription.Manager
+		windows       x11.Properties
+	}
+)
+
+// Start publishes every bspwm event to the subscription manager. It can be called at any time.
+// Window titles are only resolved if windows is not nil.
+func Start(
+	logger *log.Logger,
+	service bspwm.Service,
+	tree bspwmtree.Tree,
+	subscriptions subscription.Manager,
+	windows x11.Properties,
+) Feature {
+	for _, t := range EventTypes {
+		eventType := t
+
+		service.Events().On(eventType, func(eventPayload interface{}) error {
+			topic.BspwmEvent.Publish(subscriptions, bspc.Event{
+				Type:    eventType,
+				Payload: eventPayload,
+			})
+
+			return nil
+		})
+	}
+
+	return eventForwarding{
+		logger:        logger,
+		service:       service,
+		tree:          tree,
+		subscriptions: subscriptions,
+		windows:       windows,
+	}
+}
+
+// IsForwarded returns true if events of the given type are forwarded.
+func IsForwarded(eventType bspc.EventType) bool {
+	for _, t := range EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Subscribe returns a channel with the bspwm events of the given types. All events are returned if no type is given.
+// The channel is closed when the context is done.
+func (ef eventForwarding) Subscribe(ctx context.Context, eventTypes []bspc.EventType) chan Event {
+	wanted := make(map[bspc.EventType]struct{}, len(eventTypes))
+	for _, t := range eventTypes {
+		wanted[t] = struct{}{}
+	}
+
+	var (
+		eventCh = topic.BspwmEvent.Subscribe(ctx, ef.subscriptions, subscription.WithBufferSize(eventBufferSize))
+		resCh   = make(chan Event, 1)
+	)
+
+	go func() {
+		defer close(resCh)
+
+		for ev := range eventCh {
+			if _, ok := wanted[ev.Type]; len(wanted) != 0 && !ok {
+				continue
+			}
+
+			select {
+			case resCh <- Event{
+				Type:    ev.Type,
+				Payload: ev.Payload,
+				Nodes:   ef.describeNodes(ev),
+			}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return resCh
+}
+
+func (ef eventForwarding) describeNodes(ev bspc.Event) []Node {
+	ids := nodeIDs(ev.Payload)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	nodes := make([]Node, 0, len(ids))
+	for _, id := range ids {
+		if id == bspc.NilID {
+			continue
+		}
+
+		n, err := ef.tree.Node(id)
+		if err != nil {
+			// The node is most likely gone already (e.g. in node_remove events).
+			continue
+		}
+
+		if n.Client == nil {
+			// Not a window, so there's nothing to describe.
+			continue
+		}
+
+		node := Node{
+			ID:           id,
+			ClassName:    n.Client.ClassName,
+			InstanceName: n.Client.InstanceName,
+		}
+
+		if ef.windows != nil {
+			title, err := ef.windows.Title(id)
+			if err != nil {
+				ef.logger.Warning("failed to get window title",
+					zap.Uint("node_id", uint(id)),
+					zap.Error(err),
+				)
+			}
+
+			node.Title = title
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	return nodes
+}
+
+// nodeIDs returns the ids of the nodes referred to in the event payload.
+func nodeIDs(eventPayload interface{}) []bspc.ID {
+	switch p := eventPayload.(type) {
+	case bspc.EventNodeAdd:
+		return []bspc.ID{p.NodeID}
+	case bspc.EventNodeRemove:
+		return []bspc.ID{p.NodeID}
+	case bspc.EventNodeSwap:
+		return []bspc.ID{p.SourceNodeID, p.DestinationNodeID}
+	case bspc.EventNodeTransfer:
+		return []bspc.ID{p.SourceNodeID, p.DestinationNodeID}
+	case bspc.EventNodeFocus:
+		return []bspc.ID{p.NodeID}
+	case bspc.EventNodeActivate:
+		return []bspc.ID{p.NodeID}
+	case bspc.EventNodePreselect:
+		return []bspc.ID{p.NodeID}
+	case bspc.EventNodeStack:
+		return []bspc.ID{p.Node1ID, p.Node2ID}
+	case bspc.EventNodeGeometry:
+		return []bspc.ID{p.NodeID}
+	case bspc.EventNodeState:
+		return []bspc.ID{p.NodeID}
+	case bspc.EventNodeFlag:
+		return []bspc.ID{p.NodeID}
+	case bspc.EventNodeLayer:
+		return []bspc.ID{p.NodeID}
+	case bspc.EventPointerAction:
+		return []bspc.ID{p.NodeID}
+	default:
+		return nil
+	}
+}
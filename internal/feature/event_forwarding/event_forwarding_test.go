@@ -0,0 +1,179 @@
+package eventforwarding_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/diogox/bspc-go"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/diogox/bspm/internal/bspwm"
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	bspwmtree "github.com/diogox/bspm/internal/bspwm/tree"
+	eventforwarding "github.com/diogox/bspm/internal/feature/event_forwarding"
+	"github.com/diogox/bspm/internal/feature/event_forwarding/topic"
+	"github.com/diogox/bspm/internal/log"
+	"github.com/diogox/bspm/internal/subscription"
+	"github.com/diogox/bspm/internal/x11"
+)
+
+func TestStart(t *testing.T) {
+	t.Run("should publish every bspwm event", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockEventManager  = bspwmevent.NewMockManager(ctrl)
+			mockService       = bspwm.NewMockService(ctrl)
+			mockSubscriptions = subscription.NewMockManager(ctrl)
+			callbacks         = make(map[bspc.EventType]func(interface{}) error)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			Times(len(eventforwarding.EventTypes))
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			Do(func(eventType bspc.EventType, callback func(interface{}) error) {
+				callbacks[eventType] = callback
+			}).
+			Times(len(eventforwarding.EventTypes))
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		eventforwarding.Start(logger, mockService, bspwmtree.NewMockTree(ctrl), mockSubscriptions, nil)
+
+		payload := bspc.EventNodeFocus{NodeID: bspc.ID(1)}
+
+		mockSubscriptions.EXPECT().
+			Publish(topic.BspwmEvent.Name(), bspc.Event{
+				Type:    bspc.EventTypeNodeFocus,
+				Payload: payload,
+			})
+
+		require.Contains(t, callbacks, bspc.EventTypeNodeFocus)
+		assert.NoError(t, callbacks[bspc.EventTypeNodeFocus](payload))
+	})
+}
+
+func TestEventForwarding_Subscribe(t *testing.T) {
+	t.Run("should return events of the given types with node info", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockEventManager  = bspwmevent.NewMockManager(ctrl)
+			mockTree          = bspwmtree.NewMockTree(ctrl)
+			mockService       = bspwm.NewMockService(ctrl)
+			mockSubscriptions = subscription.NewMockManager(ctrl)
+			mockWindows       = x11.NewMockProperties(ctrl)
+			eventCh           = make(chan interface{}, 2)
+			nodeID            = bspc.ID(3)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		mockSubscriptions.EXPECT().
+			Subscribe(gomock.Any(), topic.BspwmEvent.Name(), gomock.Any()).
+			Return(eventCh)
+		mockTree.EXPECT().
+			Node(nodeID).
+			Return(bspc.Node{
+				ID: nodeID,
+				Client: &bspc.NodeClient{
+					ClassName:    "Alacritty",
+					InstanceName: "alacritty",
+				},
+			}, nil)
+		mockWindows.EXPECT().
+			Title(nodeID).
+			Return("~/bspm", nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		resCh := eventforwarding.
+			Start(logger, mockService, mockTree, mockSubscriptions, mockWindows).
+			Subscribe(context.Background(), []bspc.EventType{bspc.EventTypeNodeAdd})
+
+		eventCh <- bspc.Event{Type: bspc.EventTypeDesktopFocus, Payload: bspc.EventDesktopFocus{}}
+		eventCh <- bspc.Event{Type: bspc.EventTypeNodeAdd, Payload: bspc.EventNodeAdd{NodeID: nodeID}}
+
+		select {
+		case got := <-resCh:
+			assert.Equal(t, eventforwarding.Event{
+				Type:    bspc.EventTypeNodeAdd,
+				Payload: bspc.EventNodeAdd{NodeID: nodeID},
+				Nodes: []eventforwarding.Node{
+					{
+						ID:           nodeID,
+						ClassName:    "Alacritty",
+						InstanceName: "alacritty",
+						Title:        "~/bspm",
+					},
+				},
+			}, got)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for event")
+		}
+	})
+	t.Run("should skip nodes that no longer exist", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockEventManager  = bspwmevent.NewMockManager(ctrl)
+			mockTree          = bspwmtree.NewMockTree(ctrl)
+			mockService       = bspwm.NewMockService(ctrl)
+			mockSubscriptions = subscription.NewMockManager(ctrl)
+			eventCh           = make(chan interface{}, 1)
+			payload           = bspc.EventNodeRemove{NodeID: bspc.ID(3)}
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		mockSubscriptions.EXPECT().
+			Subscribe(gomock.Any(), topic.BspwmEvent.Name(), gomock.Any()).
+			Return(eventCh)
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			Return(bspc.Node{}, errors.New("error"))
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		resCh := eventforwarding.
+			Start(logger, mockService, mockTree, mockSubscriptions, nil).
+			Subscribe(context.Background(), nil)
+
+		eventCh <- bspc.Event{Type: bspc.EventTypeNodeRemove, Payload: payload}
+
+		select {
+		case got := <-resCh:
+			assert.Equal(t, eventforwarding.Event{
+				Type:    bspc.EventTypeNodeRemove,
+				Payload: payload,
+				Nodes:   []eventforwarding.Node{},
+			}, got)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for event")
+		}
+	})
+}
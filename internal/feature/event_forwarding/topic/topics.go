@@ -0,0 +1,11 @@
+package topic
+
+import (
+	"github.com/diogox/bspc-go"
+
+	"github.com/diogox/bspm/internal/subscription"
+)
+
+const (
+	BspwmEvent subscription.Topic[bspc.Event] = "bspwm/event"
+)
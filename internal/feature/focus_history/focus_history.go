@@ -0,0 +1,440 @@
+//go:generate mockgen -package focushistory -destination ./focus_history_mock.go -self_package github.com/diogox/bspm/internal/feature/focus_history github.com/diogox/bspm/internal/feature/focus_history Feature
+
+package focushistory
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/diogox/bspc-go"
+	"go.uber.org/zap"
+
+	"github.com/diogox/bspm/internal/bspwm"
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	bspwmtree "github.com/diogox/bspm/internal/bspwm/tree"
+	transparentmonocle "github.com/diogox/bspm/internal/feature/transparent_monocle"
+	"github.com/diogox/bspm/internal/log"
+	"github.com/diogox/bspm/internal/x11"
+)
+
+// maxEntries caps the history, dropping the oldest focus changes first.
+const maxEntries = 1000
+
+var ErrNoHistory = errors.New("no window to go to in the focus history")
+
+type (
+	// Feature keeps track of the windows focused across every desktop, unlike bspwm's own history, which only goes
+	// back and forth between nodes. Windows hidden outside a monocle stack (e.g. minimised ones) can't be focused,
+	// so going through the history skips them.
+	Feature interface {
+		// Back focuses the window that was focused before the current one, going further back each time.
+		Back() error
+		// Forward undoes Back.
+		Forward() error
+
+		// CycleStart starts an alt-tab style cycle through the windows, from the most recently focused one.
+		CycleStart() error
+		// CycleStep focuses the window the given number of places away from the current one in the cycle, wrapping
+		// around. It starts a cycle, if there isn't one already.
+		CycleStep(offset int) error
+		// CycleCommit ends the cycle, making the window it's on the most recently focused one. Until then, the
+		// windows it goes through don't change the order they're cycled in.
+		CycleCommit() error
+
+		// List returns the windows in the history, the most recently focused first.
+		List() []Entry
+	}
+
+	// Entry is a window in the focus history.
+	Entry struct {
+		NodeID    bspc.ID
+		DesktopID bspc.ID
+		FocusedAt time.Time
+
+		ClassName    string
+		InstanceName string
+
+		// Title is empty if the window's properties can't be read.
+		Title string
+
+		// IsHidden is true for hidden windows. Those hidden in a monocle stack are shown again when they're focused.
+		IsHidden bool
+	}
+
+	focus struct {
+		nodeID    bspc.ID
+		desktopID bspc.ID
+		at        time.Time
+	}
+
+	// cycle is an alt-tab style cycle in progress.
+	cycle struct {
+		focuses []focus
+		index   int
+	}
+
+	feature struct {
+		logger  *log.Logger
+		tree    bspwmtree.Tree
+		windows x11.Properties
+		monocle transparentmonocle.Feature
+
+		mutex sync.Mutex
+
+		// focuses are in the order they happened in, and cursor is where Back and Forward have taken the history
+		// to. It's at the last one unless going back.
+		focuses []focus
+		cursor  int
+		cycle   *cycle
+	}
+)
+
+// Start records every window focused until the returned function is called, starting with the one focused now.
+// Window titles are only resolved if windows is not nil.
+func Start(
+	logger *log.Logger,
+	service bspwm.Service,
+	tree bspwmtree.Tree,
+	windows x11.Properties,
+	monocle transparentmonocle.Feature,
+) (Feature, func()) {
+	f := &feature{
+		logger:  logger,
+		tree:    tree,
+		windows: windows,
+		monocle: monocle,
+	}
+
+	if desktop, err := tree.FocusedDesktop(); err == nil && desktop.FocusedNodeID != bspc.NilID {
+		f.record(desktop.ID, desktop.FocusedNodeID)
+	}
+
+	handles := []bspwmevent.Handle{
+		bspwmevent.On(service.Events(), func(payload bspc.EventNodeFocus) error {
+			f.handleNodeFocused(payload.DesktopID, payload.NodeID)
+			return nil
+		}),
+		bspwmevent.On(service.Events(), func(payload bspc.EventNodeRemove) error {
+			f.handleNodeRemoved(payload.NodeID)
+			return nil
+		}),
+		bspwmevent.On(service.Events(), func(payload bspc.EventNodeTransfer) error {
+			f.handleNodeTransferred(payload.SourceNodeID, payload.DestinationDesktopID)
+			return nil
+		}),
+	}
+
+	cancelFunc := func() {
+		for _, h := range handles {
+			service.Events().Off(h)
+		}
+	}
+
+	return f, cancelFunc
+}
+
+func (f *feature) Back() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.cycle = nil
+
+	for i := f.cursor - 1; i >= 0; i-- {
+		if f.focuses[i].nodeID == f.focuses[f.cursor].nodeID {
+			continue
+		}
+
+		if err := f.moveCursor(i); !isHidden(err) {
+			return err
+		}
+	}
+
+	return ErrNoHistory
+}
+
+func (f *feature) Forward() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.cycle = nil
+
+	for i := f.cursor + 1; i < len(f.focuses); i++ {
+		if f.focuses[i].nodeID == f.focuses[f.cursor].nodeID {
+			continue
+		}
+
+		if err := f.moveCursor(i); !isHidden(err) {
+			return err
+		}
+	}
+
+	return ErrNoHistory
+}
+
+// moveCursor focuses the window at the given place in the history. It must be called with the mutex held.
+func (f *feature) moveCursor(i int) error {
+	if err := f.focus(f.focuses[i].nodeID); err != nil {
+		return err
+	}
+
+	f.cursor = i
+
+	return nil
+}
+
+func (f *feature) CycleStart() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.startCycle()
+
+	return nil
+}
+
+// startCycle takes the windows to cycle through, the current one first. It must be called with the mutex held.
+func (f *feature) startCycle() {
+	f.cycle = &cycle{
+		focuses: f.recent(),
+	}
+}
+
+func (f *feature) CycleStep(offset int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.cycle == nil {
+		f.startCycle()
+	}
+
+	c := f.cycle
+	if len(c.focuses) < 2 {
+		return ErrNoHistory
+	}
+
+	direction := 1
+	if offset < 0 {
+		direction = -1
+	}
+
+	for n := 0; n < len(c.focuses); n++ {
+		i := ((c.index+offset+n*direction)%len(c.focuses) + len(c.focuses)) % len(c.focuses)
+
+		err := f.focus(c.focuses[i].nodeID)
+		if isHidden(err) {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		c.index = i
+
+		return nil
+	}
+
+	return ErrNoHistory
+}
+
+func (f *feature) CycleCommit() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.cycle == nil {
+		return nil
+	}
+
+	current := f.cycle.focuses[f.cycle.index]
+	f.cycle = nil
+
+	if current.nodeID != f.current() {
+		f.record(current.desktopID, current.nodeID)
+	}
+
+	return nil
+}
+
+func (f *feature) List() []Entry {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	recent := f.recent()
+
+	entries := make([]Entry, 0, len(recent))
+	for _, fc := range recent {
+		entries = append(entries, f.describe(fc))
+	}
+
+	return entries
+}
+
+// focus focuses the window, showing it first if it's hidden in a monocle stack. It must be called with the mutex held.
+func (f *feature) focus(nodeID bspc.ID) error {
+	if err := f.monocle.FocusNode(nodeID); err != nil {
+		return fmt.Errorf("failed to focus node: %w", err)
+	}
+
+	return nil
+}
+
+// isHidden returns true if the window couldn't be focused because it's hidden outside a monocle stack, so it's
+// gone past instead.
+func isHidden(err error) bool {
+	return errors.Is(err, transparentmonocle.ErrNodeHidden)
+}
+
+// handleNodeFocused records the focus change, unless it's a window the history itself was taken to.
+func (f *feature) handleNodeFocused(desktopID bspc.ID, nodeID bspc.ID) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.cycle != nil {
+		if f.cycle.focuses[f.cycle.index].nodeID == nodeID {
+			return
+		}
+
+		// Focus moved elsewhere in the middle of the cycle, so it's abandoned.
+		f.cycle = nil
+	}
+
+	if nodeID == f.current() {
+		return
+	}
+
+	node, err := f.tree.Node(nodeID)
+	if err != nil || node.Client == nil {
+		// Only windows are worth going back to.
+		return
+	}
+
+	f.record(desktopID, nodeID)
+}
+
+// record adds a focus change to the history, and brings the cursor back to it. It must be called with the mutex held.
+func (f *feature) record(desktopID bspc.ID, nodeID bspc.ID) {
+	if f.cursor < len(f.focuses)-1 {
+		// It's moving on from where the history was taken back to, so that's where going back should return to.
+		f.focuses = append(f.focuses, f.focuses[f.cursor])
+	}
+
+	f.focuses = append(f.focuses, focus{
+		nodeID:    nodeID,
+		desktopID: desktopID,
+		at:        time.Now(),
+	})
+
+	if len(f.focuses) > maxEntries {
+		f.focuses = f.focuses[len(f.focuses)-maxEntries:]
+	}
+
+	f.cursor = len(f.focuses) - 1
+}
+
+func (f *feature) handleNodeRemoved(nodeID bspc.ID) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	focuses := make([]focus, 0, len(f.focuses))
+	cursor := f.cursor
+
+	for i, fc := range f.focuses {
+		if fc.nodeID == nodeID {
+			if i <= f.cursor {
+				cursor--
+			}
+
+			continue
+		}
+
+		focuses = append(focuses, fc)
+	}
+
+	f.focuses = focuses
+	f.cursor = cursor
+
+	if f.cursor < 0 && len(f.focuses) > 0 {
+		f.cursor = 0
+	}
+
+	// The window the cycle is on might be the one that's gone, so it has to be started over.
+	f.cycle = nil
+}
+
+// handleNodeTransferred keeps track of the desktop of windows moved to another one.
+func (f *feature) handleNodeTransferred(nodeID bspc.ID, desktopID bspc.ID) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	for i := range f.focuses {
+		if f.focuses[i].nodeID == nodeID {
+			f.focuses[i].desktopID = desktopID
+		}
+	}
+}
+
+// current returns the window the history is at, or bspc.NilID if it's empty. It must be called with the mutex held.
+func (f *feature) current() bspc.ID {
+	if len(f.focuses) == 0 {
+		return bspc.NilID
+	}
+
+	return f.focuses[f.cursor].nodeID
+}
+
+// recent returns the last focus of each window, the current one first and then the most recently focused ones.
+// It must be called with the mutex held.
+func (f *feature) recent() []focus {
+	if len(f.focuses) == 0 {
+		return nil
+	}
+
+	var (
+		recent []focus
+		seen   = make(map[bspc.ID]bool)
+	)
+
+	add := func(fc focus) {
+		if !seen[fc.nodeID] {
+			seen[fc.nodeID] = true
+			recent = append(recent, fc)
+		}
+	}
+
+	add(f.focuses[f.cursor])
+
+	for i := len(f.focuses) - 1; i >= 0; i-- {
+		add(f.focuses[i])
+	}
+
+	return recent
+}
+
+func (f *feature) describe(fc focus) Entry {
+	entry := Entry{
+		NodeID:    fc.nodeID,
+		DesktopID: fc.desktopID,
+		FocusedAt: fc.at,
+	}
+
+	if n, err := f.tree.Node(fc.nodeID); err == nil && n.Client != nil {
+		entry.ClassName = n.Client.ClassName
+		entry.InstanceName = n.Client.InstanceName
+		entry.IsHidden = n.Hidden
+	}
+
+	if f.windows != nil {
+		title, err := f.windows.Title(fc.nodeID)
+		if err != nil {
+			f.logger.Warning("failed to get window title",
+				zap.Uint("node_id", uint(fc.nodeID)),
+				zap.Error(err),
+			)
+		}
+
+		entry.Title = title
+	}
+
+	return entry
+}
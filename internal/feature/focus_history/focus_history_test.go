@@ -0,0 +1,661 @@
+package focushistory_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/diogox/bspc-go"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/diogox/bspm/internal/bspwm"
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	bspwmtree "github.com/diogox/bspm/internal/bspwm/tree"
+	focushistory "github.com/diogox/bspm/internal/feature/focus_history"
+	transparentmonocle "github.com/diogox/bspm/internal/feature/transparent_monocle"
+	"github.com/diogox/bspm/internal/log"
+)
+
+const desktopID = bspc.ID(100)
+
+// nodeIDs returns the ids of the windows in the entries.
+func nodeIDs(entries []focushistory.Entry) []bspc.ID {
+	var ids []bspc.ID
+	for _, e := range entries {
+		ids = append(ids, e.NodeID)
+	}
+
+	return ids
+}
+
+func TestFocusHistory(t *testing.T) {
+	t.Run("should list the windows, the most recently focused first", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockMonocle      = transparentmonocle.NewMockFeature(ctrl)
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1}, nil)
+
+		// Every node is a window, except for the ones from 10 up, which are splits.
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (bspc.Node, error) {
+				if id >= 10 {
+					return bspc.Node{ID: id}, nil
+				}
+
+				return bspc.Node{ID: id, Client: &bspc.NodeClient{ClassName: "Alacritty"}, Hidden: id == 3}, nil
+			}).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := focushistory.Start(logger, mockService, mockTree, nil, mockMonocle)
+
+		for _, id := range []bspc.ID{2, 3, 2, 10} {
+			require.NoError(t, callbacks[bspc.EventTypeNodeFocus](bspc.EventNodeFocus{DesktopID: desktopID, NodeID: id}))
+		}
+
+		entries := feature.List()
+		require.Len(t, entries, 3)
+
+		assert.Equal(t, bspc.ID(2), entries[0].NodeID)
+		assert.Equal(t, desktopID, entries[0].DesktopID)
+		assert.Equal(t, "Alacritty", entries[0].ClassName)
+		assert.False(t, entries[0].FocusedAt.IsZero())
+		assert.Equal(t, []bspc.ID{2, 3, 1}, nodeIDs(feature.List()))
+		assert.True(t, entries[1].IsHidden)
+	})
+	t.Run("should go back and forth through the history", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockMonocle      = transparentmonocle.NewMockFeature(ctrl)
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1}, nil)
+
+		// Every node is a window, except for the ones from 10 up, which are splits.
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (bspc.Node, error) {
+				if id >= 10 {
+					return bspc.Node{ID: id}, nil
+				}
+
+				return bspc.Node{ID: id, Client: &bspc.NodeClient{ClassName: "Alacritty"}, Hidden: id == 3}, nil
+			}).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := focushistory.Start(logger, mockService, mockTree, nil, mockMonocle)
+
+		for _, id := range []bspc.ID{2, 3} {
+			require.NoError(t, callbacks[bspc.EventTypeNodeFocus](bspc.EventNodeFocus{DesktopID: desktopID, NodeID: id}))
+		}
+
+		mockMonocle.EXPECT().
+			FocusNode(bspc.ID(2)).
+			Return(nil)
+
+		require.NoError(t, feature.Back())
+		require.NoError(t, callbacks[bspc.EventTypeNodeFocus](bspc.EventNodeFocus{DesktopID: desktopID, NodeID: 2}))
+
+		mockMonocle.EXPECT().
+			FocusNode(bspc.ID(1)).
+			Return(nil)
+
+		require.NoError(t, feature.Back())
+		require.NoError(t, callbacks[bspc.EventTypeNodeFocus](bspc.EventNodeFocus{DesktopID: desktopID, NodeID: 1}))
+
+		err = feature.Back()
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, focushistory.ErrNoHistory))
+
+		mockMonocle.EXPECT().
+			FocusNode(bspc.ID(2)).
+			Return(nil)
+
+		require.NoError(t, feature.Forward())
+		require.NoError(t, callbacks[bspc.EventTypeNodeFocus](bspc.EventNodeFocus{DesktopID: desktopID, NodeID: 2}))
+
+		// Going back and forth doesn't change the history.
+		assert.Equal(t, []bspc.ID{2, 3, 1}, nodeIDs(feature.List()))
+	})
+	t.Run("should go back from where the window focused last", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockMonocle      = transparentmonocle.NewMockFeature(ctrl)
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1}, nil)
+
+		// Every node is a window, except for the ones from 10 up, which are splits.
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (bspc.Node, error) {
+				if id >= 10 {
+					return bspc.Node{ID: id}, nil
+				}
+
+				return bspc.Node{ID: id, Client: &bspc.NodeClient{ClassName: "Alacritty"}, Hidden: id == 3}, nil
+			}).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := focushistory.Start(logger, mockService, mockTree, nil, mockMonocle)
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeFocus](bspc.EventNodeFocus{DesktopID: desktopID, NodeID: 2}))
+
+		mockMonocle.EXPECT().
+			FocusNode(bspc.ID(1)).
+			Return(nil)
+
+		require.NoError(t, feature.Back())
+		require.NoError(t, callbacks[bspc.EventTypeNodeFocus](bspc.EventNodeFocus{DesktopID: desktopID, NodeID: 1}))
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeFocus](bspc.EventNodeFocus{DesktopID: desktopID, NodeID: 3}))
+
+		mockMonocle.EXPECT().
+			FocusNode(bspc.ID(1)).
+			Return(nil)
+
+		require.NoError(t, feature.Back())
+		require.NoError(t, callbacks[bspc.EventTypeNodeFocus](bspc.EventNodeFocus{DesktopID: desktopID, NodeID: 1}))
+	})
+	t.Run("should only reorder the windows once the cycle is committed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockMonocle      = transparentmonocle.NewMockFeature(ctrl)
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1}, nil)
+
+		// Every node is a window, except for the ones from 10 up, which are splits.
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (bspc.Node, error) {
+				if id >= 10 {
+					return bspc.Node{ID: id}, nil
+				}
+
+				return bspc.Node{ID: id, Client: &bspc.NodeClient{ClassName: "Alacritty"}, Hidden: id == 3}, nil
+			}).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := focushistory.Start(logger, mockService, mockTree, nil, mockMonocle)
+
+		for _, id := range []bspc.ID{2, 3, 4} {
+			require.NoError(t, callbacks[bspc.EventTypeNodeFocus](bspc.EventNodeFocus{DesktopID: desktopID, NodeID: id}))
+		}
+
+		require.NoError(t, feature.CycleStart())
+
+		mockMonocle.EXPECT().
+			FocusNode(bspc.ID(3)).
+			Return(nil)
+
+		require.NoError(t, feature.CycleStep(1))
+		require.NoError(t, callbacks[bspc.EventTypeNodeFocus](bspc.EventNodeFocus{DesktopID: desktopID, NodeID: 3}))
+
+		mockMonocle.EXPECT().
+			FocusNode(bspc.ID(2)).
+			Return(nil)
+
+		require.NoError(t, feature.CycleStep(1))
+		require.NoError(t, callbacks[bspc.EventTypeNodeFocus](bspc.EventNodeFocus{DesktopID: desktopID, NodeID: 2}))
+
+		assert.Equal(t, []bspc.ID{4, 3, 2, 1}, nodeIDs(feature.List()))
+
+		require.NoError(t, feature.CycleCommit())
+
+		assert.Equal(t, []bspc.ID{2, 4, 3, 1}, nodeIDs(feature.List()))
+	})
+	t.Run("should wrap around when cycling", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockMonocle      = transparentmonocle.NewMockFeature(ctrl)
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1}, nil)
+
+		// Every node is a window, except for the ones from 10 up, which are splits.
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (bspc.Node, error) {
+				if id >= 10 {
+					return bspc.Node{ID: id}, nil
+				}
+
+				return bspc.Node{ID: id, Client: &bspc.NodeClient{ClassName: "Alacritty"}, Hidden: id == 3}, nil
+			}).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := focushistory.Start(logger, mockService, mockTree, nil, mockMonocle)
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeFocus](bspc.EventNodeFocus{DesktopID: desktopID, NodeID: 2}))
+
+		// Stepping starts a cycle, if there isn't one.
+		mockMonocle.EXPECT().
+			FocusNode(bspc.ID(1)).
+			Return(nil)
+
+		require.NoError(t, feature.CycleStep(-1))
+		require.NoError(t, callbacks[bspc.EventTypeNodeFocus](bspc.EventNodeFocus{DesktopID: desktopID, NodeID: 1}))
+
+		mockMonocle.EXPECT().
+			FocusNode(bspc.ID(2)).
+			Return(nil)
+
+		require.NoError(t, feature.CycleStep(-1))
+		require.NoError(t, callbacks[bspc.EventTypeNodeFocus](bspc.EventNodeFocus{DesktopID: desktopID, NodeID: 2}))
+
+		require.NoError(t, feature.CycleCommit())
+
+		assert.Equal(t, []bspc.ID{2, 1}, nodeIDs(feature.List()))
+	})
+	t.Run("should abandon the cycle once something else is focused", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockMonocle      = transparentmonocle.NewMockFeature(ctrl)
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1}, nil)
+
+		// Every node is a window, except for the ones from 10 up, which are splits.
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (bspc.Node, error) {
+				if id >= 10 {
+					return bspc.Node{ID: id}, nil
+				}
+
+				return bspc.Node{ID: id, Client: &bspc.NodeClient{ClassName: "Alacritty"}, Hidden: id == 3}, nil
+			}).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := focushistory.Start(logger, mockService, mockTree, nil, mockMonocle)
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeFocus](bspc.EventNodeFocus{DesktopID: desktopID, NodeID: 2}))
+
+		mockMonocle.EXPECT().
+			FocusNode(bspc.ID(1)).
+			Return(nil)
+
+		require.NoError(t, feature.CycleStep(1))
+		require.NoError(t, callbacks[bspc.EventTypeNodeFocus](bspc.EventNodeFocus{DesktopID: desktopID, NodeID: 1}))
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeFocus](bspc.EventNodeFocus{DesktopID: desktopID, NodeID: 4}))
+		require.NoError(t, feature.CycleCommit())
+
+		assert.Equal(t, []bspc.ID{4, 2, 1}, nodeIDs(feature.List()))
+	})
+	t.Run("should go back past minimised windows", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockMonocle      = transparentmonocle.NewMockFeature(ctrl)
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1}, nil)
+
+		// Every node is a window, except for the ones from 10 up, which are splits.
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (bspc.Node, error) {
+				if id >= 10 {
+					return bspc.Node{ID: id}, nil
+				}
+
+				return bspc.Node{ID: id, Client: &bspc.NodeClient{ClassName: "Alacritty"}, Hidden: id == 3}, nil
+			}).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := focushistory.Start(logger, mockService, mockTree, nil, mockMonocle)
+
+		for _, id := range []bspc.ID{2, 3} {
+			require.NoError(t, callbacks[bspc.EventTypeNodeFocus](bspc.EventNodeFocus{DesktopID: desktopID, NodeID: id}))
+		}
+
+		mockMonocle.EXPECT().
+			FocusNode(bspc.ID(2)).
+			Return(transparentmonocle.ErrNodeHidden)
+		mockMonocle.EXPECT().
+			FocusNode(bspc.ID(1)).
+			Return(nil)
+
+		require.NoError(t, feature.Back())
+		require.NoError(t, callbacks[bspc.EventTypeNodeFocus](bspc.EventNodeFocus{DesktopID: desktopID, NodeID: 1}))
+	})
+	t.Run("should cycle past minimised windows", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockMonocle      = transparentmonocle.NewMockFeature(ctrl)
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1}, nil)
+
+		// Every node is a window, except for the ones from 10 up, which are splits.
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (bspc.Node, error) {
+				if id >= 10 {
+					return bspc.Node{ID: id}, nil
+				}
+
+				return bspc.Node{ID: id, Client: &bspc.NodeClient{ClassName: "Alacritty"}, Hidden: id == 3}, nil
+			}).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := focushistory.Start(logger, mockService, mockTree, nil, mockMonocle)
+
+		for _, id := range []bspc.ID{2, 3} {
+			require.NoError(t, callbacks[bspc.EventTypeNodeFocus](bspc.EventNodeFocus{DesktopID: desktopID, NodeID: id}))
+		}
+
+		mockMonocle.EXPECT().
+			FocusNode(bspc.ID(2)).
+			Return(transparentmonocle.ErrNodeHidden).
+			Times(2)
+		mockMonocle.EXPECT().
+			FocusNode(bspc.ID(1)).
+			Return(nil)
+
+		require.NoError(t, feature.CycleStep(1))
+		require.NoError(t, callbacks[bspc.EventTypeNodeFocus](bspc.EventNodeFocus{DesktopID: desktopID, NodeID: 1}))
+
+		// Going the other way, it's gone past again.
+		mockMonocle.EXPECT().
+			FocusNode(bspc.ID(3)).
+			Return(nil)
+
+		require.NoError(t, feature.CycleStep(-1))
+		require.NoError(t, callbacks[bspc.EventTypeNodeFocus](bspc.EventNodeFocus{DesktopID: desktopID, NodeID: 3}))
+	})
+	t.Run("should forget closed windows", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockMonocle      = transparentmonocle.NewMockFeature(ctrl)
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1}, nil)
+
+		// Every node is a window, except for the ones from 10 up, which are splits.
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (bspc.Node, error) {
+				if id >= 10 {
+					return bspc.Node{ID: id}, nil
+				}
+
+				return bspc.Node{ID: id, Client: &bspc.NodeClient{ClassName: "Alacritty"}, Hidden: id == 3}, nil
+			}).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := focushistory.Start(logger, mockService, mockTree, nil, mockMonocle)
+
+		for _, id := range []bspc.ID{2, 1, 3} {
+			require.NoError(t, callbacks[bspc.EventTypeNodeFocus](bspc.EventNodeFocus{DesktopID: desktopID, NodeID: id}))
+		}
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeRemove](bspc.EventNodeRemove{DesktopID: desktopID, NodeID: 1}))
+
+		assert.Equal(t, []bspc.ID{3, 2}, nodeIDs(feature.List()))
+
+		mockMonocle.EXPECT().
+			FocusNode(bspc.ID(2)).
+			Return(nil)
+
+		require.NoError(t, feature.Back())
+		require.NoError(t, callbacks[bspc.EventTypeNodeFocus](bspc.EventNodeFocus{DesktopID: desktopID, NodeID: 2}))
+	})
+	t.Run("should follow windows moved to another desktop", func(t *testing.T) {
+		const otherDesktopID = bspc.ID(200)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockMonocle      = transparentmonocle.NewMockFeature(ctrl)
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1}, nil)
+
+		// Every node is a window, except for the ones from 10 up, which are splits.
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (bspc.Node, error) {
+				if id >= 10 {
+					return bspc.Node{ID: id}, nil
+				}
+
+				return bspc.Node{ID: id, Client: &bspc.NodeClient{ClassName: "Alacritty"}, Hidden: id == 3}, nil
+			}).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := focushistory.Start(logger, mockService, mockTree, nil, mockMonocle)
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeTransfer](bspc.EventNodeTransfer{
+			SourceDesktopID:      desktopID,
+			SourceNodeID:         1,
+			DestinationDesktopID: otherDesktopID,
+		}))
+
+		assert.Equal(t, otherDesktopID, feature.List()[0].DesktopID)
+	})
+}
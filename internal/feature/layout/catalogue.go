@@ -0,0 +1,150 @@
+package layout
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/diogox/bspc-go"
+)
+
+const (
+	// DefaultMasterCount is how many master windows a desktop starts with.
+	DefaultMasterCount = 1
+
+	// DefaultRatio is the share of a desktop's area its master windows start with.
+	DefaultRatio = 0.5
+
+	minRatio = 0.1
+	maxRatio = 0.9
+)
+
+var ErrUnknownLayout = errors.New("unknown layout")
+
+type (
+	// Layout arranges a desktop's windows. It doesn't talk to bspwm itself, it only says what the tree should look like.
+	Layout interface {
+		// Arrange returns the shape the desktop's tree should have, for the given windows, in order.
+		// Its leaves only need their ids, and its splits their type and ratio. The windows must appear in it in the
+		// same order, from the first child to the second, since that's the order they're read back in next time.
+		Arrange(windows []bspc.ID, params Params) bspc.Node
+	}
+
+	// Params are the settings of a desktop's layout, that can be changed while it's arranged.
+	Params struct {
+		// MasterCount is how many windows go in the master area.
+		MasterCount int
+
+		// Ratio is the share of the desktop's area the master area gets.
+		Ratio float64
+	}
+)
+
+// names are the names of the layouts, in the order they're cycled through.
+var names = []string{
+	NameTall,
+	NameWide,
+	NameGrid,
+	NameDwindle,
+	NameEvenVertical,
+	NameEvenHorizontal,
+}
+
+// layouts are the layouts desktops can be arranged with, by name.
+var layouts = map[string]Layout{
+	NameTall:           MasterStack{},
+	NameWide:           MasterStack{IsWide: true},
+	NameGrid:           Grid{},
+	NameDwindle:        Dwindle{},
+	NameEvenVertical:   Even{SplitType: bspc.SplitTypeHorizontal},
+	NameEvenHorizontal: Even{SplitType: bspc.SplitTypeVertical},
+}
+
+// aliases are other names layouts are known by.
+var aliases = map[string]string{
+	NameMasterStack: NameTall,
+}
+
+// Names returns the names of the layouts, in the order they're cycled through.
+func Names() []string {
+	return append([]string(nil), names...)
+}
+
+// Get returns the layout with the given name, or alias, along with its name.
+func Get(name string) (string, Layout, error) {
+	if n, ok := aliases[name]; ok {
+		name = n
+	}
+
+	l, ok := layouts[name]
+	if !ok {
+		return "", nil, fmt.Errorf("%w: %q", ErrUnknownLayout, name)
+	}
+
+	return name, l, nil
+}
+
+// Next returns the name of the layout after the named one, wrapping around. It returns the first one if there's no
+// layout with the given name.
+func Next(name string) string {
+	for i, n := range names {
+		if n == name {
+			return names[(i+1)%len(names)]
+		}
+	}
+
+	return names[0]
+}
+
+// DefaultParams returns the params a desktop starts with.
+func DefaultParams() Params {
+	return Params{
+		MasterCount: DefaultMasterCount,
+		Ratio:       DefaultRatio,
+	}
+}
+
+func leaf(id bspc.ID) bspc.Node {
+	return bspc.Node{
+		ID: id,
+		Client: &bspc.NodeClient{
+			State: bspc.StateTypeTiled,
+		},
+	}
+}
+
+func split(splitType bspc.SplitType, ratio float64, first bspc.Node, second bspc.Node) bspc.Node {
+	return bspc.Node{
+		SplitType:   splitType,
+		SplitRatio:  ratio,
+		FirstChild:  &first,
+		SecondChild: &second,
+	}
+}
+
+// evenly splits the nodes' area between them, one after the other.
+func evenly(splitType bspc.SplitType, nodes []bspc.Node) bspc.Node {
+	if len(nodes) == 1 {
+		return nodes[0]
+	}
+
+	return split(splitType, 1/float64(len(nodes)), nodes[0], evenly(splitType, nodes[1:]))
+}
+
+func leaves(windows []bspc.ID) []bspc.Node {
+	nodes := make([]bspc.Node, 0, len(windows))
+	for _, id := range windows {
+		nodes = append(nodes, leaf(id))
+	}
+
+	return nodes
+}
+
+// column stacks the windows on top of each other, each with the same height.
+func column(windows []bspc.ID) bspc.Node {
+	return evenly(bspc.SplitTypeHorizontal, leaves(windows))
+}
+
+// row puts the windows side by side, each with the same width.
+func row(windows []bspc.ID) bspc.Node {
+	return evenly(bspc.SplitTypeVertical, leaves(windows))
+}
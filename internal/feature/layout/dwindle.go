@@ -0,0 +1,30 @@
+package layout
+
+import "github.com/diogox/bspc-go"
+
+const NameDwindle = "dwindle"
+
+// Dwindle gives each window half the area the previous ones left, splitting it sideways and downwards in turn, so
+// the windows get smaller towards the bottom right corner.
+type Dwindle struct{}
+
+func (Dwindle) Arrange(windows []bspc.ID, _ Params) bspc.Node {
+	if len(windows) == 0 {
+		return bspc.Node{}
+	}
+
+	return dwindle(windows, bspc.SplitTypeVertical)
+}
+
+func dwindle(windows []bspc.ID, splitType bspc.SplitType) bspc.Node {
+	if len(windows) == 1 {
+		return leaf(windows[0])
+	}
+
+	next := bspc.SplitTypeHorizontal
+	if splitType == bspc.SplitTypeHorizontal {
+		next = bspc.SplitTypeVertical
+	}
+
+	return split(splitType, 0.5, leaf(windows[0]), dwindle(windows[1:], next))
+}
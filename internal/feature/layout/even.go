@@ -0,0 +1,23 @@
+package layout
+
+import "github.com/diogox/bspc-go"
+
+const (
+	NameEvenVertical   = "even-vertical"
+	NameEvenHorizontal = "even-horizontal"
+)
+
+// Even gives every window the same share of the desktop, splitting it one way only.
+type Even struct {
+	// SplitType is bspc.SplitTypeHorizontal to stack the windows from top to bottom (even-vertical),
+	// and bspc.SplitTypeVertical to put them side by side (even-horizontal).
+	SplitType bspc.SplitType
+}
+
+func (e Even) Arrange(windows []bspc.ID, _ Params) bspc.Node {
+	if len(windows) == 0 {
+		return bspc.Node{}
+	}
+
+	return evenly(e.SplitType, leaves(windows))
+}
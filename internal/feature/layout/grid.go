@@ -0,0 +1,38 @@
+package layout
+
+import (
+	"math"
+
+	"github.com/diogox/bspc-go"
+)
+
+const NameGrid = "grid"
+
+// Grid puts the windows in as many columns as there are windows in each, or as close to it as it gets.
+// The columns on the right get the windows left over.
+type Grid struct{}
+
+func (Grid) Arrange(windows []bspc.ID, _ Params) bspc.Node {
+	if len(windows) == 0 {
+		return bspc.Node{}
+	}
+
+	var (
+		columnCount = int(math.Ceil(math.Sqrt(float64(len(windows)))))
+		perColumn   = len(windows) / columnCount
+		leftOver    = len(windows) % columnCount
+		columns     = make([]bspc.Node, 0, columnCount)
+	)
+
+	for i := 0; i < columnCount; i++ {
+		size := perColumn
+		if i >= columnCount-leftOver {
+			size++
+		}
+
+		columns = append(columns, column(windows[:size]))
+		windows = windows[size:]
+	}
+
+	return evenly(bspc.SplitTypeVertical, columns)
+}
@@ -0,0 +1,373 @@
+//go:generate mockgen -package layout -destination ./layout_mock.go -self_package github.com/diogox/bspm/internal/feature/layout github.com/diogox/bspm/internal/feature/layout Feature
+
+package layout
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/diogox/bspc-go"
+
+	"github.com/diogox/bspm/internal/bspwm"
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	bspwmtree "github.com/diogox/bspm/internal/bspwm/tree"
+	"github.com/diogox/bspm/internal/log"
+	"github.com/diogox/bspm/internal/subscription"
+)
+
+var ErrNoLayout = errors.New("desktop has no layout")
+
+type (
+	// Feature arranges the windows of the focused desktop.
+	Feature interface {
+		// Set arranges the desktop with the named layout, and keeps it arranged as windows come and go.
+		Set(name string) error
+		// Next arranges the desktop with the layout after its current one, or the first layout if it has none.
+		Next() error
+		// Unset leaves the desktop's windows where they are, and stops arranging them.
+		Unset() error
+		// Promote makes the focused window the first master, or swaps it with the next window if it already is.
+		Promote() error
+		ChangeMasterCount(delta int) error
+		ChangeRatio(delta float64) error
+	}
+
+	arrangement struct {
+		name   string
+		layout Layout
+		params Params
+	}
+
+	feature struct {
+		logger        *log.Logger
+		service       bspwm.Service
+		tree          bspwmtree.Tree
+		subscriptions subscription.Manager
+
+		mutex        sync.Mutex
+		arrangements map[bspc.ID]*arrangement
+	}
+)
+
+// Start keeps the desktops that have a layout arranged, until the returned function is called.
+// The windows bspm moves around itself are ignored, so it doesn't arrange a desktop again as it's being arranged.
+func Start(
+	logger *log.Logger,
+	service bspwm.Service,
+	tree bspwmtree.Tree,
+	echoes *bspwmevent.Echoes,
+	subscriptions subscription.Manager,
+) (Feature, func()) {
+	f := &feature{
+		logger:        logger,
+		service:       service,
+		tree:          tree,
+		subscriptions: subscriptions,
+		arrangements:  make(map[bspc.ID]*arrangement),
+	}
+
+	handles := []bspwmevent.Handle{
+		bspwmevent.On(service.Events(), func(payload bspc.EventNodeAdd) error {
+			if err := f.handleChange(payload.DesktopID, payload.NodeID); err != nil {
+				return fmt.Errorf("failed to arrange desktop after node was added: %w", err)
+			}
+
+			return nil
+		}),
+		bspwmevent.On(service.Events(), func(payload bspc.EventNodeRemove) error {
+			if err := f.handleChange(payload.DesktopID); err != nil {
+				return fmt.Errorf("failed to arrange desktop after node was removed: %w", err)
+			}
+
+			return nil
+		}),
+		bspwmevent.On(service.Events(), func(payload bspc.EventNodeTransfer) error {
+			if payload.SourceDesktopID == payload.DestinationDesktopID {
+				if err := f.handleChange(payload.DestinationDesktopID); err != nil {
+					return fmt.Errorf("failed to arrange desktop after node was moved: %w", err)
+				}
+
+				return nil
+			}
+
+			if err := f.handleChange(payload.SourceDesktopID); err != nil {
+				return fmt.Errorf("failed to arrange desktop after node was moved out of it: %w", err)
+			}
+
+			if err := f.handleChange(payload.DestinationDesktopID, payload.SourceNodeID); err != nil {
+				return fmt.Errorf("failed to arrange desktop after node was moved into it: %w", err)
+			}
+
+			return nil
+		}, bspwmevent.IgnoreEchoes(echoes)),
+		bspwmevent.On(service.Events(), func(payload bspc.EventDesktopRemove) error {
+			f.mutex.Lock()
+			defer f.mutex.Unlock()
+
+			if _, ok := f.arrangements[payload.DesktopID]; ok {
+				delete(f.arrangements, payload.DesktopID)
+				f.publish(payload.DesktopID, nil)
+			}
+
+			return nil
+		}),
+	}
+
+	cancelFunc := func() {
+		for _, h := range handles {
+			service.Events().Off(h)
+		}
+	}
+
+	return f, cancelFunc
+}
+
+func (f *feature) Set(name string) error {
+	if _, _, err := Get(name); err != nil {
+		return err
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	desktop, err := f.focusedDesktop()
+	if err != nil {
+		return err
+	}
+
+	return f.set(desktop, name)
+}
+
+func (f *feature) Next() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	desktop, err := f.focusedDesktop()
+	if err != nil {
+		return err
+	}
+
+	var current string
+	if a, ok := f.arrangements[desktop.ID]; ok {
+		current = a.name
+	}
+
+	return f.set(desktop, Next(current))
+}
+
+// set arranges the desktop with the named layout. It must be called with the mutex held.
+func (f *feature) set(desktop bspc.Desktop, name string) error {
+	name, l, err := Get(name)
+	if err != nil {
+		return err
+	}
+
+	a, ok := f.arrangements[desktop.ID]
+	if !ok {
+		a = &arrangement{params: DefaultParams()}
+		f.arrangements[desktop.ID] = a
+	}
+
+	a.name = name
+	a.layout = l
+	f.publish(desktop.ID, a)
+
+	return f.arrange(desktop, a, Windows(desktop.Root))
+}
+
+func (f *feature) Unset() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	desktop, err := f.focusedDesktop()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := f.arrangements[desktop.ID]; ok {
+		delete(f.arrangements, desktop.ID)
+		f.publish(desktop.ID, nil)
+	}
+
+	return nil
+}
+
+func (f *feature) Promote() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	desktop, a, err := f.focusedArrangement()
+	if err != nil {
+		return err
+	}
+
+	windows := Windows(desktop.Root)
+
+	i := indexOf(windows, desktop.FocusedNodeID)
+	switch {
+	case i < 0:
+		// It's not tiled, so it has no place in the layout.
+		return nil
+	case i == 0:
+		if len(windows) < 2 {
+			return nil
+		}
+
+		windows[0], windows[1] = windows[1], windows[0]
+	default:
+		copy(windows[1:i+1], windows[:i])
+		windows[0] = desktop.FocusedNodeID
+	}
+
+	return f.arrange(desktop, a, windows)
+}
+
+func (f *feature) ChangeMasterCount(delta int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	desktop, a, err := f.focusedArrangement()
+	if err != nil {
+		return err
+	}
+
+	windows := Windows(desktop.Root)
+
+	// Kept within the windows there are, so it never takes more than one change to make a difference.
+	masterCount := a.params.MasterCount + delta
+	if masterCount > len(windows) {
+		masterCount = len(windows)
+	}
+
+	if masterCount < 1 {
+		masterCount = 1
+	}
+
+	a.params.MasterCount = masterCount
+	f.publish(desktop.ID, a)
+
+	return f.arrange(desktop, a, windows)
+}
+
+func (f *feature) ChangeRatio(delta float64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	desktop, a, err := f.focusedArrangement()
+	if err != nil {
+		return err
+	}
+
+	ratio := a.params.Ratio + delta
+	if ratio > maxRatio {
+		ratio = maxRatio
+	}
+
+	if ratio < minRatio {
+		ratio = minRatio
+	}
+
+	a.params.Ratio = ratio
+	f.publish(desktop.ID, a)
+
+	return f.arrange(desktop, a, Windows(desktop.Root))
+}
+
+// handleChange arranges the desktop again, if it has a layout, with the added windows as the newest ones.
+// The tree handles the same events first, so it already has the change.
+func (f *feature) handleChange(desktopID bspc.ID, added ...bspc.ID) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	a, ok := f.arrangements[desktopID]
+	if !ok {
+		return nil
+	}
+
+	desktop, err := f.tree.Desktop(desktopID)
+	if err != nil {
+		return fmt.Errorf("failed to get desktop: %w", err)
+	}
+
+	return f.arrange(desktop, a, Windows(desktop.Root, added...))
+}
+
+func (f *feature) focusedDesktop() (bspc.Desktop, error) {
+	desktop, err := f.tree.FocusedDesktop()
+	if err != nil {
+		return bspc.Desktop{}, fmt.Errorf("failed to get focused desktop: %w", err)
+	}
+
+	return desktop, nil
+}
+
+func (f *feature) focusedArrangement() (bspc.Desktop, *arrangement, error) {
+	desktop, err := f.focusedDesktop()
+	if err != nil {
+		return bspc.Desktop{}, nil, err
+	}
+
+	a, ok := f.arrangements[desktop.ID]
+	if !ok {
+		return bspc.Desktop{}, nil, ErrNoLayout
+	}
+
+	return desktop, a, nil
+}
+
+// arrange lays out the windows, in order, on the desktop. It must be called with the mutex held.
+func (f *feature) arrange(desktop bspc.Desktop, a *arrangement, windows []bspc.ID) error {
+	if len(windows) == 0 {
+		return nil
+	}
+
+	target := a.layout.Arrange(windows, a.params)
+
+	for _, step := range Plan(desktop.Root, target) {
+		if err := f.apply(step); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// publish publishes the desktop's layout, or its lack of one if the arrangement is nil.
+func (f *feature) publish(desktopID bspc.ID, a *arrangement) {
+	st := State{
+		DesktopID: desktopID,
+	}
+
+	if a != nil {
+		st.Name = a.name
+		st.Params = a.params
+	}
+
+	Topic(desktopID).PublishRetained(f.subscriptions, st)
+}
+
+func (f *feature) apply(step Step) error {
+	switch step.Type {
+	case StepInsert:
+		if err := f.service.Nodes().Presel(step.TargetID, step.Direction, step.Ratio); err != nil {
+			return err
+		}
+
+		return f.service.Nodes().MoveToNode(step.NodeID, step.TargetID)
+	case StepRatio:
+		return f.service.Nodes().SetRatio(step.NodeID, step.Ratio)
+	default:
+		return fmt.Errorf("unknown step type: %d", step.Type)
+	}
+}
+
+func indexOf(ids []bspc.ID, id bspc.ID) int {
+	for i, v := range ids {
+		if v == id {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,841 @@
+package layout_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/diogox/bspc-go"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/diogox/bspm/internal/bspwm"
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	bspwmnode "github.com/diogox/bspm/internal/bspwm/node"
+	bspwmtree "github.com/diogox/bspm/internal/bspwm/tree"
+	"github.com/diogox/bspm/internal/feature/layout"
+	"github.com/diogox/bspm/internal/log"
+	"github.com/diogox/bspm/internal/subscription"
+)
+
+const desktopID = bspc.ID(100)
+
+func TestLayout(t *testing.T) {
+	// Two windows, side by side, as arranged by master-stack.
+	arranged := &bspc.Node{
+		ID:          10,
+		SplitType:   bspc.SplitTypeVertical,
+		SplitRatio:  0.5,
+		FirstChild:  &bspc.Node{ID: 1, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+		SecondChild: &bspc.Node{ID: 2, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+	}
+
+	t.Run("should arrange the desktop when its layout is set", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		capture := func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+			callbacks[eventType] = cb
+			return bspwmevent.Handle{}
+		}
+
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := layout.Start(logger, mockService, mockTree, bspwmevent.NewEchoes(), subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1, Root: bspc.Node{
+				ID:          10,
+				SplitType:   bspc.SplitTypeHorizontal,
+				SplitRatio:  0.5,
+				FirstChild:  &bspc.Node{ID: 1, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+				SecondChild: &bspc.Node{ID: 2, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+			}}, nil)
+		gomock.InOrder(
+			mockNodes.EXPECT().
+				Presel(bspc.ID(1), bspc.DirectionTypeRight, 0.5).
+				Return(nil),
+			mockNodes.EXPECT().
+				MoveToNode(bspc.ID(2), bspc.ID(1)).
+				Return(nil),
+		)
+
+		require.NoError(t, feature.Set(layout.NameMasterStack))
+
+		assert.Equal(t, layout.State{
+			DesktopID: desktopID,
+			Name:      layout.NameTall,
+			Params:    layout.DefaultParams(),
+		}, <-layout.Topic(desktopID).Subscribe(ctx, subscriptions))
+	})
+	t.Run("should cycle through the layouts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		capture := func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+			callbacks[eventType] = cb
+			return bspwmevent.Handle{}
+		}
+
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := layout.Start(logger, mockService, mockTree, bspwmevent.NewEchoes(), subscriptions)
+
+		// Every layout arranges a single window the same way, so there's nothing to move.
+		for _, name := range append(layout.Names(), layout.Names()[0]) {
+			mockTree.EXPECT().
+				FocusedDesktop().
+				Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1, Root: bspc.Node{ID: 1, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}}}, nil)
+			require.NoError(t, feature.Next())
+
+			assert.Equal(t, name, (<-layout.Topic(desktopID).Subscribe(ctx, subscriptions)).Name)
+		}
+	})
+	t.Run("should fail to set an unknown layout", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		capture := func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+			callbacks[eventType] = cb
+			return bspwmevent.Handle{}
+		}
+
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := layout.Start(logger, mockService, mockTree, bspwmevent.NewEchoes(), subscriptions)
+
+		err = feature.Set("unknown")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, layout.ErrUnknownLayout))
+	})
+	t.Run("should add windows to the end of the stack", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		capture := func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+			callbacks[eventType] = cb
+			return bspwmevent.Handle{}
+		}
+
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := layout.Start(logger, mockService, mockTree, bspwmevent.NewEchoes(), subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1, Root: *arranged}, nil)
+		require.NoError(t, feature.Set(layout.NameMasterStack))
+
+		// bspwm put the new window next to the focused one.
+		mockTree.EXPECT().
+			Desktop(desktopID).
+			Return(bspc.Desktop{ID: desktopID, Root: bspc.Node{
+				ID:         10,
+				SplitType:  bspc.SplitTypeVertical,
+				SplitRatio: 0.5,
+				FirstChild: &bspc.Node{
+					ID:          11,
+					SplitType:   bspc.SplitTypeHorizontal,
+					SplitRatio:  0.5,
+					FirstChild:  &bspc.Node{ID: 1, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+					SecondChild: &bspc.Node{ID: 3, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+				},
+				SecondChild: &bspc.Node{ID: 2, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+			}}, nil)
+
+		gomock.InOrder(
+			mockNodes.EXPECT().
+				Presel(bspc.ID(1), bspc.DirectionTypeRight, 0.5).
+				Return(nil),
+			mockNodes.EXPECT().
+				MoveToNode(bspc.ID(2), bspc.ID(1)).
+				Return(nil),
+			mockNodes.EXPECT().
+				Presel(bspc.ID(2), bspc.DirectionTypeDown, 0.5).
+				Return(nil),
+			mockNodes.EXPECT().
+				MoveToNode(bspc.ID(3), bspc.ID(2)).
+				Return(nil),
+		)
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeAdd](bspc.EventNodeAdd{DesktopID: desktopID, NodeID: 3}))
+	})
+	t.Run("should leave desktops without a layout alone", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		capture := func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+			callbacks[eventType] = cb
+			return bspwmevent.Handle{}
+		}
+
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		layout.Start(logger, mockService, mockTree, bspwmevent.NewEchoes(), subscriptions)
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeAdd](bspc.EventNodeAdd{DesktopID: desktopID, NodeID: 3}))
+		require.NoError(t, callbacks[bspc.EventTypeNodeRemove](bspc.EventNodeRemove{DesktopID: desktopID, NodeID: 3}))
+	})
+	t.Run("should stop arranging the desktop once it's removed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		capture := func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+			callbacks[eventType] = cb
+			return bspwmevent.Handle{}
+		}
+
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := layout.Start(logger, mockService, mockTree, bspwmevent.NewEchoes(), subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1, Root: *arranged}, nil)
+		require.NoError(t, feature.Set(layout.NameMasterStack))
+
+		require.NoError(t, callbacks[bspc.EventTypeDesktopRemove](bspc.EventDesktopRemove{DesktopID: desktopID}))
+		require.NoError(t, callbacks[bspc.EventTypeNodeAdd](bspc.EventNodeAdd{DesktopID: desktopID, NodeID: 3}))
+	})
+	t.Run("should arrange both desktops a window moves between", func(t *testing.T) {
+		const otherDesktopID = bspc.ID(200)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		capture := func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+			callbacks[eventType] = cb
+			return bspwmevent.Handle{}
+		}
+
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := layout.Start(logger, mockService, mockTree, bspwmevent.NewEchoes(), subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1, Root: *arranged}, nil)
+		require.NoError(t, feature.Set(layout.NameMasterStack))
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: otherDesktopID, Root: bspc.Node{ID: 3, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}}}, nil)
+		require.NoError(t, feature.Set(layout.NameMasterStack))
+
+		mockTree.EXPECT().
+			Desktop(desktopID).
+			Return(bspc.Desktop{ID: desktopID, Root: bspc.Node{ID: 1, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}}}, nil)
+		mockTree.EXPECT().
+			Desktop(otherDesktopID).
+			Return(bspc.Desktop{ID: otherDesktopID, Root: bspc.Node{
+				ID:          20,
+				SplitType:   bspc.SplitTypeHorizontal,
+				SplitRatio:  0.5,
+				FirstChild:  &bspc.Node{ID: 2, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+				SecondChild: &bspc.Node{ID: 3, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+			}}, nil)
+
+		gomock.InOrder(
+			mockNodes.EXPECT().
+				Presel(bspc.ID(3), bspc.DirectionTypeRight, 0.5).
+				Return(nil),
+			mockNodes.EXPECT().
+				MoveToNode(bspc.ID(2), bspc.ID(3)).
+				Return(nil),
+		)
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeTransfer](bspc.EventNodeTransfer{
+			SourceDesktopID:      desktopID,
+			SourceNodeID:         2,
+			DestinationDesktopID: otherDesktopID,
+		}))
+	})
+	t.Run("should promote the focused window to master", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		capture := func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+			callbacks[eventType] = cb
+			return bspwmevent.Handle{}
+		}
+
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := layout.Start(logger, mockService, mockTree, bspwmevent.NewEchoes(), subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1, Root: *arranged}, nil)
+		require.NoError(t, feature.Set(layout.NameMasterStack))
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 3, Root: bspc.Node{
+				ID:         10,
+				SplitType:  bspc.SplitTypeVertical,
+				SplitRatio: 0.5,
+				FirstChild: &bspc.Node{ID: 1, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+				SecondChild: &bspc.Node{
+					ID:          11,
+					SplitType:   bspc.SplitTypeHorizontal,
+					SplitRatio:  0.5,
+					FirstChild:  &bspc.Node{ID: 2, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+					SecondChild: &bspc.Node{ID: 3, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+				},
+			}}, nil)
+
+		gomock.InOrder(
+			mockNodes.EXPECT().
+				Presel(bspc.ID(3), bspc.DirectionTypeRight, 0.5).
+				Return(nil),
+			mockNodes.EXPECT().
+				MoveToNode(bspc.ID(1), bspc.ID(3)).
+				Return(nil),
+			mockNodes.EXPECT().
+				Presel(bspc.ID(1), bspc.DirectionTypeDown, 0.5).
+				Return(nil),
+			mockNodes.EXPECT().
+				MoveToNode(bspc.ID(2), bspc.ID(1)).
+				Return(nil),
+		)
+
+		require.NoError(t, feature.Promote())
+	})
+	t.Run("should swap the master with the next window when promoting it", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		capture := func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+			callbacks[eventType] = cb
+			return bspwmevent.Handle{}
+		}
+
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := layout.Start(logger, mockService, mockTree, bspwmevent.NewEchoes(), subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1, Root: *arranged}, nil)
+		require.NoError(t, feature.Set(layout.NameMasterStack))
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1, Root: *arranged}, nil)
+		gomock.InOrder(
+			mockNodes.EXPECT().
+				Presel(bspc.ID(2), bspc.DirectionTypeRight, 0.5).
+				Return(nil),
+			mockNodes.EXPECT().
+				MoveToNode(bspc.ID(1), bspc.ID(2)).
+				Return(nil),
+		)
+
+		require.NoError(t, feature.Promote())
+	})
+	t.Run("should change the ratio", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		capture := func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+			callbacks[eventType] = cb
+			return bspwmevent.Handle{}
+		}
+
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := layout.Start(logger, mockService, mockTree, bspwmevent.NewEchoes(), subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1, Root: *arranged}, nil)
+		require.NoError(t, feature.Set(layout.NameMasterStack))
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1, Root: *arranged}, nil)
+		mockNodes.EXPECT().
+			SetRatio(bspc.ID(10), 0.6).
+			Return(nil)
+
+		require.NoError(t, feature.ChangeRatio(0.1))
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1, Root: *arranged}, nil)
+		mockNodes.EXPECT().
+			SetRatio(bspc.ID(10), 0.9).
+			Return(nil)
+
+		require.NoError(t, feature.ChangeRatio(0.5))
+
+		assert.Equal(t, layout.Params{MasterCount: 1, Ratio: 0.9}, (<-layout.Topic(desktopID).Subscribe(ctx, subscriptions)).Params)
+	})
+	t.Run("should change the master count", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		capture := func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+			callbacks[eventType] = cb
+			return bspwmevent.Handle{}
+		}
+
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := layout.Start(logger, mockService, mockTree, bspwmevent.NewEchoes(), subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1, Root: *arranged}, nil)
+		require.NoError(t, feature.Set(layout.NameMasterStack))
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1, Root: *arranged}, nil)
+		gomock.InOrder(
+			mockNodes.EXPECT().
+				Presel(bspc.ID(1), bspc.DirectionTypeDown, 0.5).
+				Return(nil),
+			mockNodes.EXPECT().
+				MoveToNode(bspc.ID(2), bspc.ID(1)).
+				Return(nil),
+		)
+
+		require.NoError(t, feature.ChangeMasterCount(5))
+
+		// There are only two windows, so a single step back is enough.
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1, Root: bspc.Node{
+				ID:          11,
+				SplitType:   bspc.SplitTypeHorizontal,
+				SplitRatio:  0.5,
+				FirstChild:  &bspc.Node{ID: 1, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+				SecondChild: &bspc.Node{ID: 2, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+			}}, nil)
+		gomock.InOrder(
+			mockNodes.EXPECT().
+				Presel(bspc.ID(1), bspc.DirectionTypeRight, 0.5).
+				Return(nil),
+			mockNodes.EXPECT().
+				MoveToNode(bspc.ID(2), bspc.ID(1)).
+				Return(nil),
+		)
+
+		require.NoError(t, feature.ChangeMasterCount(-1))
+	})
+	t.Run("should fail to change a desktop without a layout", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		capture := func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+			callbacks[eventType] = cb
+			return bspwmevent.Handle{}
+		}
+
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := layout.Start(logger, mockService, mockTree, bspwmevent.NewEchoes(), subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1, Root: *arranged}, nil)
+
+		err = feature.ChangeRatio(0.1)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, layout.ErrNoLayout))
+	})
+	t.Run("should stop arranging the desktop once its layout is unset", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		capture := func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+			callbacks[eventType] = cb
+			return bspwmevent.Handle{}
+		}
+
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := layout.Start(logger, mockService, mockTree, bspwmevent.NewEchoes(), subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1, Root: *arranged}, nil)
+		require.NoError(t, feature.Set(layout.NameMasterStack))
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1, Root: *arranged}, nil)
+		require.NoError(t, feature.Unset())
+
+		assert.Equal(t, layout.State{DesktopID: desktopID}, <-layout.Topic(desktopID).Subscribe(ctx, subscriptions))
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeAdd](bspc.EventNodeAdd{DesktopID: desktopID, NodeID: 3}))
+	})
+}
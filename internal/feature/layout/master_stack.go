@@ -0,0 +1,50 @@
+package layout
+
+import "github.com/diogox/bspc-go"
+
+const (
+	NameTall = "tall"
+	NameWide = "wide"
+
+	// NameMasterStack is what tall was first called.
+	NameMasterStack = "master-stack"
+)
+
+// MasterStack puts the first windows (the masters) in a column on the left, and stacks the rest in another on the
+// right. If there are no more windows than masters, they take up the whole desktop.
+type MasterStack struct {
+	// IsWide puts the masters in a row at the top instead, and the rest in another below them.
+	IsWide bool
+}
+
+func (ms MasterStack) Arrange(windows []bspc.ID, params Params) bspc.Node {
+	if len(windows) == 0 {
+		return bspc.Node{}
+	}
+
+	masterCount := params.MasterCount
+	if masterCount < 1 {
+		masterCount = 1
+	}
+
+	var (
+		line      = column
+		splitType = bspc.SplitTypeVertical
+	)
+
+	if ms.IsWide {
+		line = row
+		splitType = bspc.SplitTypeHorizontal
+	}
+
+	if masterCount >= len(windows) {
+		return line(windows)
+	}
+
+	return split(
+		splitType,
+		params.Ratio,
+		line(windows[:masterCount]),
+		line(windows[masterCount:]),
+	)
+}
@@ -0,0 +1,210 @@
+package layout
+
+import (
+	"math"
+
+	"github.com/diogox/bspc-go"
+)
+
+// ratioTolerance is how far a split's ratio can be from the layout's before it's set again.
+// bspwm stores ratios as doubles, so they rarely come back exactly as they were set.
+const ratioTolerance = 0.001
+
+type (
+	StepType int
+
+	// Step is a change to a desktop's tree, as part of arranging it.
+	Step struct {
+		Type StepType
+
+		// NodeID is the node being inserted (StepInsert), or the split whose ratio is set (StepRatio).
+		NodeID bspc.ID
+
+		// TargetID is the window the node is inserted next to (StepInsert).
+		TargetID bspc.ID
+
+		// Direction is the side of the target the node is inserted on (StepInsert).
+		Direction bspc.DirectionType
+
+		// Ratio is the share of the area the split's first child gets.
+		Ratio float64
+	}
+)
+
+const (
+	// StepInsert moves the node next to the target, splitting the target's area between them.
+	StepInsert StepType = iota
+
+	// StepRatio sets the ratio of an existing split.
+	StepRatio
+)
+
+// Plan returns the steps that turn the current tree into the target one, ignoring the windows that don't take up any
+// space in it (e.g. floating or hidden ones). If they already have the same shape, only the ratios are set.
+// Otherwise, the target is rebuilt around its first window, inserting every other window in turn.
+func Plan(current bspc.Node, target bspc.Node) []Step {
+	if !isShape(target) {
+		return nil
+	}
+
+	if tiled, ok := Prune(current); ok {
+		if steps, ok := adjust(tiled, target); ok {
+			return steps
+		}
+	}
+
+	return build(target)
+}
+
+// Windows returns the windows that take up space in the tree, in order. The added windows go last, wherever they were
+// inserted, so layouts treat them as the newest ones.
+func Windows(root bspc.Node, added ...bspc.ID) []bspc.ID {
+	tiled, ok := Prune(root)
+	if !ok {
+		return nil
+	}
+
+	isAdded := make(map[bspc.ID]bool, len(added))
+	for _, id := range added {
+		isAdded[id] = true
+	}
+
+	var (
+		windows []bspc.ID
+		last    []bspc.ID
+	)
+
+	for _, n := range tiled.LeafNodes() {
+		if isAdded[n.ID] {
+			last = append(last, n.ID)
+			continue
+		}
+
+		windows = append(windows, n.ID)
+	}
+
+	return append(windows, last...)
+}
+
+// Prune returns the tree as it's tiled, without the windows that don't take up any space in it. Splits left with a
+// single child are replaced by it, like bspwm does when tiling them. It returns false if there's nothing left.
+func Prune(n bspc.Node) (bspc.Node, bool) {
+	if n.FirstChild == nil && n.SecondChild == nil {
+		return n, n.Client != nil && !n.Hidden && isTiled(n.Client.State)
+	}
+
+	var (
+		first, hasFirst   = pruneChild(n.FirstChild)
+		second, hasSecond = pruneChild(n.SecondChild)
+	)
+
+	switch {
+	case hasFirst && hasSecond:
+		n.FirstChild = &first
+		n.SecondChild = &second
+
+		return n, true
+	case hasFirst:
+		return first, true
+	case hasSecond:
+		return second, true
+	default:
+		return bspc.Node{}, false
+	}
+}
+
+func pruneChild(n *bspc.Node) (bspc.Node, bool) {
+	if n == nil {
+		return bspc.Node{}, false
+	}
+
+	return Prune(*n)
+}
+
+// adjust returns the steps that set the current tree's ratios to the target's, if they have the same shape.
+func adjust(current bspc.Node, target bspc.Node) ([]Step, bool) {
+	if isLeaf(target) {
+		return nil, isLeaf(current) && current.ID == target.ID
+	}
+
+	if isLeaf(current) || current.SplitType != target.SplitType {
+		return nil, false
+	}
+
+	first, ok := adjust(*current.FirstChild, *target.FirstChild)
+	if !ok {
+		return nil, false
+	}
+
+	second, ok := adjust(*current.SecondChild, *target.SecondChild)
+	if !ok {
+		return nil, false
+	}
+
+	var steps []Step
+	if math.Abs(current.SplitRatio-target.SplitRatio) > ratioTolerance {
+		steps = append(steps, Step{
+			Type:   StepRatio,
+			NodeID: current.ID,
+			Ratio:  target.SplitRatio,
+		})
+	}
+
+	return append(append(steps, first...), second...), true
+}
+
+// build returns the steps that arrange the target's windows into its shape, around the first one. Each split is made
+// by inserting the first window of its second child next to the first window of its first child. Every other window
+// ends up in the split they're inserted into, so the target is all that's left of the tree once they've all moved.
+func build(target bspc.Node) []Step {
+	if isLeaf(target) {
+		return nil
+	}
+
+	steps := []Step{
+		{
+			Type:      StepInsert,
+			NodeID:    firstWindow(*target.SecondChild),
+			TargetID:  firstWindow(*target.FirstChild),
+			Direction: directionOf(target.SplitType),
+			Ratio:     target.SplitRatio,
+		},
+	}
+
+	steps = append(steps, build(*target.FirstChild)...)
+	return append(steps, build(*target.SecondChild)...)
+}
+
+// isShape returns true if the node is a complete layout: a window, or a split of two of them.
+func isShape(n bspc.Node) bool {
+	if isLeaf(n) {
+		return n.ID != bspc.NilID
+	}
+
+	return n.FirstChild != nil && n.SecondChild != nil && isShape(*n.FirstChild) && isShape(*n.SecondChild)
+}
+
+func isLeaf(n bspc.Node) bool {
+	return n.FirstChild == nil && n.SecondChild == nil
+}
+
+func firstWindow(n bspc.Node) bspc.ID {
+	for !isLeaf(n) {
+		n = *n.FirstChild
+	}
+
+	return n.ID
+}
+
+// directionOf returns the side new windows go to in a split, so the existing one stays as its first child.
+func directionOf(splitType bspc.SplitType) bspc.DirectionType {
+	if splitType == bspc.SplitTypeVertical {
+		return bspc.DirectionTypeRight
+	}
+
+	return bspc.DirectionTypeDown
+}
+
+func isTiled(state bspc.StateType) bool {
+	return state == bspc.StateTypeTiled || state == bspc.StateTypePseudoTiled
+}
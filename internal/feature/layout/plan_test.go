@@ -0,0 +1,426 @@
+package layout_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/diogox/bspc-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/diogox/bspm/internal/feature/layout"
+)
+
+// describe writes the tree down compactly, e.g. "V0.50(1,H0.50(2,3))", so trees are easy to compare.
+func describe(n bspc.Node) string {
+	if n.FirstChild == nil && n.SecondChild == nil {
+		return fmt.Sprintf("%d", n.ID)
+	}
+
+	splitType := "H"
+	if n.SplitType == bspc.SplitTypeVertical {
+		splitType = "V"
+	}
+
+	return fmt.Sprintf("%s%.2f(%s,%s)", splitType, n.SplitRatio, describe(*n.FirstChild), describe(*n.SecondChild))
+}
+
+// simulator applies steps to a tree, the way bspwm applies the commands they're made of.
+type simulator struct {
+	root   *bspc.Node
+	nextID bspc.ID
+}
+
+func newSimulator(root bspc.Node) *simulator {
+	return &simulator{
+		root:   &root,
+		nextID: 1000,
+	}
+}
+
+func (s *simulator) apply(t *testing.T, steps []layout.Step) {
+	for _, step := range steps {
+		switch step.Type {
+		case layout.StepInsert:
+			s.insert(t, step)
+		case layout.StepRatio:
+			n := find(s.root, step.NodeID)
+			require.NotNil(t, n, "no split %d to set the ratio of", step.NodeID)
+			require.NotNil(t, n.FirstChild, "node %d isn't a split", step.NodeID)
+
+			n.SplitRatio = step.Ratio
+		default:
+			t.Fatalf("unknown step type: %d", step.Type)
+		}
+	}
+}
+
+func (s *simulator) insert(t *testing.T, step layout.Step) {
+	require.NotEqual(t, step.NodeID, step.TargetID)
+
+	node := find(s.root, step.NodeID)
+	require.NotNil(t, node, "no node %d to move", step.NodeID)
+
+	moved := *node
+
+	// Its parent is replaced by its sibling.
+	parent := parentOf(s.root, step.NodeID)
+	require.NotNil(t, parent, "node %d has nowhere to be moved from", step.NodeID)
+
+	if parent.FirstChild.ID == step.NodeID {
+		*parent = *parent.SecondChild
+	} else {
+		*parent = *parent.FirstChild
+	}
+
+	target := find(s.root, step.TargetID)
+	require.NotNil(t, target, "no node %d to move to", step.TargetID)
+	require.Nil(t, target.FirstChild, "node %d isn't a window", step.TargetID)
+
+	splitType := bspc.SplitTypeHorizontal
+	if step.Direction == bspc.DirectionTypeRight {
+		splitType = bspc.SplitTypeVertical
+	}
+
+	existing := *target
+
+	s.nextID++
+	*target = bspc.Node{
+		ID:          s.nextID,
+		SplitType:   splitType,
+		SplitRatio:  step.Ratio,
+		FirstChild:  &existing,
+		SecondChild: &moved,
+	}
+}
+
+func find(n *bspc.Node, id bspc.ID) *bspc.Node {
+	if n == nil {
+		return nil
+	}
+
+	if n.ID == id {
+		return n
+	}
+
+	if found := find(n.FirstChild, id); found != nil {
+		return found
+	}
+
+	return find(n.SecondChild, id)
+}
+
+func parentOf(n *bspc.Node, id bspc.ID) *bspc.Node {
+	if n == nil || n.FirstChild == nil {
+		return nil
+	}
+
+	if n.FirstChild.ID == id || n.SecondChild.ID == id {
+		return n
+	}
+
+	if found := parentOf(n.FirstChild, id); found != nil {
+		return found
+	}
+
+	return parentOf(n.SecondChild, id)
+}
+
+func TestPlan(t *testing.T) {
+	target := layout.MasterStack{}.Arrange([]bspc.ID{1, 2, 3, 4}, layout.Params{MasterCount: 1, Ratio: 0.6})
+
+	tt := []struct {
+		name    string
+		current *bspc.Node
+	}{
+		{
+			name: "should rebuild a dwindle",
+			current: &bspc.Node{
+				ID:         10,
+				SplitType:  bspc.SplitTypeVertical,
+				SplitRatio: 0.5,
+				FirstChild: &bspc.Node{ID: 1, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+				SecondChild: &bspc.Node{
+					ID:         11,
+					SplitType:  bspc.SplitTypeHorizontal,
+					SplitRatio: 0.5,
+					FirstChild: &bspc.Node{ID: 2, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+					SecondChild: &bspc.Node{
+						ID:          12,
+						SplitType:   bspc.SplitTypeVertical,
+						SplitRatio:  0.5,
+						FirstChild:  &bspc.Node{ID: 3, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+						SecondChild: &bspc.Node{ID: 4, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+					},
+				},
+			},
+		},
+		{
+			name: "should rebuild windows out of order",
+			current: &bspc.Node{
+				ID:         10,
+				SplitType:  bspc.SplitTypeVertical,
+				SplitRatio: 0.5,
+				FirstChild: &bspc.Node{
+					ID:          11,
+					SplitType:   bspc.SplitTypeHorizontal,
+					SplitRatio:  0.5,
+					FirstChild:  &bspc.Node{ID: 4, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+					SecondChild: &bspc.Node{ID: 2, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+				},
+				SecondChild: &bspc.Node{
+					ID:          12,
+					SplitType:   bspc.SplitTypeHorizontal,
+					SplitRatio:  0.5,
+					FirstChild:  &bspc.Node{ID: 3, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+					SecondChild: &bspc.Node{ID: 1, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+				},
+			},
+		},
+		{
+			name: "should rebuild a tree with the first window deep inside it",
+			current: &bspc.Node{
+				ID:         10,
+				SplitType:  bspc.SplitTypeHorizontal,
+				SplitRatio: 0.5,
+				FirstChild: &bspc.Node{
+					ID:         11,
+					SplitType:  bspc.SplitTypeHorizontal,
+					SplitRatio: 0.5,
+					FirstChild: &bspc.Node{ID: 2, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+					SecondChild: &bspc.Node{
+						ID:          12,
+						SplitType:   bspc.SplitTypeVertical,
+						SplitRatio:  0.5,
+						FirstChild:  &bspc.Node{ID: 3, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+						SecondChild: &bspc.Node{ID: 1, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+					},
+				},
+				SecondChild: &bspc.Node{ID: 4, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+			},
+		},
+		{
+			name: "should rebuild a tree with floating windows in it",
+			current: &bspc.Node{
+				ID:         10,
+				SplitType:  bspc.SplitTypeVertical,
+				SplitRatio: 0.5,
+				FirstChild: &bspc.Node{
+					ID:          11,
+					SplitType:   bspc.SplitTypeHorizontal,
+					SplitRatio:  0.5,
+					FirstChild:  &bspc.Node{ID: 5, Client: &bspc.NodeClient{State: bspc.StateTypeFloating}},
+					SecondChild: &bspc.Node{ID: 1, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+				},
+				SecondChild: &bspc.Node{
+					ID:         12,
+					SplitType:  bspc.SplitTypeHorizontal,
+					SplitRatio: 0.5,
+					FirstChild: &bspc.Node{ID: 2, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+					SecondChild: &bspc.Node{
+						ID:         13,
+						SplitType:  bspc.SplitTypeVertical,
+						SplitRatio: 0.5,
+						FirstChild: &bspc.Node{ID: 4, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+						SecondChild: &bspc.Node{
+							ID:          14,
+							SplitType:   bspc.SplitTypeHorizontal,
+							SplitRatio:  0.5,
+							FirstChild:  &bspc.Node{ID: 3, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+							SecondChild: &bspc.Node{ID: 6, Client: &bspc.NodeClient{State: bspc.StateTypeFloating}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newSimulator(*tc.current)
+			s.apply(t, layout.Plan(*s.root, target))
+
+			arranged, ok := layout.Prune(*s.root)
+			require.True(t, ok)
+
+			assert.Equal(t, describe(target), describe(arranged))
+			assert.Empty(t, layout.Plan(*s.root, target), "should be arranged already")
+		})
+	}
+
+	t.Run("should only set the ratios of a tree with the same shape", func(t *testing.T) {
+		current := &bspc.Node{
+			ID:         10,
+			SplitType:  bspc.SplitTypeVertical,
+			SplitRatio: 0.5,
+			FirstChild: &bspc.Node{ID: 1, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+			SecondChild: &bspc.Node{
+				ID:         11,
+				SplitType:  bspc.SplitTypeHorizontal,
+				SplitRatio: 0.5,
+				FirstChild: &bspc.Node{ID: 2, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+				SecondChild: &bspc.Node{
+					ID:          12,
+					SplitType:   bspc.SplitTypeHorizontal,
+					SplitRatio:  0.5,
+					FirstChild:  &bspc.Node{ID: 3, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+					SecondChild: &bspc.Node{ID: 4, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+				},
+			},
+		}
+
+		steps := layout.Plan(*current, target)
+		assert.Equal(t, []layout.Step{
+			{Type: layout.StepRatio, NodeID: 10, Ratio: 0.6},
+			{Type: layout.StepRatio, NodeID: 11, Ratio: 1.0 / 3},
+		}, steps)
+
+		s := newSimulator(*current)
+		s.apply(t, steps)
+
+		assert.Equal(t, describe(target), describe(*s.root))
+	})
+
+	t.Run("should do nothing without windows", func(t *testing.T) {
+		assert.Empty(t, layout.Plan(bspc.Node{ID: 1, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}}, bspc.Node{}))
+	})
+}
+
+func TestWindows(t *testing.T) {
+	root := &bspc.Node{
+		ID:         10,
+		SplitType:  bspc.SplitTypeVertical,
+		SplitRatio: 0.5,
+		FirstChild: &bspc.Node{
+			ID:          11,
+			SplitType:   bspc.SplitTypeHorizontal,
+			SplitRatio:  0.5,
+			FirstChild:  &bspc.Node{ID: 1, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+			SecondChild: &bspc.Node{ID: 2, Client: &bspc.NodeClient{State: bspc.StateTypeFloating}},
+		},
+		SecondChild: &bspc.Node{
+			ID:         12,
+			SplitType:  bspc.SplitTypeHorizontal,
+			SplitRatio: 0.5,
+			FirstChild: &bspc.Node{ID: 3, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+			SecondChild: &bspc.Node{
+				ID:          13,
+				SplitType:   bspc.SplitTypeVertical,
+				SplitRatio:  0.5,
+				FirstChild:  &bspc.Node{ID: 4, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+				SecondChild: &bspc.Node{ID: 5, Hidden: true, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+			},
+		},
+	}
+
+	t.Run("should return the tiled windows in order", func(t *testing.T) {
+		assert.Equal(t, []bspc.ID{1, 3, 4}, layout.Windows(*root))
+	})
+	t.Run("should put the added windows last", func(t *testing.T) {
+		assert.Equal(t, []bspc.ID{1, 4, 3}, layout.Windows(*root, 3))
+	})
+	t.Run("should return no windows for an empty desktop", func(t *testing.T) {
+		assert.Empty(t, layout.Windows(bspc.Node{}))
+	})
+}
+
+func TestLayouts_Arrange(t *testing.T) {
+	tt := []struct {
+		name     string
+		layout   string
+		windows  []bspc.ID
+		params   layout.Params
+		expected string
+	}{
+		{
+			name:     "should give a single window the whole desktop",
+			layout:   layout.NameTall,
+			windows:  []bspc.ID{1},
+			params:   layout.DefaultParams(),
+			expected: "1",
+		},
+		{
+			name:     "should put the master next to the stack",
+			layout:   layout.NameTall,
+			windows:  []bspc.ID{1, 2, 3, 4},
+			params:   layout.Params{MasterCount: 1, Ratio: 0.6},
+			expected: "V0.60(1,H0.33(2,H0.50(3,4)))",
+		},
+		{
+			name:     "should put several masters in a column",
+			layout:   layout.NameTall,
+			windows:  []bspc.ID{1, 2, 3, 4},
+			params:   layout.Params{MasterCount: 2, Ratio: 0.5},
+			expected: "V0.50(H0.50(1,2),H0.50(3,4))",
+		},
+		{
+			name:     "should stack every window when they're all masters",
+			layout:   layout.NameTall,
+			windows:  []bspc.ID{1, 2, 3},
+			params:   layout.Params{MasterCount: 5, Ratio: 0.5},
+			expected: "H0.33(1,H0.50(2,3))",
+		},
+		{
+			name:     "should put the master above the stack",
+			layout:   layout.NameWide,
+			windows:  []bspc.ID{1, 2, 3},
+			params:   layout.Params{MasterCount: 1, Ratio: 0.6},
+			expected: "H0.60(1,V0.50(2,3))",
+		},
+		{
+			name:     "should put the windows in a square grid",
+			layout:   layout.NameGrid,
+			windows:  []bspc.ID{1, 2, 3, 4},
+			params:   layout.DefaultParams(),
+			expected: "V0.50(H0.50(1,2),H0.50(3,4))",
+		},
+		{
+			name:     "should give the windows left over to the columns on the right",
+			layout:   layout.NameGrid,
+			windows:  []bspc.ID{1, 2, 3, 4, 5},
+			params:   layout.DefaultParams(),
+			expected: "V0.33(1,V0.50(H0.50(2,3),H0.50(4,5)))",
+		},
+		{
+			name:     "should halve the area left for each window",
+			layout:   layout.NameDwindle,
+			windows:  []bspc.ID{1, 2, 3, 4},
+			params:   layout.DefaultParams(),
+			expected: "V0.50(1,H0.50(2,V0.50(3,4)))",
+		},
+		{
+			name:     "should stack the windows evenly",
+			layout:   layout.NameEvenVertical,
+			windows:  []bspc.ID{1, 2, 3},
+			params:   layout.DefaultParams(),
+			expected: "H0.33(1,H0.50(2,3))",
+		},
+		{
+			name:     "should put the windows side by side evenly",
+			layout:   layout.NameEvenHorizontal,
+			windows:  []bspc.ID{1, 2, 3},
+			params:   layout.DefaultParams(),
+			expected: "V0.33(1,V0.50(2,3))",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, l, err := layout.Get(tc.layout)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, describe(l.Arrange(tc.windows, tc.params)))
+		})
+	}
+
+	t.Run("should keep the windows in order in every layout", func(t *testing.T) {
+		windows := []bspc.ID{1, 2, 3, 4, 5, 6, 7}
+
+		for _, name := range layout.Names() {
+			_, l, err := layout.Get(name)
+			require.NoError(t, err)
+
+			assert.Equal(t, windows, layout.Windows(l.Arrange(windows, layout.Params{MasterCount: 2, Ratio: 0.6})), name)
+		}
+	})
+}
@@ -0,0 +1,30 @@
+package layout
+
+import (
+	"fmt"
+
+	"github.com/diogox/bspc-go"
+
+	"github.com/diogox/bspm/internal/subscription"
+)
+
+// ChangedTopic is published, and retained, under each desktop's id (see Topic) whenever its layout or its params
+// change. Subscribing to layout/* gets the layout of every desktop straight away.
+const ChangedTopic subscription.Topic[State] = "changed"
+
+// State is the payload of a desktop's layout topic.
+type State struct {
+	DesktopID bspc.ID
+
+	// Name is empty while the desktop has no layout.
+	Name   string
+	Params Params
+}
+
+// Topic returns the layout topic of the desktop with the given id (e.g. layout/4194306/changed).
+func Topic(desktopID bspc.ID) subscription.Topic[State] {
+	return ChangedTopic.Under("layout", fmt.Sprintf("%d", desktopID))
+}
+
+// AnyTopic matches the layout topics of every desktop.
+var AnyTopic = ChangedTopic.Under("layout", subscription.Wildcard)
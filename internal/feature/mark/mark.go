@@ -0,0 +1,188 @@
+//go:generate mockgen -package mark -destination ./mark_mock.go -self_package github.com/diogox/bspm/internal/feature/mark github.com/diogox/bspm/internal/feature/mark Feature
+
+package mark
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/diogox/bspc-go"
+	"go.uber.org/zap"
+
+	"github.com/diogox/bspm/internal/bspwm"
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	bspwmtree "github.com/diogox/bspm/internal/bspwm/tree"
+	transparentmonocle "github.com/diogox/bspm/internal/feature/transparent_monocle"
+	"github.com/diogox/bspm/internal/log"
+)
+
+var (
+	ErrInvalidMark   = errors.New("marks must be a single letter")
+	ErrUnknownMark   = errors.New("mark isn't set")
+	ErrNoFocusedNode = errors.New("no focused window")
+)
+
+type (
+	// Feature labels windows with a letter, like vim's marks, so they can be jumped to from anywhere.
+	Feature interface {
+		// Set labels the focused window with the mark, taking it from whichever window had it before.
+		Set(mark string) error
+		// Jump focuses the window with the mark, along with its desktop. If it's hidden in a monocle stack, it's
+		// made the stack's selected window first. Windows hidden by anything else (e.g. minimised ones) can't be
+		// jumped to.
+		Jump(mark string) error
+	}
+
+	feature struct {
+		logger  *log.Logger
+		tree    bspwmtree.Tree
+		monocle transparentmonocle.Feature
+		store   Store
+
+		mutex sync.Mutex
+		marks map[string]bspc.ID
+	}
+)
+
+// Start loads the marks from the store, and keeps them until the returned function is called. Marks are dropped
+// once their window is closed, as are those of windows that were closed while the daemon wasn't running.
+func Start(
+	logger *log.Logger,
+	service bspwm.Service,
+	tree bspwmtree.Tree,
+	monocle transparentmonocle.Feature,
+	store Store,
+) (Feature, func()) {
+	f := &feature{
+		logger:  logger,
+		tree:    tree,
+		monocle: monocle,
+		store:   store,
+		marks:   make(map[string]bspc.ID),
+	}
+
+	f.load()
+
+	handles := []bspwmevent.Handle{
+		bspwmevent.On(service.Events(), func(payload bspc.EventNodeRemove) error {
+			if err := f.handleNodeRemoved(payload.NodeID); err != nil {
+				return fmt.Errorf("failed to remove marks of removed node: %w", err)
+			}
+
+			return nil
+		}),
+	}
+
+	cancelFunc := func() {
+		for _, h := range handles {
+			service.Events().Off(h)
+		}
+	}
+
+	return f, cancelFunc
+}
+
+// load takes the stored marks whose windows still exist. Failing to load them isn't fatal, since marks are only
+// a convenience, so it starts without any instead.
+func (f *feature) load() {
+	marks, err := f.store.Load()
+	if err != nil {
+		f.logger.Warning("failed to load marks", zap.Error(err))
+		return
+	}
+
+	for mark, nodeID := range marks {
+		if _, err := f.tree.Node(nodeID); err != nil {
+			continue
+		}
+
+		f.marks[mark] = nodeID
+	}
+
+	if len(f.marks) == len(marks) {
+		return
+	}
+
+	if err := f.store.Save(f.marks); err != nil {
+		f.logger.Warning("failed to save marks", zap.Error(err))
+	}
+}
+
+func (f *feature) Set(mark string) error {
+	if !isValid(mark) {
+		return fmt.Errorf("%w: %q", ErrInvalidMark, mark)
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	desktop, err := f.tree.FocusedDesktop()
+	if err != nil {
+		return fmt.Errorf("failed to get focused desktop: %w", err)
+	}
+
+	if desktop.FocusedNodeID == bspc.NilID {
+		return ErrNoFocusedNode
+	}
+
+	f.marks[mark] = desktop.FocusedNodeID
+
+	if err := f.store.Save(f.marks); err != nil {
+		return fmt.Errorf("failed to save marks: %w", err)
+	}
+
+	return nil
+}
+
+func (f *feature) Jump(mark string) error {
+	if !isValid(mark) {
+		return fmt.Errorf("%w: %q", ErrInvalidMark, mark)
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	nodeID, ok := f.marks[mark]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownMark, mark)
+	}
+
+	if err := f.monocle.FocusNode(nodeID); err != nil {
+		return fmt.Errorf("failed to focus node: %w", err)
+	}
+
+	return nil
+}
+
+func (f *feature) handleNodeRemoved(nodeID bspc.ID) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	var isRemoved bool
+	for mark, id := range f.marks {
+		if id == nodeID {
+			delete(f.marks, mark)
+			isRemoved = true
+		}
+	}
+
+	if !isRemoved {
+		return nil
+	}
+
+	if err := f.store.Save(f.marks); err != nil {
+		return fmt.Errorf("failed to save marks: %w", err)
+	}
+
+	return nil
+}
+
+func isValid(mark string) bool {
+	if len(mark) != 1 {
+		return false
+	}
+
+	c := mark[0]
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
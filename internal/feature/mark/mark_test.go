@@ -0,0 +1,342 @@
+package mark_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/diogox/bspc-go"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/diogox/bspm/internal/bspwm"
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	bspwmtree "github.com/diogox/bspm/internal/bspwm/tree"
+	"github.com/diogox/bspm/internal/feature/mark"
+	transparentmonocle "github.com/diogox/bspm/internal/feature/transparent_monocle"
+	"github.com/diogox/bspm/internal/log"
+)
+
+// memoryStore keeps the marks it's given, as a stand-in for the file store.
+type memoryStore struct {
+	marks map[string]bspc.ID
+}
+
+func (s *memoryStore) Load() (map[string]bspc.ID, error) {
+	marks := make(map[string]bspc.ID, len(s.marks))
+	for m, id := range s.marks {
+		marks[m] = id
+	}
+
+	return marks, nil
+}
+
+func (s *memoryStore) Save(marks map[string]bspc.ID) error {
+	s.marks = make(map[string]bspc.ID, len(marks))
+	for m, id := range marks {
+		s.marks[m] = id
+	}
+
+	return nil
+}
+
+func TestMark(t *testing.T) {
+	t.Run("should jump to the marked window", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockMonocle      = transparentmonocle.NewMockFeature(ctrl)
+			store            = &memoryStore{}
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			Return(bspc.Node{}, nil).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := mark.Start(logger, mockService, mockTree, mockMonocle, store)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: 100, FocusedNodeID: 1}, nil)
+		require.NoError(t, feature.Set("a"))
+
+		mockMonocle.EXPECT().
+			FocusNode(bspc.ID(1)).
+			Return(nil)
+		require.NoError(t, feature.Jump("a"))
+	})
+	t.Run("should move the mark to the focused window", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockMonocle      = transparentmonocle.NewMockFeature(ctrl)
+			store            = &memoryStore{marks: map[string]bspc.ID{"a": 1}}
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			Return(bspc.Node{}, nil).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := mark.Start(logger, mockService, mockTree, mockMonocle, store)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: 100, FocusedNodeID: 2}, nil)
+		require.NoError(t, feature.Set("a"))
+
+		assert.Equal(t, map[string]bspc.ID{"a": 2}, store.marks)
+
+		mockMonocle.EXPECT().
+			FocusNode(bspc.ID(2)).
+			Return(nil)
+		require.NoError(t, feature.Jump("a"))
+	})
+	t.Run("should jump to marks set before the daemon was restarted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockMonocle      = transparentmonocle.NewMockFeature(ctrl)
+			store            = &memoryStore{marks: map[string]bspc.ID{"a": 1}}
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			Return(bspc.Node{}, nil).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := mark.Start(logger, mockService, mockTree, mockMonocle, store)
+
+		mockMonocle.EXPECT().
+			FocusNode(bspc.ID(1)).
+			Return(nil)
+		require.NoError(t, feature.Jump("a"))
+	})
+	t.Run("should fail to jump to hidden windows", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockMonocle      = transparentmonocle.NewMockFeature(ctrl)
+			store            = &memoryStore{marks: map[string]bspc.ID{"a": 1}}
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			Return(bspc.Node{}, nil).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := mark.Start(logger, mockService, mockTree, mockMonocle, store)
+
+		mockMonocle.EXPECT().
+			FocusNode(bspc.ID(1)).
+			Return(transparentmonocle.ErrNodeHidden)
+
+		err = feature.Jump("a")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, transparentmonocle.ErrNodeHidden))
+	})
+	t.Run("should drop the marks of windows that no longer exist", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			store            = &memoryStore{marks: map[string]bspc.ID{"a": 1, "b": 2}}
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		mockTree.EXPECT().
+			Node(bspc.ID(1)).
+			Return(bspc.Node{}, nil)
+		mockTree.EXPECT().
+			Node(bspc.ID(2)).
+			Return(bspc.Node{}, errors.New("not found"))
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		mark.Start(logger, mockService, mockTree, transparentmonocle.NewMockFeature(ctrl), store)
+
+		assert.Equal(t, map[string]bspc.ID{"a": 1}, store.marks)
+	})
+	t.Run("should drop the marks of closed windows", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockMonocle      = transparentmonocle.NewMockFeature(ctrl)
+			store            = &memoryStore{marks: map[string]bspc.ID{"a": 1, "b": 1, "c": 2}}
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			Return(bspc.Node{}, nil).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := mark.Start(logger, mockService, mockTree, mockMonocle, store)
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeRemove](bspc.EventNodeRemove{NodeID: 1}))
+
+		assert.Equal(t, map[string]bspc.ID{"c": 2}, store.marks)
+
+		err = feature.Jump("a")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, mark.ErrUnknownMark))
+	})
+	t.Run("should fail to set a mark without a focused window", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockMonocle      = transparentmonocle.NewMockFeature(ctrl)
+			store            = &memoryStore{}
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			Return(bspc.Node{}, nil).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := mark.Start(logger, mockService, mockTree, mockMonocle, store)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: 100, FocusedNodeID: bspc.NilID}, nil)
+
+		err = feature.Set("a")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, mark.ErrNoFocusedNode))
+	})
+	t.Run("should only take single letters as marks", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockMonocle      = transparentmonocle.NewMockFeature(ctrl)
+			store            = &memoryStore{}
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			Return(bspc.Node{}, nil).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := mark.Start(logger, mockService, mockTree, mockMonocle, store)
+
+		for _, m := range []string{"", "ab", "1", "*"} {
+			err = feature.Set(m)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, mark.ErrInvalidMark))
+		}
+	})
+}
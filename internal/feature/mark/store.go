@@ -0,0 +1,92 @@
+package mark
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/diogox/bspc-go"
+)
+
+type (
+	// Store keeps the marks across daemon restarts.
+	Store interface {
+		Load() (map[string]bspc.ID, error)
+		Save(marks map[string]bspc.ID) error
+	}
+
+	fileStore struct {
+		path string
+	}
+)
+
+// NewFileStore returns a store that keeps the marks in a JSON file at the given path, creating its directory if it
+// doesn't exist.
+func NewFileStore(path string) Store {
+	return fileStore{
+		path: path,
+	}
+}
+
+// DefaultPath returns where the marks are kept by default: $XDG_STATE_HOME/bspm/marks.json, or
+// ~/.local/state/bspm/marks.json if it's not set. It falls back to the temporary directory if there's no home
+// directory either.
+func DefaultPath() string {
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			home = os.TempDir()
+		}
+
+		dir = filepath.Join(home, ".local", "state")
+	}
+
+	return filepath.Join(dir, "bspm", "marks.json")
+}
+
+// Load returns no marks, rather than an error, if none were ever saved.
+func (s fileStore) Load() (map[string]bspc.ID, error) {
+	bb, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return make(map[string]bspc.ID), nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read marks: %w", err)
+	}
+
+	marks := make(map[string]bspc.ID)
+	if err := json.Unmarshal(bb, &marks); err != nil {
+		return nil, fmt.Errorf("failed to decode marks: %w", err)
+	}
+
+	return marks, nil
+}
+
+// Save writes the marks to a temporary file first, and then moves it into place, so a crash halfway through
+// doesn't leave the file corrupted.
+func (s fileStore) Save(marks map[string]bspc.ID) error {
+	bb, err := json.Marshal(marks)
+	if err != nil {
+		return fmt.Errorf("failed to encode marks: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create marks directory: %w", err)
+	}
+
+	tmpPath := s.path + ".tmp"
+	if err := os.WriteFile(tmpPath, bb, 0o600); err != nil {
+		return fmt.Errorf("failed to write marks: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		return fmt.Errorf("failed to replace marks: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package mark_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/diogox/bspc-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/diogox/bspm/internal/feature/mark"
+)
+
+func TestFileStore(t *testing.T) {
+	t.Run("should load the marks it saved", func(t *testing.T) {
+		store := mark.NewFileStore(filepath.Join(t.TempDir(), "bspm", "marks.json"))
+
+		require.NoError(t, store.Save(map[string]bspc.ID{"a": 1, "B": 2}))
+
+		marks, err := store.Load()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]bspc.ID{"a": 1, "B": 2}, marks)
+	})
+	t.Run("should load no marks if none were saved", func(t *testing.T) {
+		store := mark.NewFileStore(filepath.Join(t.TempDir(), "marks.json"))
+
+		marks, err := store.Load()
+		require.NoError(t, err)
+		assert.Empty(t, marks)
+	})
+	t.Run("should fail to load corrupted marks", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "marks.json")
+		require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+		_, err := mark.NewFileStore(path).Load()
+		assert.Error(t, err)
+	})
+}
+
+func TestDefaultPath(t *testing.T) {
+	t.Run("should keep the marks in the state directory", func(t *testing.T) {
+		t.Setenv("XDG_STATE_HOME", "/state")
+
+		assert.Equal(t, "/state/bspm/marks.json", mark.DefaultPath())
+	})
+	t.Run("should fall back to the home directory", func(t *testing.T) {
+		t.Setenv("XDG_STATE_HOME", "")
+		t.Setenv("HOME", "/home/user")
+
+		assert.Equal(t, "/home/user/.local/state/bspm/marks.json", mark.DefaultPath())
+	})
+}
@@ -0,0 +1,260 @@
+//go:generate mockgen -package minimize -destination ./minimize_mock.go -self_package github.com/diogox/bspm/internal/feature/minimize github.com/diogox/bspm/internal/feature/minimize Feature
+
+package minimize
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/diogox/bspc-go"
+	"go.uber.org/zap"
+
+	"github.com/diogox/bspm/internal/bspwm"
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	"github.com/diogox/bspm/internal/bspwm/filter"
+	bspwmtree "github.com/diogox/bspm/internal/bspwm/tree"
+	"github.com/diogox/bspm/internal/log"
+	"github.com/diogox/bspm/internal/subscription"
+	"github.com/diogox/bspm/internal/x11"
+)
+
+var (
+	ErrNoFocusedNode    = errors.New("no focused window")
+	ErrNothingMinimized = errors.New("no window is minimised")
+	ErrNotMinimized     = errors.New("window isn't minimised")
+)
+
+type (
+	// Feature hides windows away, and keeps track of them, so they can be brought back later.
+	// It's separate from the hiding done by the monocle and tabbed features, which manage their own windows.
+	Feature interface {
+		// Minimize hides the focused window.
+		Minimize() error
+		// Restore shows the minimised window again, and focuses it. It goes back to the spot it was minimised from,
+		// or to the focused desktop if toFocusedDesktop is true.
+		Restore(nodeID bspc.ID, toFocusedDesktop bool) error
+		// RestoreLast restores the window that was minimised last.
+		RestoreLast(toFocusedDesktop bool) error
+	}
+
+	feature struct {
+		logger        *log.Logger
+		service       bspwm.Service
+		tree          bspwmtree.Tree
+		windows       x11.Properties
+		subscriptions subscription.Manager
+
+		mutex     sync.Mutex
+		minimized []Window
+	}
+)
+
+// Start keeps track of the minimised windows until the returned function is called.
+// Windows that are closed, or shown by anything else, are no longer minimised. Window titles are only resolved if
+// windows is not nil.
+func Start(
+	logger *log.Logger,
+	service bspwm.Service,
+	tree bspwmtree.Tree,
+	windows x11.Properties,
+	subscriptions subscription.Manager,
+) (Feature, func()) {
+	f := &feature{
+		logger:        logger,
+		service:       service,
+		tree:          tree,
+		windows:       windows,
+		subscriptions: subscriptions,
+	}
+
+	// Published straight away, so subscribers don't wait for the first window to be minimised to hear of none.
+	f.publish()
+
+	handles := []bspwmevent.Handle{
+		bspwmevent.On(service.Events(), func(payload bspc.EventNodeRemove) error {
+			f.forget(payload.NodeID)
+			return nil
+		}),
+		bspwmevent.On(service.Events(), func(payload bspc.EventNodeFlag) error {
+			if payload.Flag == bspc.FlagTypeHidden && !payload.WasEnabled {
+				f.forget(payload.NodeID)
+			}
+
+			return nil
+		}),
+		bspwmevent.On(service.Events(), func(payload bspc.EventNodeTransfer) error {
+			f.handleNodeTransferred(payload.SourceNodeID, payload.DestinationDesktopID)
+			return nil
+		}),
+	}
+
+	cancelFunc := func() {
+		for _, h := range handles {
+			service.Events().Off(h)
+		}
+	}
+
+	return f, cancelFunc
+}
+
+// Minimize doesn't need to remember where the window was in the tree: bspwm keeps hidden windows where they are,
+// and only takes them into account again once they're shown.
+func (f *feature) Minimize() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	desktop, err := f.tree.FocusedDesktop()
+	if err != nil {
+		return fmt.Errorf("failed to get focused desktop: %w", err)
+	}
+
+	node, ok := leafOf(desktop.Root, desktop.FocusedNodeID)
+	if !ok || node.Client == nil {
+		return ErrNoFocusedNode
+	}
+
+	if err := f.service.Nodes().SetVisibility(node.ID, false); err != nil {
+		return fmt.Errorf("failed to hide window: %w", err)
+	}
+
+	f.minimized = append(f.minimized, f.describe(node, desktop.ID))
+	f.publish()
+
+	return nil
+}
+
+func (f *feature) Restore(nodeID bspc.ID, toFocusedDesktop bool) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	i := f.indexOf(nodeID)
+	if i < 0 {
+		return fmt.Errorf("%w: %d", ErrNotMinimized, nodeID)
+	}
+
+	return f.restore(i, toFocusedDesktop)
+}
+
+func (f *feature) RestoreLast(toFocusedDesktop bool) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.minimized) == 0 {
+		return ErrNothingMinimized
+	}
+
+	return f.restore(len(f.minimized)-1, toFocusedDesktop)
+}
+
+// restore shows the i-th minimised window. It must be called with the mutex held.
+func (f *feature) restore(i int, toFocusedDesktop bool) error {
+	w := f.minimized[i]
+
+	if toFocusedDesktop {
+		desktop, err := f.tree.FocusedDesktop()
+		if err != nil {
+			return fmt.Errorf("failed to get focused desktop: %w", err)
+		}
+
+		if desktop.ID != w.DesktopID {
+			if err := f.service.Nodes().MoveToDesktop(w.NodeID, filter.DesktopID(desktop.ID)); err != nil {
+				return fmt.Errorf("failed to move window to the focused desktop: %w", err)
+			}
+
+			f.minimized[i].DesktopID = desktop.ID
+		}
+	}
+
+	if err := f.service.Nodes().SetVisibility(w.NodeID, true); err != nil {
+		return fmt.Errorf("failed to show window: %w", err)
+	}
+
+	f.minimized = append(f.minimized[:i], f.minimized[i+1:]...)
+	f.publish()
+
+	if err := f.service.Nodes().Focus(w.NodeID); err != nil {
+		return fmt.Errorf("failed to focus window: %w", err)
+	}
+
+	return nil
+}
+
+// forget stops tracking the window, if it's minimised, since it was either closed or shown by something else.
+func (f *feature) forget(nodeID bspc.ID) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	i := f.indexOf(nodeID)
+	if i < 0 {
+		return
+	}
+
+	f.minimized = append(f.minimized[:i], f.minimized[i+1:]...)
+	f.publish()
+}
+
+// handleNodeTransferred keeps track of minimised windows that are moved to another desktop (e.g. when theirs is
+// removed), so they're restored there.
+func (f *feature) handleNodeTransferred(nodeID bspc.ID, desktopID bspc.ID) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	i := f.indexOf(nodeID)
+	if i < 0 || f.minimized[i].DesktopID == desktopID {
+		return
+	}
+
+	f.minimized[i].DesktopID = desktopID
+	f.publish()
+}
+
+func (f *feature) indexOf(nodeID bspc.ID) int {
+	for i, w := range f.minimized {
+		if w.NodeID == nodeID {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (f *feature) describe(node bspc.Node, desktopID bspc.ID) Window {
+	w := Window{
+		NodeID:       node.ID,
+		DesktopID:    desktopID,
+		ClassName:    node.Client.ClassName,
+		InstanceName: node.Client.InstanceName,
+	}
+
+	if f.windows != nil {
+		title, err := f.windows.Title(node.ID)
+		if err != nil {
+			f.logger.Warning("failed to get window title",
+				zap.Uint("node_id", uint(node.ID)),
+				zap.Error(err),
+			)
+		}
+
+		w.Title = title
+	}
+
+	return w
+}
+
+// publish publishes the minimised windows. It must be called with the mutex held.
+func (f *feature) publish() {
+	ChangedTopic.PublishRetained(f.subscriptions, State{
+		Windows: append(make([]Window, 0, len(f.minimized)), f.minimized...),
+	})
+}
+
+func leafOf(root bspc.Node, id bspc.ID) (bspc.Node, bool) {
+	for _, n := range root.LeafNodes() {
+		if n.ID == id {
+			return n, true
+		}
+	}
+
+	return bspc.Node{}, false
+}
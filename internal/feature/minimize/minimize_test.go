@@ -0,0 +1,739 @@
+package minimize_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/diogox/bspc-go"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/diogox/bspm/internal/bspwm"
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	"github.com/diogox/bspm/internal/bspwm/filter"
+	bspwmnode "github.com/diogox/bspm/internal/bspwm/node"
+	bspwmtree "github.com/diogox/bspm/internal/bspwm/tree"
+	"github.com/diogox/bspm/internal/feature/minimize"
+	"github.com/diogox/bspm/internal/log"
+	"github.com/diogox/bspm/internal/subscription"
+	"github.com/diogox/bspm/internal/x11"
+)
+
+const (
+	desktopID      = bspc.ID(100)
+	otherDesktopID = bspc.ID(200)
+)
+
+// minimizedIDs returns the ids of the minimised windows, along with their desktop's.
+func minimizedIDs(st minimize.State) [][2]bspc.ID {
+	ids := make([][2]bspc.ID, 0, len(st.Windows))
+	for _, w := range st.Windows {
+		ids = append(ids, [2]bspc.ID{w.NodeID, w.DesktopID})
+	}
+
+	return ids
+}
+
+func TestMinimize(t *testing.T) {
+	t.Run("should publish that there are no minimised windows straight away", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockWindows      = x11.NewMockProperties(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockWindows.EXPECT().
+			Title(gomock.Any()).
+			Return("title", nil).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		minimize.Start(logger, mockService, mockTree, mockWindows, subscriptions)
+
+		assert.Empty(t, minimizedIDs(<-minimize.ChangedTopic.Subscribe(ctx, subscriptions)))
+	})
+	t.Run("should hide the focused window", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockWindows      = x11.NewMockProperties(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockWindows.EXPECT().
+			Title(gomock.Any()).
+			Return("title", nil).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := minimize.Start(logger, mockService, mockTree, mockWindows, subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{
+				ID:            desktopID,
+				FocusedNodeID: 1,
+				Root:          bspc.Node{ID: 1, Client: &bspc.NodeClient{ClassName: "Alacritty", InstanceName: "alacritty", State: bspc.StateTypeTiled}},
+			}, nil)
+		mockNodes.EXPECT().
+			SetVisibility(bspc.ID(1), false).
+			Return(nil)
+
+		require.NoError(t, feature.Minimize())
+
+		assert.Equal(t, minimize.State{
+			Windows: []minimize.Window{{
+				NodeID:       1,
+				DesktopID:    desktopID,
+				ClassName:    "Alacritty",
+				InstanceName: "alacritty",
+				Title:        "title",
+			}},
+		}, <-minimize.ChangedTopic.Subscribe(ctx, subscriptions))
+	})
+	t.Run("should fail to minimise without a focused window", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockWindows      = x11.NewMockProperties(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockWindows.EXPECT().
+			Title(gomock.Any()).
+			Return("title", nil).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := minimize.Start(logger, mockService, mockTree, mockWindows, subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID}, nil)
+
+		err = feature.Minimize()
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, minimize.ErrNoFocusedNode))
+	})
+	t.Run("should restore the last window minimised", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockWindows      = x11.NewMockProperties(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockWindows.EXPECT().
+			Title(gomock.Any()).
+			Return("title", nil).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := minimize.Start(logger, mockService, mockTree, mockWindows, subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{
+				ID:            desktopID,
+				FocusedNodeID: 1,
+				Root:          bspc.Node{ID: 1, Client: &bspc.NodeClient{ClassName: "Alacritty", InstanceName: "alacritty", State: bspc.StateTypeTiled}},
+			}, nil)
+		mockNodes.EXPECT().
+			SetVisibility(bspc.ID(1), false).
+			Return(nil)
+
+		require.NoError(t, feature.Minimize())
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{
+				ID:            desktopID,
+				FocusedNodeID: 2,
+				Root:          bspc.Node{ID: 2, Client: &bspc.NodeClient{ClassName: "Alacritty", InstanceName: "alacritty", State: bspc.StateTypeTiled}},
+			}, nil)
+		mockNodes.EXPECT().
+			SetVisibility(bspc.ID(2), false).
+			Return(nil)
+
+		require.NoError(t, feature.Minimize())
+
+		gomock.InOrder(
+			mockNodes.EXPECT().
+				SetVisibility(bspc.ID(2), true).
+				Return(nil),
+			mockNodes.EXPECT().
+				Focus(bspc.ID(2)).
+				Return(nil),
+		)
+		require.NoError(t, feature.RestoreLast(false))
+
+		assert.Equal(t, [][2]bspc.ID{{1, desktopID}}, minimizedIDs(<-minimize.ChangedTopic.Subscribe(ctx, subscriptions)))
+	})
+	t.Run("should restore the given window", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockWindows      = x11.NewMockProperties(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockWindows.EXPECT().
+			Title(gomock.Any()).
+			Return("title", nil).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := minimize.Start(logger, mockService, mockTree, mockWindows, subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{
+				ID:            desktopID,
+				FocusedNodeID: 1,
+				Root:          bspc.Node{ID: 1, Client: &bspc.NodeClient{ClassName: "Alacritty", InstanceName: "alacritty", State: bspc.StateTypeTiled}},
+			}, nil)
+		mockNodes.EXPECT().
+			SetVisibility(bspc.ID(1), false).
+			Return(nil)
+
+		require.NoError(t, feature.Minimize())
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{
+				ID:            desktopID,
+				FocusedNodeID: 2,
+				Root:          bspc.Node{ID: 2, Client: &bspc.NodeClient{ClassName: "Alacritty", InstanceName: "alacritty", State: bspc.StateTypeTiled}},
+			}, nil)
+		mockNodes.EXPECT().
+			SetVisibility(bspc.ID(2), false).
+			Return(nil)
+
+		require.NoError(t, feature.Minimize())
+
+		gomock.InOrder(
+			mockNodes.EXPECT().
+				SetVisibility(bspc.ID(1), true).
+				Return(nil),
+			mockNodes.EXPECT().
+				Focus(bspc.ID(1)).
+				Return(nil),
+		)
+		require.NoError(t, feature.Restore(1, false))
+
+		assert.Equal(t, [][2]bspc.ID{{2, desktopID}}, minimizedIDs(<-minimize.ChangedTopic.Subscribe(ctx, subscriptions)))
+	})
+	t.Run("should fail to restore a window that isn't minimised", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockWindows      = x11.NewMockProperties(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockWindows.EXPECT().
+			Title(gomock.Any()).
+			Return("title", nil).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := minimize.Start(logger, mockService, mockTree, mockWindows, subscriptions)
+
+		err = feature.Restore(1, false)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, minimize.ErrNotMinimized))
+
+		err = feature.RestoreLast(false)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, minimize.ErrNothingMinimized))
+	})
+	t.Run("should restore a window to the focused desktop", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockWindows      = x11.NewMockProperties(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockWindows.EXPECT().
+			Title(gomock.Any()).
+			Return("title", nil).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := minimize.Start(logger, mockService, mockTree, mockWindows, subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{
+				ID:            desktopID,
+				FocusedNodeID: 1,
+				Root:          bspc.Node{ID: 1, Client: &bspc.NodeClient{ClassName: "Alacritty", InstanceName: "alacritty", State: bspc.StateTypeTiled}},
+			}, nil)
+		mockNodes.EXPECT().
+			SetVisibility(bspc.ID(1), false).
+			Return(nil)
+
+		require.NoError(t, feature.Minimize())
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{
+				ID:            otherDesktopID,
+				FocusedNodeID: 2,
+				Root:          bspc.Node{ID: 2, Client: &bspc.NodeClient{ClassName: "Alacritty", InstanceName: "alacritty", State: bspc.StateTypeTiled}},
+			}, nil)
+		mockNodes.EXPECT().
+			MoveToDesktop(bspc.ID(1), filter.DesktopID(otherDesktopID)).
+			Return(nil)
+		gomock.InOrder(
+			mockNodes.EXPECT().
+				SetVisibility(bspc.ID(1), true).
+				Return(nil),
+			mockNodes.EXPECT().
+				Focus(bspc.ID(1)).
+				Return(nil),
+		)
+
+		require.NoError(t, feature.RestoreLast(true))
+	})
+	t.Run("should leave a window where it is when restoring it to its own desktop", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockWindows      = x11.NewMockProperties(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockWindows.EXPECT().
+			Title(gomock.Any()).
+			Return("title", nil).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := minimize.Start(logger, mockService, mockTree, mockWindows, subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{
+				ID:            desktopID,
+				FocusedNodeID: 1,
+				Root:          bspc.Node{ID: 1, Client: &bspc.NodeClient{ClassName: "Alacritty", InstanceName: "alacritty", State: bspc.StateTypeTiled}},
+			}, nil)
+		mockNodes.EXPECT().
+			SetVisibility(bspc.ID(1), false).
+			Return(nil)
+
+		require.NoError(t, feature.Minimize())
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{
+				ID:            desktopID,
+				FocusedNodeID: 2,
+				Root:          bspc.Node{ID: 2, Client: &bspc.NodeClient{ClassName: "Alacritty", InstanceName: "alacritty", State: bspc.StateTypeTiled}},
+			}, nil)
+		gomock.InOrder(
+			mockNodes.EXPECT().
+				SetVisibility(bspc.ID(1), true).
+				Return(nil),
+			mockNodes.EXPECT().
+				Focus(bspc.ID(1)).
+				Return(nil),
+		)
+
+		require.NoError(t, feature.RestoreLast(true))
+	})
+	t.Run("should follow windows moved to another desktop", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockWindows      = x11.NewMockProperties(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockWindows.EXPECT().
+			Title(gomock.Any()).
+			Return("title", nil).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := minimize.Start(logger, mockService, mockTree, mockWindows, subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{
+				ID:            desktopID,
+				FocusedNodeID: 1,
+				Root:          bspc.Node{ID: 1, Client: &bspc.NodeClient{ClassName: "Alacritty", InstanceName: "alacritty", State: bspc.StateTypeTiled}},
+			}, nil)
+		mockNodes.EXPECT().
+			SetVisibility(bspc.ID(1), false).
+			Return(nil)
+
+		require.NoError(t, feature.Minimize())
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeTransfer](bspc.EventNodeTransfer{
+			SourceDesktopID:      desktopID,
+			SourceNodeID:         1,
+			DestinationDesktopID: otherDesktopID,
+		}))
+
+		assert.Equal(t, [][2]bspc.ID{{1, otherDesktopID}}, minimizedIDs(<-minimize.ChangedTopic.Subscribe(ctx, subscriptions)))
+	})
+	t.Run("should forget windows that are closed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockWindows      = x11.NewMockProperties(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockWindows.EXPECT().
+			Title(gomock.Any()).
+			Return("title", nil).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := minimize.Start(logger, mockService, mockTree, mockWindows, subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{
+				ID:            desktopID,
+				FocusedNodeID: 1,
+				Root:          bspc.Node{ID: 1, Client: &bspc.NodeClient{ClassName: "Alacritty", InstanceName: "alacritty", State: bspc.StateTypeTiled}},
+			}, nil)
+		mockNodes.EXPECT().
+			SetVisibility(bspc.ID(1), false).
+			Return(nil)
+
+		require.NoError(t, feature.Minimize())
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeRemove](bspc.EventNodeRemove{DesktopID: desktopID, NodeID: 1}))
+
+		assert.Empty(t, minimizedIDs(<-minimize.ChangedTopic.Subscribe(ctx, subscriptions)))
+	})
+	t.Run("should forget windows that are shown by something else", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockWindows      = x11.NewMockProperties(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockWindows.EXPECT().
+			Title(gomock.Any()).
+			Return("title", nil).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := minimize.Start(logger, mockService, mockTree, mockWindows, subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{
+				ID:            desktopID,
+				FocusedNodeID: 1,
+				Root:          bspc.Node{ID: 1, Client: &bspc.NodeClient{ClassName: "Alacritty", InstanceName: "alacritty", State: bspc.StateTypeTiled}},
+			}, nil)
+		mockNodes.EXPECT().
+			SetVisibility(bspc.ID(1), false).
+			Return(nil)
+
+		require.NoError(t, feature.Minimize())
+
+		// Hiding it again doesn't change anything.
+		require.NoError(t, callbacks[bspc.EventTypeNodeFlag](bspc.EventNodeFlag{
+			NodeID:     1,
+			Flag:       bspc.FlagTypeHidden,
+			WasEnabled: true,
+		}))
+		assert.Len(t, minimizedIDs(<-minimize.ChangedTopic.Subscribe(ctx, subscriptions)), 1)
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeFlag](bspc.EventNodeFlag{
+			NodeID:     1,
+			Flag:       bspc.FlagTypeHidden,
+			WasEnabled: false,
+		}))
+		assert.Empty(t, minimizedIDs(<-minimize.ChangedTopic.Subscribe(ctx, subscriptions)))
+	})
+}
@@ -0,0 +1,31 @@
+package minimize
+
+import (
+	"github.com/diogox/bspc-go"
+
+	"github.com/diogox/bspm/internal/subscription"
+)
+
+// ChangedTopic is published, and retained, whenever a window is minimised or restored. Subscribing to it gets the
+// minimised windows straight away.
+const ChangedTopic subscription.Topic[State] = "minimized/changed"
+
+type (
+	// State is the payload of the minimised topic.
+	State struct {
+		// Windows are in the order they were minimised in, the last one being the one restored by default.
+		Windows []Window
+	}
+
+	Window struct {
+		NodeID bspc.ID
+
+		// DesktopID is the desktop the window is restored to, unless it's brought to the focused one.
+		DesktopID    bspc.ID
+		ClassName    string
+		InstanceName string
+
+		// Title is empty if the window's properties can't be read.
+		Title string
+	}
+)
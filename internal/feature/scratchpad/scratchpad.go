@@ -0,0 +1,411 @@
+//go:generate mockgen -package scratchpad -destination ./scratchpad_mock.go -self_package github.com/diogox/bspm/internal/feature/scratchpad github.com/diogox/bspm/internal/feature/scratchpad Feature
+
+package scratchpad
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/diogox/bspc-go"
+
+	"github.com/diogox/bspm/internal/bspwm"
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	"github.com/diogox/bspm/internal/bspwm/filter"
+	bspwmtree "github.com/diogox/bspm/internal/bspwm/tree"
+	"github.com/diogox/bspm/internal/log"
+	"github.com/diogox/bspm/internal/subscription"
+)
+
+// launchTimeout is how long a scratchpad without a window class waits for its window, after launching its command.
+// Any window added in the meantime is taken to be it.
+const launchTimeout = 5 * time.Second
+
+var (
+	ErrInvalidName  = errors.New("invalid scratchpad name")
+	ErrNoDefinition = errors.New("scratchpad has neither a window class nor a command")
+)
+
+type (
+	Feature interface {
+		Toggle(name string, definition Definition) error
+	}
+
+	// Definition ties a scratchpad to its window. A window of the given class is used if there is one,
+	// and the command is launched otherwise. Either of them can be left empty, but not both.
+	Definition struct {
+		ClassName string
+		Command   string
+	}
+
+	// launchFunc runs the command in the background.
+	launchFunc func(command string) error
+
+	scratchpad struct {
+		Definition
+
+		nodeID    bspc.ID
+		isVisible bool
+
+		// desktopID is the desktop the window was last shown in.
+		desktopID bspc.ID
+
+		// launchedAt is set while waiting for the window of a launched command.
+		launchedAt time.Time
+	}
+
+	feature struct {
+		logger        *log.Logger
+		service       bspwm.Service
+		tree          bspwmtree.Tree
+		subscriptions subscription.Manager
+		launch        launchFunc
+
+		mutex       sync.Mutex
+		scratchpads map[string]*scratchpad
+	}
+)
+
+// Start keeps track of the scratchpads' windows until the returned function is called.
+func Start(
+	logger *log.Logger,
+	service bspwm.Service,
+	tree bspwmtree.Tree,
+	subscriptions subscription.Manager,
+) (Feature, func()) {
+	return start(logger, service, tree, subscriptions, launch)
+}
+
+func start(
+	logger *log.Logger,
+	service bspwm.Service,
+	tree bspwmtree.Tree,
+	subscriptions subscription.Manager,
+	launch launchFunc,
+) (Feature, func()) {
+	f := &feature{
+		logger:        logger,
+		service:       service,
+		tree:          tree,
+		subscriptions: subscriptions,
+		launch:        launch,
+		scratchpads:   make(map[string]*scratchpad),
+	}
+
+	handles := []bspwmevent.Handle{
+		bspwmevent.On(service.Events(), func(payload bspc.EventNodeAdd) error {
+			if err := f.handleNodeAdded(payload.NodeID); err != nil {
+				return fmt.Errorf("failed to handle added node: %w", err)
+			}
+
+			return nil
+		}),
+		bspwmevent.On(service.Events(), func(payload bspc.EventNodeRemove) error {
+			f.handleNodeRemoved(payload.NodeID)
+			return nil
+		}),
+	}
+
+	cancelFunc := func() {
+		for _, h := range handles {
+			service.Events().Off(h)
+		}
+	}
+
+	return f, cancelFunc
+}
+
+// Toggle shows the scratchpad's window in the focused desktop, as a centred floating window, or hides it if it's
+// already there. Its window is launched if it doesn't exist yet, and shown once it comes up.
+// The definition is remembered, so it only needs to be given the first time.
+func (f *feature) Toggle(name string, definition Definition) error {
+	if name == "" || strings.Contains(name, subscription.Separator) || name == subscription.Wildcard {
+		return fmt.Errorf("%w: %q", ErrInvalidName, name)
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	sp := f.define(name, definition)
+	if sp.ClassName == "" && sp.Command == "" {
+		return ErrNoDefinition
+	}
+
+	node, ok, err := f.window(sp)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		if !sp.launchedAt.IsZero() && time.Since(sp.launchedAt) < launchTimeout {
+			// Still waiting for its window.
+			return nil
+		}
+
+		if sp.Command == "" {
+			return fmt.Errorf("no window of class %s to show, and no command to launch one", sp.ClassName)
+		}
+
+		if err := f.launch(sp.Command); err != nil {
+			return fmt.Errorf("failed to launch scratchpad %s: %w", name, err)
+		}
+
+		sp.launchedAt = time.Now()
+		return nil
+	}
+
+	focusedDesktop, err := f.tree.FocusedDesktop()
+	if err != nil {
+		return fmt.Errorf("failed to get focused desktop: %w", err)
+	}
+
+	if sp.isVisible && sp.desktopID == focusedDesktop.ID {
+		return f.hide(name, sp)
+	}
+
+	return f.show(name, sp, node, focusedDesktop)
+}
+
+// define returns the scratchpad with the given name, updated with whatever the definition sets.
+func (f *feature) define(name string, definition Definition) *scratchpad {
+	sp, ok := f.scratchpads[name]
+	if !ok {
+		sp = &scratchpad{}
+		f.scratchpads[name] = sp
+	}
+
+	if definition.ClassName != "" {
+		sp.ClassName = definition.ClassName
+	}
+
+	if definition.Command != "" {
+		sp.Command = definition.Command
+	}
+
+	return sp
+}
+
+// window returns the scratchpad's window. If it doesn't have one yet, it takes the first window of its class.
+func (f *feature) window(sp *scratchpad) (bspc.Node, bool, error) {
+	if sp.nodeID != bspc.NilID {
+		node, err := f.tree.Node(sp.nodeID)
+		if err == nil {
+			return node, true, nil
+		}
+
+		// The window is gone.
+		sp.nodeID = bspc.NilID
+		sp.isVisible = false
+	}
+
+	if sp.ClassName == "" {
+		return bspc.Node{}, false, nil
+	}
+
+	for _, m := range f.tree.State().Monitors {
+		for _, d := range m.Desktops {
+			for _, n := range d.Root.LeafNodes() {
+				if n.Client.ClassName == sp.ClassName && !f.isTaken(n.ID) {
+					sp.nodeID = n.ID
+					sp.isVisible = !n.Hidden
+					sp.desktopID = d.ID
+
+					return n, true, nil
+				}
+			}
+		}
+	}
+
+	return bspc.Node{}, false, nil
+}
+
+// isTaken returns true if the window already belongs to a scratchpad.
+func (f *feature) isTaken(nodeID bspc.ID) bool {
+	for _, sp := range f.scratchpads {
+		if sp.nodeID == nodeID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f *feature) hide(name string, sp *scratchpad) error {
+	if err := f.service.Nodes().SetVisibility(sp.nodeID, false); err != nil {
+		return fmt.Errorf("failed to hide scratchpad %s: %w", name, err)
+	}
+
+	sp.isVisible = false
+	f.publish(name, sp)
+
+	return nil
+}
+
+// show brings the window to the given desktop, floating in the middle of its monitor, and focuses it.
+// It's positioned while still hidden, so it doesn't flash anywhere else first.
+func (f *feature) show(name string, sp *scratchpad, node bspc.Node, desktop bspc.Desktop) error {
+	if node.Client.State != bspc.StateTypeFloating {
+		if err := f.service.Nodes().SetState(node.ID, bspc.StateTypeFloating); err != nil {
+			return fmt.Errorf("failed to float scratchpad %s: %w", name, err)
+		}
+	}
+
+	if !containsNode(desktop, node.ID) {
+		if err := f.service.Nodes().MoveToDesktop(node.ID, filter.DesktopID(desktop.ID)); err != nil {
+			return fmt.Errorf("failed to move scratchpad %s to the focused desktop: %w", name, err)
+		}
+	}
+
+	if err := f.centre(node.ID, desktop.ID); err != nil {
+		return fmt.Errorf("failed to centre scratchpad %s: %w", name, err)
+	}
+
+	if err := f.service.Nodes().SetVisibility(node.ID, true); err != nil {
+		return fmt.Errorf("failed to show scratchpad %s: %w", name, err)
+	}
+
+	if err := f.service.Nodes().Focus(node.ID); err != nil {
+		return fmt.Errorf("failed to focus scratchpad %s: %w", name, err)
+	}
+
+	sp.isVisible = true
+	sp.desktopID = desktop.ID
+	f.publish(name, sp)
+
+	return nil
+}
+
+// centre moves the floating node to the middle of the desktop's monitor.
+func (f *feature) centre(nodeID bspc.ID, desktopID bspc.ID) error {
+	monitor, ok := monitorOf(f.tree.State(), desktopID)
+	if !ok {
+		return fmt.Errorf("no monitor found for desktop %d", desktopID)
+	}
+
+	// Floating and moving the node changes its position, so it's fetched from bspwm rather than the tree.
+	node, err := f.service.Nodes().Get(filter.NodeID(nodeID))
+	if err != nil {
+		return err
+	}
+
+	var (
+		dx = monitor.Rectangle.X + (monitor.Rectangle.Width-node.Rectangle.Width)/2 - node.Rectangle.X
+		dy = monitor.Rectangle.Y + (monitor.Rectangle.Height-node.Rectangle.Height)/2 - node.Rectangle.Y
+	)
+
+	if dx == 0 && dy == 0 {
+		return nil
+	}
+
+	return f.service.Nodes().Move(nodeID, dx, dy)
+}
+
+// handleNodeAdded shows the window of a scratchpad that was launched, once it comes up.
+func (f *feature) handleNodeAdded(nodeID bspc.ID) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	for name, sp := range f.scratchpads {
+		if sp.launchedAt.IsZero() {
+			continue
+		}
+
+		node, err := f.tree.Node(nodeID)
+		if err != nil {
+			return err
+		}
+
+		if !isLaunchedWindow(sp, node) {
+			continue
+		}
+
+		sp.launchedAt = time.Time{}
+		sp.nodeID = node.ID
+
+		focusedDesktop, err := f.tree.FocusedDesktop()
+		if err != nil {
+			return fmt.Errorf("failed to get focused desktop: %w", err)
+		}
+
+		return f.show(name, sp, node, focusedDesktop)
+	}
+
+	return nil
+}
+
+// handleNodeRemoved forgets the window of a scratchpad once it's closed.
+func (f *feature) handleNodeRemoved(nodeID bspc.ID) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	for name, sp := range f.scratchpads {
+		if sp.nodeID != nodeID {
+			continue
+		}
+
+		sp.nodeID = bspc.NilID
+		sp.isVisible = false
+		f.publish(name, sp)
+	}
+}
+
+func (f *feature) publish(name string, sp *scratchpad) {
+	Topic(name).PublishRetained(f.subscriptions, State{
+		Name:      name,
+		ClassName: sp.ClassName,
+		Command:   sp.Command,
+		NodeID:    sp.nodeID,
+		IsVisible: sp.isVisible,
+	})
+}
+
+// isLaunchedWindow returns true if the node is the window the scratchpad's command was launched for.
+func isLaunchedWindow(sp *scratchpad, node bspc.Node) bool {
+	if node.Client == nil {
+		return false
+	}
+
+	if sp.ClassName != "" {
+		return node.Client.ClassName == sp.ClassName
+	}
+
+	return time.Since(sp.launchedAt) < launchTimeout
+}
+
+func containsNode(desktop bspc.Desktop, nodeID bspc.ID) bool {
+	for _, n := range desktop.Root.LeafNodes() {
+		if n.ID == nodeID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func monitorOf(st bspc.State, desktopID bspc.ID) (bspc.Monitor, bool) {
+	for _, m := range st.Monitors {
+		for _, d := range m.Desktops {
+			if d.ID == desktopID {
+				return m, true
+			}
+		}
+	}
+
+	return bspc.Monitor{}, false
+}
+
+// launch runs the command through the shell, so it can have arguments, pipes, etc.
+func launch(command string) error {
+	cmd := exec.Command("sh", "-c", command)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	// Reaped in the background, so it doesn't linger as a zombie once it exits.
+	go func() { _ = cmd.Wait() }()
+
+	return nil
+}
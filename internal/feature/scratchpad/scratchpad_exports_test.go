@@ -0,0 +1,19 @@
+package scratchpad
+
+import (
+	"github.com/diogox/bspm/internal/bspwm"
+	bspwmtree "github.com/diogox/bspm/internal/bspwm/tree"
+	"github.com/diogox/bspm/internal/log"
+	"github.com/diogox/bspm/internal/subscription"
+)
+
+// StartWithLauncher starts the feature with a launcher that doesn't actually run the commands.
+func StartWithLauncher(
+	logger *log.Logger,
+	service bspwm.Service,
+	tree bspwmtree.Tree,
+	subscriptions subscription.Manager,
+	launch func(command string) error,
+) (Feature, func()) {
+	return start(logger, service, tree, subscriptions, launch)
+}
@@ -0,0 +1,481 @@
+package scratchpad_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/diogox/bspc-go"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/diogox/bspm/internal/bspwm"
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	"github.com/diogox/bspm/internal/bspwm/filter"
+	bspwmnode "github.com/diogox/bspm/internal/bspwm/node"
+	bspwmtree "github.com/diogox/bspm/internal/bspwm/tree"
+	"github.com/diogox/bspm/internal/feature/scratchpad"
+	"github.com/diogox/bspm/internal/log"
+	"github.com/diogox/bspm/internal/subscription"
+)
+
+const (
+	desktopID      = bspc.ID(1)
+	otherDesktopID = bspc.ID(2)
+	nodeID         = bspc.ID(3)
+	className      = "scratch-term"
+)
+
+func TestScratchpad_Toggle(t *testing.T) {
+	t.Run("should launch the command and show its window once it comes up", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			node = bspc.Node{
+				ID:     nodeID,
+				Hidden: true,
+				Client: &bspc.NodeClient{
+					ClassName: className,
+					State:     bspc.StateTypeTiled,
+				},
+			}
+			monitor = bspc.Monitor{Desktops: []bspc.Desktop{{ID: desktopID}}}
+			placed  = node
+		)
+
+		// The window is centred in the monitor it's shown in.
+		monitor.Rectangle.Width = 1000
+		monitor.Rectangle.Height = 800
+		placed.Rectangle.Width = 400
+		placed.Rectangle.Height = 200
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+			launched         = []string{}
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockTree.EXPECT().
+			State().
+			Return(bspc.State{})
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := scratchpad.StartWithLauncher(logger, mockService, mockTree, subscriptions, func(command string) error {
+			launched = append(launched, command)
+			return nil
+		})
+
+		definition := scratchpad.Definition{ClassName: className, Command: "alacritty --class scratch-term"}
+		require.NoError(t, feature.Toggle("term", definition))
+		assert.Equal(t, []string{definition.Command}, launched)
+
+		// Toggling again while waiting for the window doesn't launch it twice.
+		mockTree.EXPECT().
+			State().
+			Return(bspc.State{})
+
+		require.NoError(t, feature.Toggle("term", scratchpad.Definition{}))
+		assert.Len(t, launched, 1)
+
+		mockTree.EXPECT().
+			Node(nodeID).
+			Return(node, nil)
+		gomock.InOrder(
+			mockTree.EXPECT().
+				FocusedDesktop().
+				Return(bspc.Desktop{ID: desktopID}, nil),
+			mockNodes.EXPECT().
+				SetState(nodeID, bspc.StateTypeFloating).
+				Return(nil),
+			mockNodes.EXPECT().
+				MoveToDesktop(nodeID, filter.DesktopID(desktopID)).
+				Return(nil),
+			mockTree.EXPECT().
+				State().
+				Return(bspc.State{Monitors: []bspc.Monitor{monitor}}),
+			mockNodes.EXPECT().
+				Get(filter.NodeID(nodeID)).
+				Return(placed, nil),
+			mockNodes.EXPECT().
+				Move(nodeID, 300, 300).
+				Return(nil),
+			mockNodes.EXPECT().
+				SetVisibility(nodeID, true).
+				Return(nil),
+			mockNodes.EXPECT().
+				Focus(nodeID).
+				Return(nil),
+		)
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeAdd](bspc.EventNodeAdd{DesktopID: otherDesktopID, NodeID: nodeID}))
+
+		assert.Equal(t, scratchpad.State{
+			Name:      "term",
+			ClassName: definition.ClassName,
+			Command:   definition.Command,
+			NodeID:    nodeID,
+			IsVisible: true,
+		}, <-scratchpad.Topic("term").Subscribe(ctx, subscriptions))
+	})
+
+	t.Run("should show an existing window of the class, and hide it when toggled again", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			node = bspc.Node{
+				ID:     nodeID,
+				Hidden: true,
+				Client: &bspc.NodeClient{
+					ClassName: className,
+					State:     bspc.StateTypeTiled,
+				},
+			}
+			monitor        = bspc.Monitor{Desktops: []bspc.Desktop{{ID: desktopID}}}
+			placed         = node
+			focusedDesktop = bspc.Desktop{ID: desktopID}
+		)
+
+		// The window is centred in the monitor it's shown in.
+		monitor.Rectangle.Width = 1000
+		monitor.Rectangle.Height = 800
+		placed.Rectangle.Width = 400
+		placed.Rectangle.Height = 200
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+			launched         = []string{}
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockTree.EXPECT().
+			State().
+			Return(bspc.State{
+				Monitors: []bspc.Monitor{
+					{Desktops: []bspc.Desktop{{ID: otherDesktopID, Root: node}}},
+				},
+			})
+		gomock.InOrder(
+			mockTree.EXPECT().
+				FocusedDesktop().
+				Return(focusedDesktop, nil),
+			mockNodes.EXPECT().
+				SetState(nodeID, bspc.StateTypeFloating).
+				Return(nil),
+			mockNodes.EXPECT().
+				MoveToDesktop(nodeID, filter.DesktopID(desktopID)).
+				Return(nil),
+			mockTree.EXPECT().
+				State().
+				Return(bspc.State{Monitors: []bspc.Monitor{monitor}}),
+			mockNodes.EXPECT().
+				Get(filter.NodeID(nodeID)).
+				Return(placed, nil),
+			mockNodes.EXPECT().
+				Move(nodeID, 300, 300).
+				Return(nil),
+			mockNodes.EXPECT().
+				SetVisibility(nodeID, true).
+				Return(nil),
+			mockNodes.EXPECT().
+				Focus(nodeID).
+				Return(nil),
+		)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := scratchpad.StartWithLauncher(logger, mockService, mockTree, subscriptions, func(command string) error {
+			launched = append(launched, command)
+			return nil
+		})
+
+		require.NoError(t, feature.Toggle("term", scratchpad.Definition{ClassName: className}))
+		assert.Empty(t, launched)
+
+		mockTree.EXPECT().
+			Node(nodeID).
+			Return(node, nil)
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(focusedDesktop, nil)
+		mockNodes.EXPECT().
+			SetVisibility(nodeID, false).
+			Return(nil)
+
+		require.NoError(t, feature.Toggle("term", scratchpad.Definition{}))
+
+		assert.False(t, (<-scratchpad.Topic("term").Subscribe(ctx, subscriptions)).IsVisible)
+	})
+
+	t.Run("should forget the window once it's closed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			node = bspc.Node{
+				ID:     nodeID,
+				Hidden: true,
+				Client: &bspc.NodeClient{
+					ClassName: className,
+					State:     bspc.StateTypeTiled,
+				},
+			}
+			monitor = bspc.Monitor{Desktops: []bspc.Desktop{{ID: desktopID}}}
+			placed  = node
+		)
+
+		// The window is centred in the monitor it's shown in.
+		monitor.Rectangle.Width = 1000
+		monitor.Rectangle.Height = 800
+		placed.Rectangle.Width = 400
+		placed.Rectangle.Height = 200
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+			launched         = []string{}
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockTree.EXPECT().
+			State().
+			Return(bspc.State{
+				Monitors: []bspc.Monitor{
+					{Desktops: []bspc.Desktop{{ID: otherDesktopID, Root: node}}},
+				},
+			})
+		gomock.InOrder(
+			mockTree.EXPECT().
+				FocusedDesktop().
+				Return(bspc.Desktop{ID: desktopID}, nil),
+			mockNodes.EXPECT().
+				SetState(nodeID, bspc.StateTypeFloating).
+				Return(nil),
+			mockNodes.EXPECT().
+				MoveToDesktop(nodeID, filter.DesktopID(desktopID)).
+				Return(nil),
+			mockTree.EXPECT().
+				State().
+				Return(bspc.State{Monitors: []bspc.Monitor{monitor}}),
+			mockNodes.EXPECT().
+				Get(filter.NodeID(nodeID)).
+				Return(placed, nil),
+			mockNodes.EXPECT().
+				Move(nodeID, 300, 300).
+				Return(nil),
+			mockNodes.EXPECT().
+				SetVisibility(nodeID, true).
+				Return(nil),
+			mockNodes.EXPECT().
+				Focus(nodeID).
+				Return(nil),
+		)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := scratchpad.StartWithLauncher(logger, mockService, mockTree, subscriptions, func(command string) error {
+			launched = append(launched, command)
+			return nil
+		})
+
+		require.NoError(t, feature.Toggle("term", scratchpad.Definition{ClassName: className, Command: "alacritty"}))
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeRemove](bspc.EventNodeRemove{NodeID: nodeID}))
+		assert.Equal(t, bspc.NilID, (<-scratchpad.Topic("term").Subscribe(ctx, subscriptions)).NodeID)
+
+		mockTree.EXPECT().
+			State().
+			Return(bspc.State{})
+
+		require.NoError(t, feature.Toggle("term", scratchpad.Definition{}))
+		assert.Equal(t, []string{"alacritty"}, launched)
+	})
+
+	t.Run("should fail", func(t *testing.T) {
+		tt := []struct {
+			name        string
+			toggleName  string
+			definition  scratchpad.Definition
+			expectedErr error
+		}{
+			{
+				name:        "with an empty name",
+				toggleName:  "",
+				definition:  scratchpad.Definition{ClassName: className},
+				expectedErr: scratchpad.ErrInvalidName,
+			},
+			{
+				name:        "with a name that isn't a single topic segment",
+				toggleName:  "term/1",
+				definition:  scratchpad.Definition{ClassName: className},
+				expectedErr: scratchpad.ErrInvalidName,
+			},
+			{
+				name:        "without a definition",
+				toggleName:  "term",
+				expectedErr: scratchpad.ErrNoDefinition,
+			},
+		}
+
+		for _, tc := range tt {
+			t.Run(tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				var (
+					mockService      = bspwm.NewMockService(ctrl)
+					mockEventManager = bspwmevent.NewMockManager(ctrl)
+					mockNodes        = bspwmnode.NewMockService(ctrl)
+					mockTree         = bspwmtree.NewMockTree(ctrl)
+					subscriptions    = subscription.NewManager()
+					callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+				)
+
+				mockService.EXPECT().
+					Events().
+					Return(mockEventManager).
+					AnyTimes()
+				mockService.EXPECT().
+					Nodes().
+					Return(mockNodes).
+					AnyTimes()
+				mockEventManager.EXPECT().
+					On(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+						callbacks[eventType] = cb
+						return bspwmevent.Handle{}
+					}).
+					AnyTimes()
+
+				logger, err := log.New(zaptest.NewLogger(t), false)
+				require.NoError(t, err)
+
+				feature, _ := scratchpad.StartWithLauncher(logger, mockService, mockTree, subscriptions, func(string) error {
+					return nil
+				})
+
+				err = feature.Toggle(tc.toggleName, tc.definition)
+				require.Error(t, err)
+
+				assert.True(t, errors.Is(err, tc.expectedErr))
+			})
+		}
+	})
+
+	t.Run("should fail when there's no window and no command to launch one", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+			launched         = []string{}
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockTree.EXPECT().
+			State().
+			Return(bspc.State{})
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := scratchpad.StartWithLauncher(logger, mockService, mockTree, subscriptions, func(command string) error {
+			launched = append(launched, command)
+			return nil
+		})
+
+		assert.Error(t, feature.Toggle("term", scratchpad.Definition{ClassName: className}))
+		assert.Empty(t, launched)
+	})
+}
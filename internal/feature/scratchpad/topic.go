@@ -0,0 +1,27 @@
+package scratchpad
+
+import (
+	"github.com/diogox/bspc-go"
+
+	"github.com/diogox/bspm/internal/subscription"
+)
+
+// ChangedTopic is published, and retained, under each scratchpad's name (see Topic) whenever its state changes.
+// Subscribing to scratchpad/* gets the current state of every scratchpad straight away.
+const ChangedTopic subscription.Topic[State] = "state_changed"
+
+// State is the payload of a scratchpad's topic.
+type State struct {
+	Name      string
+	ClassName string
+	Command   string
+
+	// NodeID is bspc.NilID while the scratchpad has no window.
+	NodeID    bspc.ID
+	IsVisible bool
+}
+
+// Topic returns the topic of the scratchpad with the given name (e.g. scratchpad/term/state_changed).
+func Topic(name string) subscription.Topic[State] {
+	return ChangedTopic.Under("scratchpad", name)
+}
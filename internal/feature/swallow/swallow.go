@@ -0,0 +1,234 @@
+package swallow
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/diogox/bspc-go"
+	"go.uber.org/zap"
+
+	"github.com/diogox/bspm/internal/bspwm"
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	bspwmtree "github.com/diogox/bspm/internal/bspwm/tree"
+	"github.com/diogox/bspm/internal/log"
+	"github.com/diogox/bspm/internal/process"
+	"github.com/diogox/bspm/internal/x11"
+)
+
+// maxAncestors is how far up the process tree a terminal is looked for. Programs are usually launched through a
+// shell or two, so this is plenty, and it guards against cycles in a process tree that changed while it was read.
+const maxAncestors = 32
+
+type (
+	// Config holds the classes of the windows that take part in swallowing.
+	Config struct {
+		// SwallowClasses are the classes of the windows (usually terminals) that are swallowed by the windows
+		// of the programs they launch.
+		SwallowClasses []string
+
+		// NoSwallowClasses are the classes of the windows that never swallow the terminal they're launched from.
+		NoSwallowClasses []string
+	}
+
+	swallow struct {
+		logger    *log.Logger
+		service   bspwm.Service
+		tree      bspwmtree.Tree
+		windows   x11.Properties
+		processes process.Tree
+
+		swallowClasses   map[string]struct{}
+		noSwallowClasses map[string]struct{}
+
+		mutex sync.Mutex
+
+		// swallowed maps the windows that swallowed a terminal to the terminal they swallowed.
+		swallowed map[bspc.ID]bspc.ID
+	}
+)
+
+// Start hides the terminals that windows are launched from, and puts the windows in their place,
+// until the returned function is called. The terminals come back once the windows are closed.
+func Start(
+	logger *log.Logger,
+	service bspwm.Service,
+	tree bspwmtree.Tree,
+	windows x11.Properties,
+	processes process.Tree,
+	config Config,
+) func() {
+	s := &swallow{
+		logger:           logger,
+		service:          service,
+		tree:             tree,
+		windows:          windows,
+		processes:        processes,
+		swallowClasses:   toSet(config.SwallowClasses),
+		noSwallowClasses: toSet(config.NoSwallowClasses),
+		swallowed:        make(map[bspc.ID]bspc.ID),
+	}
+
+	handles := []bspwmevent.Handle{
+		bspwmevent.On(service.Events(), func(payload bspc.EventNodeAdd) error {
+			if err := s.handleNodeAdded(payload.NodeID); err != nil {
+				return fmt.Errorf("failed to swallow terminal: %w", err)
+			}
+
+			return nil
+		}),
+		bspwmevent.On(service.Events(), func(payload bspc.EventNodeRemove) error {
+			if err := s.handleNodeRemoved(payload.NodeID); err != nil {
+				return fmt.Errorf("failed to bring back swallowed terminal: %w", err)
+			}
+
+			return nil
+		}),
+	}
+
+	return func() {
+		for _, h := range handles {
+			service.Events().Off(h)
+		}
+	}
+}
+
+// handleNodeAdded swaps the window with the terminal it was launched from, if any, and hides the terminal.
+func (s *swallow) handleNodeAdded(nodeID bspc.ID) error {
+	node, err := s.tree.Node(nodeID)
+	if err != nil {
+		return fmt.Errorf("failed to get added node: %w", err)
+	}
+
+	if !s.canSwallow(node) {
+		return nil
+	}
+
+	pid, err := s.windows.PID(nodeID)
+	if err != nil {
+		// Without a pid, there's no way to tell where it was launched from.
+		s.logger.Info("not swallowing window without a pid",
+			zap.Uint("node_id", uint(nodeID)),
+			zap.Error(err),
+		)
+
+		return nil
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	terminalID, ok := s.findTerminal(pid)
+	if !ok {
+		return nil
+	}
+
+	if err := s.service.Nodes().Swap(nodeID, terminalID); err != nil {
+		return err
+	}
+
+	if err := s.service.Nodes().SetVisibility(terminalID, false); err != nil {
+		return err
+	}
+
+	s.swallowed[nodeID] = terminalID
+
+	return nil
+}
+
+// handleNodeRemoved brings back the terminal the window swallowed, if any.
+func (s *swallow) handleNodeRemoved(nodeID bspc.ID) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// The terminal might have been closed while swallowed.
+	for id, terminalID := range s.swallowed {
+		if terminalID == nodeID {
+			delete(s.swallowed, id)
+		}
+	}
+
+	terminalID, ok := s.swallowed[nodeID]
+	if !ok {
+		return nil
+	}
+
+	delete(s.swallowed, nodeID)
+
+	if err := s.service.Nodes().SetVisibility(terminalID, true); err != nil {
+		return err
+	}
+
+	return s.service.Nodes().Focus(terminalID)
+}
+
+// canSwallow returns true if the node is a tiled window, whose class isn't excluded from swallowing.
+// Terminals never swallow each other, so a terminal launched from another one doesn't hide it.
+func (s *swallow) canSwallow(node bspc.Node) bool {
+	if node.Client == nil || node.Client.State == bspc.StateTypeFloating {
+		return false
+	}
+
+	if _, ok := s.noSwallowClasses[node.Client.ClassName]; ok {
+		return false
+	}
+
+	_, isTerminal := s.swallowClasses[node.Client.ClassName]
+	return !isTerminal
+}
+
+// findTerminal returns the visible terminal that the process was launched from, by going up the process tree until
+// it finds the process of one of them.
+func (s *swallow) findTerminal(pid int) (bspc.ID, bool) {
+	terminals := s.terminalsByPID()
+	if len(terminals) == 0 {
+		return bspc.NilID, false
+	}
+
+	for i := 0; i < maxAncestors; i++ {
+		ppid, err := s.processes.Parent(pid)
+		if err != nil || ppid <= 1 {
+			return bspc.NilID, false
+		}
+
+		if terminalID, ok := terminals[ppid]; ok {
+			return terminalID, true
+		}
+
+		pid = ppid
+	}
+
+	return bspc.NilID, false
+}
+
+// terminalsByPID returns the visible windows of the swallow classes, by the id of their process.
+func (s *swallow) terminalsByPID() map[int]bspc.ID {
+	terminals := make(map[int]bspc.ID)
+
+	for _, m := range s.tree.State().Monitors {
+		for _, d := range m.Desktops {
+			for _, n := range d.Root.LeafNodes() {
+				if _, ok := s.swallowClasses[n.Client.ClassName]; !ok || n.Hidden {
+					continue
+				}
+
+				pid, err := s.windows.PID(n.ID)
+				if err != nil {
+					continue
+				}
+
+				terminals[pid] = n.ID
+			}
+		}
+	}
+
+	return terminals
+}
+
+func toSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+
+	return set
+}
@@ -0,0 +1,367 @@
+package swallow_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/diogox/bspc-go"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/diogox/bspm/internal/bspwm"
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	bspwmnode "github.com/diogox/bspm/internal/bspwm/node"
+	bspwmtree "github.com/diogox/bspm/internal/bspwm/tree"
+	"github.com/diogox/bspm/internal/feature/swallow"
+	"github.com/diogox/bspm/internal/log"
+	"github.com/diogox/bspm/internal/x11"
+)
+
+const (
+	terminalID  = bspc.ID(1)
+	childID     = bspc.ID(2)
+	terminalPID = 100
+	shellPID    = 101
+	childPID    = 102
+)
+
+// fakeProcessTree maps the pids of processes to the pids of their parents.
+type fakeProcessTree map[int]int
+
+func (f fakeProcessTree) Parent(pid int) (int, error) {
+	ppid, ok := f[pid]
+	if !ok {
+		return 0, errors.New("no such process")
+	}
+
+	return ppid, nil
+}
+
+func TestSwallow(t *testing.T) {
+	processes := fakeProcessTree{
+		childPID:    shellPID,
+		shellPID:    terminalPID,
+		terminalPID: 1,
+	}
+
+	t.Run("should swallow the terminal a window was launched from, and bring it back once it closes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			terminal = bspc.Node{
+				ID:     terminalID,
+				Client: &bspc.NodeClient{ClassName: "Alacritty", State: bspc.StateTypeTiled},
+			}
+			child = bspc.Node{
+				ID:     childID,
+				Client: &bspc.NodeClient{ClassName: "mpv", State: bspc.StateTypeTiled},
+			}
+			state = bspc.State{
+				Monitors: []bspc.Monitor{
+					{Desktops: []bspc.Desktop{{Root: bspc.Node{FirstChild: &terminal, SecondChild: &child}}}},
+				},
+			}
+		)
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockWindows      = x11.NewMockProperties(ctrl)
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		gomock.InOrder(
+			mockTree.EXPECT().
+				Node(childID).
+				Return(child, nil),
+			mockWindows.EXPECT().
+				PID(childID).
+				Return(childPID, nil),
+			mockTree.EXPECT().
+				State().
+				Return(state),
+			mockWindows.EXPECT().
+				PID(terminalID).
+				Return(terminalPID, nil),
+			mockNodes.EXPECT().
+				Swap(childID, terminalID).
+				Return(nil),
+			mockNodes.EXPECT().
+				SetVisibility(terminalID, false).
+				Return(nil),
+		)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		swallow.Start(logger, mockService, mockTree, mockWindows, processes, swallow.Config{
+			SwallowClasses:   []string{"Alacritty"},
+			NoSwallowClasses: []string{"Gimp"},
+		})
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeAdd](bspc.EventNodeAdd{NodeID: childID}))
+
+		gomock.InOrder(
+			mockNodes.EXPECT().
+				SetVisibility(terminalID, true).
+				Return(nil),
+			mockNodes.EXPECT().
+				Focus(terminalID).
+				Return(nil),
+		)
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeRemove](bspc.EventNodeRemove{NodeID: childID}))
+
+		// It's only brought back once.
+		require.NoError(t, callbacks[bspc.EventTypeNodeRemove](bspc.EventNodeRemove{NodeID: childID}))
+	})
+
+	t.Run("should not swallow", func(t *testing.T) {
+		tt := []struct {
+			name   string
+			child  bspc.Node
+			expect func(mockTree *bspwmtree.MockTree, mockWindows *x11.MockProperties)
+		}{
+			{
+				name: "for windows of the no-swallow classes",
+				child: bspc.Node{
+					ID:     childID,
+					Client: &bspc.NodeClient{ClassName: "Gimp", State: bspc.StateTypeTiled},
+				},
+			},
+			{
+				name: "for terminals launched from terminals",
+				child: bspc.Node{
+					ID:     childID,
+					Client: &bspc.NodeClient{ClassName: "Alacritty", State: bspc.StateTypeTiled},
+				},
+			},
+			{
+				name: "for floating windows",
+				child: bspc.Node{
+					ID:     childID,
+					Client: &bspc.NodeClient{ClassName: "mpv", State: bspc.StateTypeFloating},
+				},
+			},
+			{
+				name: "for windows without a pid",
+				child: bspc.Node{
+					ID:     childID,
+					Client: &bspc.NodeClient{ClassName: "mpv", State: bspc.StateTypeTiled},
+				},
+				expect: func(mockTree *bspwmtree.MockTree, mockWindows *x11.MockProperties) {
+					mockWindows.EXPECT().
+						PID(childID).
+						Return(0, x11.ErrPropertyNotFound)
+				},
+			},
+			{
+				name: "for windows not launched from a terminal",
+				child: bspc.Node{
+					ID:     childID,
+					Client: &bspc.NodeClient{ClassName: "mpv", State: bspc.StateTypeTiled},
+				},
+				expect: func(mockTree *bspwmtree.MockTree, mockWindows *x11.MockProperties) {
+					mockWindows.EXPECT().
+						PID(childID).
+						Return(childPID, nil)
+					mockTree.EXPECT().
+						State().
+						Return(bspc.State{
+							Monitors: []bspc.Monitor{
+								{Desktops: []bspc.Desktop{{Root: bspc.Node{
+									FirstChild: &bspc.Node{
+										ID:     terminalID,
+										Client: &bspc.NodeClient{ClassName: "Alacritty", State: bspc.StateTypeTiled},
+									},
+									SecondChild: &bspc.Node{
+										ID:     childID,
+										Client: &bspc.NodeClient{ClassName: "mpv", State: bspc.StateTypeTiled},
+									},
+								}}}},
+							},
+						})
+					mockWindows.EXPECT().
+						PID(terminalID).
+						Return(999, nil)
+				},
+			},
+			{
+				name: "when the terminal is hidden",
+				child: bspc.Node{
+					ID:     childID,
+					Client: &bspc.NodeClient{ClassName: "mpv", State: bspc.StateTypeTiled},
+				},
+				expect: func(mockTree *bspwmtree.MockTree, mockWindows *x11.MockProperties) {
+					mockWindows.EXPECT().
+						PID(childID).
+						Return(childPID, nil)
+					mockTree.EXPECT().
+						State().
+						Return(bspc.State{
+							Monitors: []bspc.Monitor{
+								{Desktops: []bspc.Desktop{{Root: bspc.Node{
+									FirstChild: &bspc.Node{
+										ID:     terminalID,
+										Hidden: true,
+										Client: &bspc.NodeClient{ClassName: "Alacritty", State: bspc.StateTypeTiled},
+									},
+									SecondChild: &bspc.Node{
+										ID:     childID,
+										Client: &bspc.NodeClient{ClassName: "mpv", State: bspc.StateTypeTiled},
+									},
+								}}}},
+							},
+						})
+				},
+			},
+		}
+
+		for _, tc := range tt {
+			t.Run(tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				var (
+					mockService      = bspwm.NewMockService(ctrl)
+					mockEventManager = bspwmevent.NewMockManager(ctrl)
+					mockNodes        = bspwmnode.NewMockService(ctrl)
+					mockTree         = bspwmtree.NewMockTree(ctrl)
+					mockWindows      = x11.NewMockProperties(ctrl)
+					callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+				)
+
+				mockService.EXPECT().
+					Events().
+					Return(mockEventManager).
+					AnyTimes()
+				mockService.EXPECT().
+					Nodes().
+					Return(mockNodes).
+					AnyTimes()
+				mockEventManager.EXPECT().
+					On(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+						callbacks[eventType] = cb
+						return bspwmevent.Handle{}
+					}).
+					AnyTimes()
+				mockTree.EXPECT().
+					Node(childID).
+					Return(tc.child, nil)
+
+				if tc.expect != nil {
+					tc.expect(mockTree, mockWindows)
+				}
+
+				logger, err := log.New(zaptest.NewLogger(t), false)
+				require.NoError(t, err)
+
+				swallow.Start(logger, mockService, mockTree, mockWindows, processes, swallow.Config{
+					SwallowClasses:   []string{"Alacritty"},
+					NoSwallowClasses: []string{"Gimp"},
+				})
+
+				require.NoError(t, callbacks[bspc.EventTypeNodeAdd](bspc.EventNodeAdd{NodeID: childID}))
+			})
+		}
+	})
+
+	t.Run("should forget the terminal if it's closed while swallowed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			terminal = bspc.Node{
+				ID:     terminalID,
+				Client: &bspc.NodeClient{ClassName: "Alacritty", State: bspc.StateTypeTiled},
+			}
+			child = bspc.Node{
+				ID:     childID,
+				Client: &bspc.NodeClient{ClassName: "mpv", State: bspc.StateTypeTiled},
+			}
+			state = bspc.State{
+				Monitors: []bspc.Monitor{
+					{Desktops: []bspc.Desktop{{Root: bspc.Node{FirstChild: &terminal, SecondChild: &child}}}},
+				},
+			}
+		)
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockWindows      = x11.NewMockProperties(ctrl)
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+				callbacks[eventType] = cb
+				return bspwmevent.Handle{}
+			}).
+			AnyTimes()
+		mockTree.EXPECT().
+			Node(childID).
+			Return(child, nil)
+		mockWindows.EXPECT().
+			PID(childID).
+			Return(childPID, nil)
+		mockTree.EXPECT().
+			State().
+			Return(state)
+		mockWindows.EXPECT().
+			PID(terminalID).
+			Return(terminalPID, nil)
+		mockNodes.EXPECT().
+			Swap(childID, terminalID).
+			Return(nil)
+		mockNodes.EXPECT().
+			SetVisibility(terminalID, false).
+			Return(nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		swallow.Start(logger, mockService, mockTree, mockWindows, processes, swallow.Config{
+			SwallowClasses:   []string{"Alacritty"},
+			NoSwallowClasses: []string{"Gimp"},
+		})
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeAdd](bspc.EventNodeAdd{NodeID: childID}))
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeRemove](bspc.EventNodeRemove{NodeID: terminalID}))
+
+		// The terminal is gone, so there's nothing to bring back.
+		require.NoError(t, callbacks[bspc.EventTypeNodeRemove](bspc.EventNodeRemove{NodeID: childID}))
+	})
+}
@@ -0,0 +1,500 @@
+//go:generate mockgen -package tabbed -destination ./tabbed_mock.go -self_package github.com/diogox/bspm/internal/feature/tabbed github.com/diogox/bspm/internal/feature/tabbed Feature
+
+package tabbed
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/diogox/bspc-go"
+	"go.uber.org/zap"
+
+	"github.com/diogox/bspm/internal/bspwm"
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	bspwmnode "github.com/diogox/bspm/internal/bspwm/node"
+	bspwmtree "github.com/diogox/bspm/internal/bspwm/tree"
+	"github.com/diogox/bspm/internal/log"
+	"github.com/diogox/bspm/internal/subscription"
+	"github.com/diogox/bspm/internal/x11"
+)
+
+var (
+	ErrNoFocusedNode = errors.New("no focused node")
+	ErrNothingToTab  = errors.New("the focused node has no windows next to it to tab")
+	ErrNotTabbed     = errors.New("node isn't in a tabbed container")
+)
+
+type (
+	// Feature turns the subtree around the focused window into a tabbed container, which only shows one of its
+	// windows (tabs) at a time, while the rest of the desktop stays tiled.
+	Feature interface {
+		// Toggle tabs the focused window's parent split, or brings back the windows of its container if it's
+		// already tabbed.
+		Toggle() error
+		FocusPreviousTab() error
+		FocusNextTab() error
+		// Select shows, and focuses, the tab in whichever container it's in.
+		Select(nodeID bspc.ID) error
+	}
+
+	container struct {
+		desktopID bspc.ID
+		tabs      []bspc.ID
+		selected  bspc.ID
+	}
+
+	feature struct {
+		logger        *log.Logger
+		service       bspwm.Service
+		tree          bspwmtree.Tree
+		windows       x11.Properties
+		subscriptions subscription.Manager
+
+		mutex      sync.Mutex
+		containers []*container
+	}
+)
+
+// Start keeps track of the tabbed containers until the returned function is called.
+// Windows opened from a container's selected tab join it, as a new tab. Tab titles are only resolved if windows is
+// not nil.
+func Start(
+	logger *log.Logger,
+	service bspwm.Service,
+	tree bspwmtree.Tree,
+	windows x11.Properties,
+	echoes *bspwmevent.Echoes,
+	subscriptions subscription.Manager,
+) (Feature, func()) {
+	f := &feature{
+		logger:        logger,
+		service:       service,
+		tree:          tree,
+		windows:       windows,
+		subscriptions: subscriptions,
+	}
+
+	handles := []bspwmevent.Handle{
+		bspwmevent.On(service.Events(), func(payload bspc.EventNodeAdd) error {
+			if err := f.handleNodeAdded(payload.DesktopID, payload.NodeID); err != nil {
+				return fmt.Errorf("failed to add node to tabbed container: %w", err)
+			}
+
+			return nil
+		}),
+		bspwmevent.On(service.Events(), func(payload bspc.EventNodeRemove) error {
+			if err := f.removeTab(payload.NodeID, false); err != nil {
+				return fmt.Errorf("failed to remove node from tabbed container: %w", err)
+			}
+
+			return nil
+		}),
+		bspwmevent.On(service.Events(), func(payload bspc.EventNodeTransfer) error {
+			if err := f.removeTab(payload.SourceNodeID, true); err != nil {
+				return fmt.Errorf("failed to remove moved node from tabbed container: %w", err)
+			}
+
+			return nil
+		}, bspwmevent.IgnoreEchoes(echoes)),
+		bspwmevent.On(service.Events(), func(payload bspc.EventDesktopRemove) error {
+			f.handleDesktopRemoved(payload.DesktopID)
+			return nil
+		}),
+	}
+
+	cancelFunc := func() {
+		for _, h := range handles {
+			service.Events().Off(h)
+		}
+	}
+
+	return f, cancelFunc
+}
+
+func (f *feature) Toggle() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	desktop, err := f.focusedDesktop()
+	if err != nil {
+		return err
+	}
+
+	if c, _ := f.containerOf(desktop.FocusedNodeID); c != nil {
+		return f.untab(c)
+	}
+
+	parent := parentOf(&desktop.Root, desktop.FocusedNodeID)
+	if parent == nil {
+		return ErrNothingToTab
+	}
+
+	tabs := f.tabsIn(*parent)
+	if len(tabs) < 2 {
+		return ErrNothingToTab
+	}
+
+	changes := make([]bspwmnode.Visibility, 0, len(tabs)-1)
+	for _, id := range tabs {
+		if id != desktop.FocusedNodeID {
+			changes = append(changes, bspwmnode.Visibility{NodeID: id, IsVisible: false})
+		}
+	}
+
+	if err := f.service.Nodes().SetVisibilities(changes...); err != nil {
+		return fmt.Errorf("failed to hide tabs: %w", err)
+	}
+
+	// The containers inside it are merged into it.
+	for _, id := range tabs {
+		if c, _ := f.containerOf(id); c != nil {
+			f.remove(c)
+		}
+	}
+
+	f.containers = append(f.containers, &container{
+		desktopID: desktop.ID,
+		tabs:      tabs,
+		selected:  desktop.FocusedNodeID,
+	})
+
+	f.publish(desktop.ID)
+
+	return nil
+}
+
+func (f *feature) FocusPreviousTab() error {
+	return f.cycle(-1)
+}
+
+func (f *feature) FocusNextTab() error {
+	return f.cycle(1)
+}
+
+func (f *feature) Select(nodeID bspc.ID) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	c, _ := f.containerOf(nodeID)
+	if c == nil {
+		return fmt.Errorf("%w: %d", ErrNotTabbed, nodeID)
+	}
+
+	return f.switchTo(c, nodeID)
+}
+
+// cycle switches the focused window's container to the tab the given number of places away from it, wrapping around.
+func (f *feature) cycle(offset int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	desktop, err := f.focusedDesktop()
+	if err != nil {
+		return err
+	}
+
+	c, _ := f.containerOf(desktop.FocusedNodeID)
+	if c == nil {
+		return fmt.Errorf("%w: %d", ErrNotTabbed, desktop.FocusedNodeID)
+	}
+
+	i := indexOf(c.tabs, c.selected)
+	next := c.tabs[((i+offset)%len(c.tabs)+len(c.tabs))%len(c.tabs)]
+
+	return f.switchTo(c, next)
+}
+
+// switchTo shows the tab instead of the selected one, and focuses it. It must be called with the mutex held.
+func (f *feature) switchTo(c *container, nodeID bspc.ID) error {
+	if nodeID != c.selected {
+		err := f.service.Nodes().SetVisibilities(
+			bspwmnode.Visibility{NodeID: nodeID, IsVisible: true},
+			bspwmnode.Visibility{NodeID: c.selected, IsVisible: false},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to switch tabs: %w", err)
+		}
+
+		c.selected = nodeID
+		f.publish(c.desktopID)
+	}
+
+	if err := f.service.Nodes().Focus(nodeID); err != nil {
+		return fmt.Errorf("failed to focus tab: %w", err)
+	}
+
+	return nil
+}
+
+// untab brings back the container's hidden tabs. It must be called with the mutex held.
+func (f *feature) untab(c *container) error {
+	changes := make([]bspwmnode.Visibility, 0, len(c.tabs)-1)
+	for _, id := range c.tabs {
+		if id != c.selected {
+			changes = append(changes, bspwmnode.Visibility{NodeID: id, IsVisible: true})
+		}
+	}
+
+	if err := f.service.Nodes().SetVisibilities(changes...); err != nil {
+		return fmt.Errorf("failed to show tabs: %w", err)
+	}
+
+	f.remove(c)
+	f.publish(c.desktopID)
+
+	return nil
+}
+
+// handleNodeAdded adds the node as a tab, next to the selected one, if that's where it was opened.
+func (f *feature) handleNodeAdded(desktopID bspc.ID, nodeID bspc.ID) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.containers) == 0 {
+		return nil
+	}
+
+	desktop, err := f.tree.Desktop(desktopID)
+	if err != nil {
+		return fmt.Errorf("failed to get desktop: %w", err)
+	}
+
+	parent := parentOf(&desktop.Root, nodeID)
+	if parent == nil {
+		return nil
+	}
+
+	node, sibling := *parent.FirstChild, *parent.SecondChild
+	if sibling.ID == nodeID {
+		node, sibling = sibling, node
+	}
+
+	c, i := f.containerOf(sibling.ID)
+	if c == nil || c.selected != sibling.ID || !isTab(node) {
+		return nil
+	}
+
+	if err := f.service.Nodes().SetVisibility(c.selected, false); err != nil {
+		return fmt.Errorf("failed to hide selected tab: %w", err)
+	}
+
+	c.tabs = append(c.tabs[:i+1], append([]bspc.ID{nodeID}, c.tabs[i+1:]...)...)
+	c.selected = nodeID
+	f.publish(c.desktopID)
+
+	return nil
+}
+
+// removeTab takes the node out of its container, if it's in one. The container is dissolved once it only has one tab
+// left. Tabs that are moved away are shown, since they'd have no way back otherwise.
+func (f *feature) removeTab(nodeID bspc.ID, isMovedAway bool) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	c, i := f.containerOf(nodeID)
+	if c == nil {
+		return nil
+	}
+
+	c.tabs = append(c.tabs[:i], c.tabs[i+1:]...)
+
+	var changes []bspwmnode.Visibility
+	if isMovedAway && nodeID != c.selected {
+		changes = append(changes, bspwmnode.Visibility{NodeID: nodeID, IsVisible: true})
+	}
+
+	if nodeID == c.selected {
+		if i == len(c.tabs) {
+			i--
+		}
+
+		c.selected = c.tabs[i]
+		changes = append(changes, bspwmnode.Visibility{NodeID: c.selected, IsVisible: true})
+	}
+
+	if len(c.tabs) < 2 {
+		f.remove(c)
+	}
+
+	f.publish(c.desktopID)
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	if err := f.service.Nodes().SetVisibilities(changes...); err != nil {
+		return fmt.Errorf("failed to show tabs: %w", err)
+	}
+
+	return nil
+}
+
+func (f *feature) handleDesktopRemoved(desktopID bspc.ID) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	var (
+		containers = f.containers[:0]
+		isRemoved  bool
+	)
+
+	for _, c := range f.containers {
+		if c.desktopID == desktopID {
+			isRemoved = true
+			continue
+		}
+
+		containers = append(containers, c)
+	}
+
+	f.containers = containers
+
+	if isRemoved {
+		f.publish(desktopID)
+	}
+}
+
+// tabsIn returns the windows in the subtree that can be tabbed: the tiled windows that are showing, along with every
+// tab of the containers inside it.
+func (f *feature) tabsIn(root bspc.Node) []bspc.ID {
+	var (
+		tabs []bspc.ID
+		seen = make(map[bspc.ID]bool)
+	)
+
+	for _, n := range root.LeafNodes() {
+		if seen[n.ID] {
+			continue
+		}
+
+		if c, _ := f.containerOf(n.ID); c != nil {
+			for _, id := range c.tabs {
+				seen[id] = true
+			}
+
+			tabs = append(tabs, c.tabs...)
+			continue
+		}
+
+		if isTab(n) && !n.Hidden {
+			tabs = append(tabs, n.ID)
+		}
+	}
+
+	return tabs
+}
+
+// containerOf returns the container the node is a tab of, and where it is in it, or nil if it isn't in any.
+func (f *feature) containerOf(nodeID bspc.ID) (*container, int) {
+	for _, c := range f.containers {
+		if i := indexOf(c.tabs, nodeID); i >= 0 {
+			return c, i
+		}
+	}
+
+	return nil, -1
+}
+
+func (f *feature) remove(c *container) {
+	for i, other := range f.containers {
+		if other == c {
+			f.containers = append(f.containers[:i], f.containers[i+1:]...)
+			return
+		}
+	}
+}
+
+// focusedDesktop returns the focused desktop, or ErrNoFocusedNode if there's no window to tab around.
+func (f *feature) focusedDesktop() (bspc.Desktop, error) {
+	desktop, err := f.tree.FocusedDesktop()
+	if err != nil {
+		return bspc.Desktop{}, fmt.Errorf("failed to get focused desktop: %w", err)
+	}
+
+	if desktop.FocusedNodeID == bspc.NilID {
+		return bspc.Desktop{}, ErrNoFocusedNode
+	}
+
+	return desktop, nil
+}
+
+// publish publishes the tabs of the desktop's containers. It must be called with the mutex held.
+func (f *feature) publish(desktopID bspc.ID) {
+	st := State{
+		DesktopID:  desktopID,
+		Containers: make([]Container, 0),
+	}
+
+	for _, c := range f.containers {
+		if c.desktopID != desktopID {
+			continue
+		}
+
+		tabs := make([]Tab, 0, len(c.tabs))
+		for _, id := range c.tabs {
+			tabs = append(tabs, f.describe(id, id == c.selected))
+		}
+
+		st.Containers = append(st.Containers, Container{Tabs: tabs})
+	}
+
+	Topic(desktopID).PublishRetained(f.subscriptions, st)
+}
+
+func (f *feature) describe(nodeID bspc.ID, isSelected bool) Tab {
+	tab := Tab{
+		NodeID:     nodeID,
+		IsSelected: isSelected,
+	}
+
+	if n, err := f.tree.Node(nodeID); err == nil && n.Client != nil {
+		tab.ClassName = n.Client.ClassName
+		tab.InstanceName = n.Client.InstanceName
+	}
+
+	if f.windows != nil {
+		title, err := f.windows.Title(nodeID)
+		if err != nil {
+			f.logger.Warning("failed to get window title",
+				zap.Uint("node_id", uint(nodeID)),
+				zap.Error(err),
+			)
+		}
+
+		tab.Title = title
+	}
+
+	return tab
+}
+
+// isTab returns true if the node is a window that takes up space in the tree.
+func isTab(n bspc.Node) bool {
+	return n.Client != nil && (n.Client.State == bspc.StateTypeTiled || n.Client.State == bspc.StateTypePseudoTiled)
+}
+
+func parentOf(n *bspc.Node, id bspc.ID) *bspc.Node {
+	if n == nil || n.FirstChild == nil || n.SecondChild == nil {
+		return nil
+	}
+
+	if n.FirstChild.ID == id || n.SecondChild.ID == id {
+		return n
+	}
+
+	if parent := parentOf(n.FirstChild, id); parent != nil {
+		return parent
+	}
+
+	return parentOf(n.SecondChild, id)
+}
+
+func indexOf(ids []bspc.ID, id bspc.ID) int {
+	for i, v := range ids {
+		if v == id {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,1070 @@
+package tabbed_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/diogox/bspc-go"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/diogox/bspm/internal/bspwm"
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	bspwmnode "github.com/diogox/bspm/internal/bspwm/node"
+	bspwmtree "github.com/diogox/bspm/internal/bspwm/tree"
+	"github.com/diogox/bspm/internal/feature/tabbed"
+	"github.com/diogox/bspm/internal/log"
+	"github.com/diogox/bspm/internal/subscription"
+	"github.com/diogox/bspm/internal/x11"
+)
+
+const desktopID = bspc.ID(100)
+
+// tabIDs returns the ids of the tabs in each container, with the selected ones marked by a negative id.
+func tabIDs(st tabbed.State) [][]int {
+	containers := make([][]int, 0, len(st.Containers))
+	for _, c := range st.Containers {
+		tabs := make([]int, 0, len(c.Tabs))
+		for _, tab := range c.Tabs {
+			id := int(tab.NodeID)
+			if tab.IsSelected {
+				id = -id
+			}
+
+			tabs = append(tabs, id)
+		}
+
+		containers = append(containers, tabs)
+	}
+
+	return containers
+}
+
+func TestTabbed(t *testing.T) {
+	// Window 1 on the left, and windows 2 and 3 on the right.
+	untabbed := &bspc.Node{
+		ID:         10,
+		SplitType:  bspc.SplitTypeVertical,
+		SplitRatio: 0.5,
+		FirstChild: &bspc.Node{ID: 1, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+		SecondChild: &bspc.Node{
+			ID:          11,
+			SplitType:   bspc.SplitTypeVertical,
+			SplitRatio:  0.5,
+			FirstChild:  &bspc.Node{ID: 2, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+			SecondChild: &bspc.Node{ID: 3, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+		},
+	}
+
+	// The same, with windows 2 and 3 tabbed and 2 selected.
+	tabbedRight := &bspc.Node{
+		ID:         10,
+		SplitType:  bspc.SplitTypeVertical,
+		SplitRatio: 0.5,
+		FirstChild: &bspc.Node{ID: 1, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+		SecondChild: &bspc.Node{
+			ID:          11,
+			SplitType:   bspc.SplitTypeVertical,
+			SplitRatio:  0.5,
+			FirstChild:  &bspc.Node{ID: 2, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+			SecondChild: &bspc.Node{ID: 3, Hidden: true, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+		},
+	}
+
+	t.Run("should hide every tab but the focused one", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockWindows      = x11.NewMockProperties(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+
+		capture := func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+			callbacks[eventType] = cb
+			return bspwmevent.Handle{}
+		}
+
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+
+		// Every window is a terminal, titled after its id.
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (bspc.Node, error) {
+				return bspc.Node{ID: id, Client: &bspc.NodeClient{ClassName: "Alacritty", InstanceName: "alacritty"}}, nil
+			}).
+			AnyTimes()
+		mockWindows.EXPECT().
+			Title(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (string, error) {
+				return fmt.Sprintf("window %d", id), nil
+			}).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := tabbed.Start(logger, mockService, mockTree, mockWindows, bspwmevent.NewEchoes(), subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 2, Root: *untabbed}, nil)
+		mockNodes.EXPECT().
+			SetVisibilities(bspwmnode.Visibility{NodeID: 3, IsVisible: false}).
+			Return(nil)
+
+		require.NoError(t, feature.Toggle())
+
+		assert.Equal(t, tabbed.State{
+			DesktopID: desktopID,
+			Containers: []tabbed.Container{{
+				Tabs: []tabbed.Tab{
+					{NodeID: 2, ClassName: "Alacritty", InstanceName: "alacritty", Title: "window 2", IsSelected: true},
+					{NodeID: 3, ClassName: "Alacritty", InstanceName: "alacritty", Title: "window 3"},
+				},
+			}},
+		}, <-tabbed.Topic(desktopID).Subscribe(ctx, subscriptions))
+	})
+	t.Run("should merge the containers inside the one being tabbed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockWindows      = x11.NewMockProperties(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+
+		capture := func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+			callbacks[eventType] = cb
+			return bspwmevent.Handle{}
+		}
+
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+
+		// Every window is a terminal, titled after its id.
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (bspc.Node, error) {
+				return bspc.Node{ID: id, Client: &bspc.NodeClient{ClassName: "Alacritty", InstanceName: "alacritty"}}, nil
+			}).
+			AnyTimes()
+		mockWindows.EXPECT().
+			Title(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (string, error) {
+				return fmt.Sprintf("window %d", id), nil
+			}).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := tabbed.Start(logger, mockService, mockTree, mockWindows, bspwmevent.NewEchoes(), subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 2, Root: *untabbed}, nil)
+		mockNodes.EXPECT().
+			SetVisibilities(bspwmnode.Visibility{NodeID: 3, IsVisible: false}).
+			Return(nil)
+
+		require.NoError(t, feature.Toggle())
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1, Root: *tabbedRight}, nil)
+		mockNodes.EXPECT().
+			SetVisibilities(bspwmnode.Visibility{NodeID: 2, IsVisible: false}).
+			Return(nil)
+
+		require.NoError(t, feature.Toggle())
+
+		assert.Equal(t, [][]int{{-1, 2, 3}}, tabIDs(<-tabbed.Topic(desktopID).Subscribe(ctx, subscriptions)))
+	})
+	t.Run("should bring the tabs back when toggled again", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockWindows      = x11.NewMockProperties(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+
+		capture := func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+			callbacks[eventType] = cb
+			return bspwmevent.Handle{}
+		}
+
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+
+		// Every window is a terminal, titled after its id.
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (bspc.Node, error) {
+				return bspc.Node{ID: id, Client: &bspc.NodeClient{ClassName: "Alacritty", InstanceName: "alacritty"}}, nil
+			}).
+			AnyTimes()
+		mockWindows.EXPECT().
+			Title(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (string, error) {
+				return fmt.Sprintf("window %d", id), nil
+			}).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := tabbed.Start(logger, mockService, mockTree, mockWindows, bspwmevent.NewEchoes(), subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 2, Root: *untabbed}, nil)
+		mockNodes.EXPECT().
+			SetVisibilities(bspwmnode.Visibility{NodeID: 3, IsVisible: false}).
+			Return(nil)
+
+		require.NoError(t, feature.Toggle())
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 2, Root: *tabbedRight}, nil)
+		mockNodes.EXPECT().
+			SetVisibilities(bspwmnode.Visibility{NodeID: 3, IsVisible: true}).
+			Return(nil)
+
+		require.NoError(t, feature.Toggle())
+
+		assert.Equal(t, [][]int{}, tabIDs(<-tabbed.Topic(desktopID).Subscribe(ctx, subscriptions)))
+	})
+	t.Run("should fail to tab a window on its own", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockWindows      = x11.NewMockProperties(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+
+		capture := func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+			callbacks[eventType] = cb
+			return bspwmevent.Handle{}
+		}
+
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+
+		// Every window is a terminal, titled after its id.
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (bspc.Node, error) {
+				return bspc.Node{ID: id, Client: &bspc.NodeClient{ClassName: "Alacritty", InstanceName: "alacritty"}}, nil
+			}).
+			AnyTimes()
+		mockWindows.EXPECT().
+			Title(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (string, error) {
+				return fmt.Sprintf("window %d", id), nil
+			}).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := tabbed.Start(logger, mockService, mockTree, mockWindows, bspwmevent.NewEchoes(), subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1, Root: bspc.Node{ID: 1, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}}}, nil)
+
+		err = feature.Toggle()
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, tabbed.ErrNothingToTab))
+	})
+	t.Run("should cycle through the tabs, wrapping around", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockWindows      = x11.NewMockProperties(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+
+		capture := func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+			callbacks[eventType] = cb
+			return bspwmevent.Handle{}
+		}
+
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+
+		// Every window is a terminal, titled after its id.
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (bspc.Node, error) {
+				return bspc.Node{ID: id, Client: &bspc.NodeClient{ClassName: "Alacritty", InstanceName: "alacritty"}}, nil
+			}).
+			AnyTimes()
+		mockWindows.EXPECT().
+			Title(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (string, error) {
+				return fmt.Sprintf("window %d", id), nil
+			}).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := tabbed.Start(logger, mockService, mockTree, mockWindows, bspwmevent.NewEchoes(), subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 2, Root: *untabbed}, nil)
+		mockNodes.EXPECT().
+			SetVisibilities(bspwmnode.Visibility{NodeID: 3, IsVisible: false}).
+			Return(nil)
+
+		require.NoError(t, feature.Toggle())
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 2, Root: *tabbedRight}, nil)
+		gomock.InOrder(
+			mockNodes.EXPECT().
+				SetVisibilities(bspwmnode.Visibility{NodeID: 3, IsVisible: true}, bspwmnode.Visibility{NodeID: 2, IsVisible: false}).
+				Return(nil),
+			mockNodes.EXPECT().
+				Focus(bspc.ID(3)).
+				Return(nil),
+		)
+
+		require.NoError(t, feature.FocusNextTab())
+		assert.Equal(t, [][]int{{2, -3}}, tabIDs(<-tabbed.Topic(desktopID).Subscribe(ctx, subscriptions)))
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 3, Root: bspc.Node{
+				ID:         10,
+				SplitType:  bspc.SplitTypeVertical,
+				SplitRatio: 0.5,
+				FirstChild: &bspc.Node{ID: 1, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+				SecondChild: &bspc.Node{
+					ID:          11,
+					SplitType:   bspc.SplitTypeVertical,
+					SplitRatio:  0.5,
+					FirstChild:  &bspc.Node{ID: 2, Hidden: true, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+					SecondChild: &bspc.Node{ID: 3, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+				},
+			}}, nil)
+		gomock.InOrder(
+			mockNodes.EXPECT().
+				SetVisibilities(bspwmnode.Visibility{NodeID: 2, IsVisible: true}, bspwmnode.Visibility{NodeID: 3, IsVisible: false}).
+				Return(nil),
+			mockNodes.EXPECT().
+				Focus(bspc.ID(2)).
+				Return(nil),
+		)
+
+		require.NoError(t, feature.FocusNextTab())
+		assert.Equal(t, [][]int{{-2, 3}}, tabIDs(<-tabbed.Topic(desktopID).Subscribe(ctx, subscriptions)))
+	})
+	t.Run("should fail to cycle outside of a container", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockWindows      = x11.NewMockProperties(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+
+		capture := func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+			callbacks[eventType] = cb
+			return bspwmevent.Handle{}
+		}
+
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+
+		// Every window is a terminal, titled after its id.
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (bspc.Node, error) {
+				return bspc.Node{ID: id, Client: &bspc.NodeClient{ClassName: "Alacritty", InstanceName: "alacritty"}}, nil
+			}).
+			AnyTimes()
+		mockWindows.EXPECT().
+			Title(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (string, error) {
+				return fmt.Sprintf("window %d", id), nil
+			}).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := tabbed.Start(logger, mockService, mockTree, mockWindows, bspwmevent.NewEchoes(), subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 2, Root: *untabbed}, nil)
+		mockNodes.EXPECT().
+			SetVisibilities(bspwmnode.Visibility{NodeID: 3, IsVisible: false}).
+			Return(nil)
+
+		require.NoError(t, feature.Toggle())
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1, Root: *tabbedRight}, nil)
+
+		err = feature.FocusPreviousTab()
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, tabbed.ErrNotTabbed))
+	})
+	t.Run("should select a tab", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockWindows      = x11.NewMockProperties(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+
+		capture := func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+			callbacks[eventType] = cb
+			return bspwmevent.Handle{}
+		}
+
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+
+		// Every window is a terminal, titled after its id.
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (bspc.Node, error) {
+				return bspc.Node{ID: id, Client: &bspc.NodeClient{ClassName: "Alacritty", InstanceName: "alacritty"}}, nil
+			}).
+			AnyTimes()
+		mockWindows.EXPECT().
+			Title(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (string, error) {
+				return fmt.Sprintf("window %d", id), nil
+			}).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := tabbed.Start(logger, mockService, mockTree, mockWindows, bspwmevent.NewEchoes(), subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 2, Root: *untabbed}, nil)
+		mockNodes.EXPECT().
+			SetVisibilities(bspwmnode.Visibility{NodeID: 3, IsVisible: false}).
+			Return(nil)
+
+		require.NoError(t, feature.Toggle())
+
+		mockNodes.EXPECT().
+			SetVisibilities(bspwmnode.Visibility{NodeID: 3, IsVisible: true}, bspwmnode.Visibility{NodeID: 2, IsVisible: false}).
+			Return(nil)
+		mockNodes.EXPECT().
+			Focus(bspc.ID(3)).
+			Return(nil)
+
+		require.NoError(t, feature.Select(3))
+
+		err = feature.Select(1)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, tabbed.ErrNotTabbed))
+	})
+	t.Run("should add windows opened from the selected tab", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockWindows      = x11.NewMockProperties(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+
+		capture := func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+			callbacks[eventType] = cb
+			return bspwmevent.Handle{}
+		}
+
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+
+		// Every window is a terminal, titled after its id.
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (bspc.Node, error) {
+				return bspc.Node{ID: id, Client: &bspc.NodeClient{ClassName: "Alacritty", InstanceName: "alacritty"}}, nil
+			}).
+			AnyTimes()
+		mockWindows.EXPECT().
+			Title(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (string, error) {
+				return fmt.Sprintf("window %d", id), nil
+			}).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := tabbed.Start(logger, mockService, mockTree, mockWindows, bspwmevent.NewEchoes(), subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 2, Root: *untabbed}, nil)
+		mockNodes.EXPECT().
+			SetVisibilities(bspwmnode.Visibility{NodeID: 3, IsVisible: false}).
+			Return(nil)
+
+		require.NoError(t, feature.Toggle())
+
+		mockTree.EXPECT().
+			Desktop(desktopID).
+			Return(bspc.Desktop{ID: desktopID, Root: bspc.Node{
+				ID:         10,
+				SplitType:  bspc.SplitTypeVertical,
+				SplitRatio: 0.5,
+				FirstChild: &bspc.Node{ID: 1, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+				SecondChild: &bspc.Node{
+					ID:         11,
+					SplitType:  bspc.SplitTypeVertical,
+					SplitRatio: 0.5,
+					FirstChild: &bspc.Node{
+						ID:          12,
+						SplitType:   bspc.SplitTypeVertical,
+						SplitRatio:  0.5,
+						FirstChild:  &bspc.Node{ID: 2, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+						SecondChild: &bspc.Node{ID: 4, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+					},
+					SecondChild: &bspc.Node{ID: 3, Hidden: true, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+				},
+			}}, nil)
+		mockNodes.EXPECT().
+			SetVisibility(bspc.ID(2), false).
+			Return(nil)
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeAdd](bspc.EventNodeAdd{DesktopID: desktopID, NodeID: 4}))
+
+		assert.Equal(t, [][]int{{2, -4, 3}}, tabIDs(<-tabbed.Topic(desktopID).Subscribe(ctx, subscriptions)))
+	})
+	t.Run("should leave windows opened elsewhere alone", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockWindows      = x11.NewMockProperties(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+
+		capture := func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+			callbacks[eventType] = cb
+			return bspwmevent.Handle{}
+		}
+
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+
+		// Every window is a terminal, titled after its id.
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (bspc.Node, error) {
+				return bspc.Node{ID: id, Client: &bspc.NodeClient{ClassName: "Alacritty", InstanceName: "alacritty"}}, nil
+			}).
+			AnyTimes()
+		mockWindows.EXPECT().
+			Title(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (string, error) {
+				return fmt.Sprintf("window %d", id), nil
+			}).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := tabbed.Start(logger, mockService, mockTree, mockWindows, bspwmevent.NewEchoes(), subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 2, Root: *untabbed}, nil)
+		mockNodes.EXPECT().
+			SetVisibilities(bspwmnode.Visibility{NodeID: 3, IsVisible: false}).
+			Return(nil)
+
+		require.NoError(t, feature.Toggle())
+
+		mockTree.EXPECT().
+			Desktop(desktopID).
+			Return(bspc.Desktop{ID: desktopID, Root: bspc.Node{
+				ID:         10,
+				SplitType:  bspc.SplitTypeVertical,
+				SplitRatio: 0.5,
+				FirstChild: &bspc.Node{
+					ID:          12,
+					SplitType:   bspc.SplitTypeVertical,
+					SplitRatio:  0.5,
+					FirstChild:  &bspc.Node{ID: 1, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+					SecondChild: &bspc.Node{ID: 4, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+				},
+				SecondChild: &bspc.Node{
+					ID:          11,
+					SplitType:   bspc.SplitTypeVertical,
+					SplitRatio:  0.5,
+					FirstChild:  &bspc.Node{ID: 2, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+					SecondChild: &bspc.Node{ID: 3, Hidden: true, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+				},
+			}}, nil)
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeAdd](bspc.EventNodeAdd{DesktopID: desktopID, NodeID: 4}))
+
+		assert.Equal(t, [][]int{{-2, 3}}, tabIDs(<-tabbed.Topic(desktopID).Subscribe(ctx, subscriptions)))
+	})
+	t.Run("should select a neighbour when the selected tab is closed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockWindows      = x11.NewMockProperties(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+
+		capture := func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+			callbacks[eventType] = cb
+			return bspwmevent.Handle{}
+		}
+
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+
+		// Every window is a terminal, titled after its id.
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (bspc.Node, error) {
+				return bspc.Node{ID: id, Client: &bspc.NodeClient{ClassName: "Alacritty", InstanceName: "alacritty"}}, nil
+			}).
+			AnyTimes()
+		mockWindows.EXPECT().
+			Title(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (string, error) {
+				return fmt.Sprintf("window %d", id), nil
+			}).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := tabbed.Start(logger, mockService, mockTree, mockWindows, bspwmevent.NewEchoes(), subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 1, Root: bspc.Node{
+				ID:         10,
+				SplitType:  bspc.SplitTypeVertical,
+				SplitRatio: 0.5,
+				FirstChild: &bspc.Node{ID: 1, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+				SecondChild: &bspc.Node{
+					ID:          11,
+					SplitType:   bspc.SplitTypeVertical,
+					SplitRatio:  0.5,
+					FirstChild:  &bspc.Node{ID: 2, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+					SecondChild: &bspc.Node{ID: 3, Client: &bspc.NodeClient{State: bspc.StateTypeTiled}},
+				},
+			}}, nil)
+		mockNodes.EXPECT().
+			SetVisibilities(bspwmnode.Visibility{NodeID: 2, IsVisible: false}, bspwmnode.Visibility{NodeID: 3, IsVisible: false}).
+			Return(nil)
+
+		require.NoError(t, feature.Toggle())
+
+		mockNodes.EXPECT().
+			SetVisibilities(bspwmnode.Visibility{NodeID: 2, IsVisible: true}).
+			Return(nil)
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeRemove](bspc.EventNodeRemove{DesktopID: desktopID, NodeID: 1}))
+
+		assert.Equal(t, [][]int{{-2, 3}}, tabIDs(<-tabbed.Topic(desktopID).Subscribe(ctx, subscriptions)))
+	})
+	t.Run("should dissolve the container once it has a single tab left", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockWindows      = x11.NewMockProperties(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+
+		capture := func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+			callbacks[eventType] = cb
+			return bspwmevent.Handle{}
+		}
+
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+
+		// Every window is a terminal, titled after its id.
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (bspc.Node, error) {
+				return bspc.Node{ID: id, Client: &bspc.NodeClient{ClassName: "Alacritty", InstanceName: "alacritty"}}, nil
+			}).
+			AnyTimes()
+		mockWindows.EXPECT().
+			Title(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (string, error) {
+				return fmt.Sprintf("window %d", id), nil
+			}).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := tabbed.Start(logger, mockService, mockTree, mockWindows, bspwmevent.NewEchoes(), subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 2, Root: *untabbed}, nil)
+		mockNodes.EXPECT().
+			SetVisibilities(bspwmnode.Visibility{NodeID: 3, IsVisible: false}).
+			Return(nil)
+
+		require.NoError(t, feature.Toggle())
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeRemove](bspc.EventNodeRemove{DesktopID: desktopID, NodeID: 3}))
+
+		assert.Equal(t, [][]int{}, tabIDs(<-tabbed.Topic(desktopID).Subscribe(ctx, subscriptions)))
+	})
+	t.Run("should show hidden tabs that are moved away", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			mockWindows      = x11.NewMockProperties(ctrl)
+			subscriptions    = subscription.NewManager()
+			callbacks        = make(map[bspc.EventType]bspwmevent.Callback)
+		)
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+
+		capture := func(eventType bspc.EventType, cb bspwmevent.Callback, _ ...bspwmevent.Middleware) bspwmevent.Handle {
+			callbacks[eventType] = cb
+			return bspwmevent.Handle{}
+		}
+
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(capture).
+			AnyTimes()
+
+		// Every window is a terminal, titled after its id.
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (bspc.Node, error) {
+				return bspc.Node{ID: id, Client: &bspc.NodeClient{ClassName: "Alacritty", InstanceName: "alacritty"}}, nil
+			}).
+			AnyTimes()
+		mockWindows.EXPECT().
+			Title(gomock.Any()).
+			DoAndReturn(func(id bspc.ID) (string, error) {
+				return fmt.Sprintf("window %d", id), nil
+			}).
+			AnyTimes()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		feature, _ := tabbed.Start(logger, mockService, mockTree, mockWindows, bspwmevent.NewEchoes(), subscriptions)
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID, FocusedNodeID: 2, Root: *untabbed}, nil)
+		mockNodes.EXPECT().
+			SetVisibilities(bspwmnode.Visibility{NodeID: 3, IsVisible: false}).
+			Return(nil)
+
+		require.NoError(t, feature.Toggle())
+
+		mockNodes.EXPECT().
+			SetVisibilities(bspwmnode.Visibility{NodeID: 3, IsVisible: true}).
+			Return(nil)
+
+		require.NoError(t, callbacks[bspc.EventTypeNodeTransfer](bspc.EventNodeTransfer{
+			SourceDesktopID:      desktopID,
+			SourceNodeID:         3,
+			DestinationDesktopID: 200,
+		}))
+
+		assert.Equal(t, [][]int{}, tabIDs(<-tabbed.Topic(desktopID).Subscribe(ctx, subscriptions)))
+	})
+}
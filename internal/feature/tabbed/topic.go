@@ -0,0 +1,44 @@
+package tabbed
+
+import (
+	"fmt"
+
+	"github.com/diogox/bspc-go"
+
+	"github.com/diogox/bspm/internal/subscription"
+)
+
+// ChangedTopic is published, and retained, under each desktop's id (see Topic) whenever its tabbed containers change.
+// Subscribing to tabbed/* gets the tabs of every desktop straight away.
+const ChangedTopic subscription.Topic[State] = "changed"
+
+// AnyTopic matches the tabbed topics of every desktop.
+var AnyTopic = ChangedTopic.Under("tabbed", subscription.Wildcard)
+
+type (
+	// State is the payload of a desktop's tabbed topic.
+	State struct {
+		DesktopID  bspc.ID
+		Containers []Container
+	}
+
+	// Container is a part of the desktop that shows one of its windows (tabs) at a time.
+	Container struct {
+		Tabs []Tab
+	}
+
+	Tab struct {
+		NodeID       bspc.ID
+		ClassName    string
+		InstanceName string
+
+		// Title is empty if the window's properties can't be read.
+		Title      string
+		IsSelected bool
+	}
+)
+
+// Topic returns the tabbed topic of the desktop with the given id (e.g. tabbed/4194306/changed).
+func Topic(desktopID bspc.ID) subscription.Topic[State] {
+	return ChangedTopic.Under("tabbed", fmt.Sprintf("%d", desktopID))
+}
@@ -0,0 +1,75 @@
+package transparentmonocle
+
+import (
+	"errors"
+
+	"github.com/diogox/bspc-go"
+
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+)
+
+var errActorStopped = errors.New("transparent monocle feature stopped")
+
+type (
+	// actor runs the commands it's given one at a time, in the order they're given. Every change to the
+	// desktops' state goes through it, so commands reading and then writing the state (e.g. cycling nodes
+	// while one is being added) can't interleave.
+	actor struct {
+		commands chan command
+		done     chan struct{}
+	}
+
+	command struct {
+		run   func() error
+		errCh chan error
+	}
+)
+
+// startActor starts running commands, until the returned function is called.
+func startActor() (actor, func()) {
+	a := actor{
+		commands: make(chan command),
+		done:     make(chan struct{}),
+	}
+
+	go func() {
+		for {
+			select {
+			case <-a.done:
+				return
+			case cmd := <-a.commands:
+				cmd.errCh <- cmd.run()
+			}
+		}
+	}()
+
+	return a, func() { close(a.done) }
+}
+
+// do runs the command once the ones before it are done, and returns its error.
+// It must not be called from within a command, since that command would be waiting on itself.
+func (a actor) do(run func() error) error {
+	cmd := command{
+		run:   run,
+		errCh: make(chan error, 1),
+	}
+
+	select {
+	case a.commands <- cmd:
+	case <-a.done:
+		return errActorStopped
+	}
+
+	return <-cmd.errCh
+}
+
+// middleware runs event callbacks as commands.
+func (a actor) middleware() bspwmevent.Middleware {
+	return func(_ bspc.EventType, next bspwmevent.Callback) bspwmevent.Callback {
+		return func(eventPayload interface{}) error {
+			return a.do(func() error {
+				return next(eventPayload)
+			})
+		}
+	}
+}
@@ -7,13 +7,13 @@ import (
 
 	"github.com/diogox/bspc-go"
 
-	"github.com/diogox/bspm/internal/feature/transparent_monocle/topic"
 	"github.com/diogox/bspm/internal/subscription"
 )
 
 type (
 	Manager interface {
 		Get(desktopID bspc.ID) (State, bool)
+		DesktopIDs() []bspc.ID
 		Set(desktopID bspc.ID, st State)
 		Delete(desktopID bspc.ID)
 	}
@@ -23,6 +23,12 @@ type (
 		HiddenNodeIDs  []bspc.ID
 	}
 
+	// Event is the payload published to the monocle topics, whenever a desktop's state changes.
+	Event struct {
+		DesktopID bspc.ID
+		State     State
+	}
+
 	manager struct {
 		rwMutex       *sync.RWMutex
 		subscriptions subscription.Manager
@@ -38,12 +44,31 @@ func NewTransparentMonocle(subscriptions subscription.Manager) manager {
 	}
 }
 
+// Get returns the desktop's state, and false if it's not in monocle mode. The state is a copy, so changing it has
+// no effect until it's Set.
 func (m manager) Get(desktopID bspc.ID) (State, bool) {
 	m.rwMutex.RLock()
 	defer m.rwMutex.RUnlock()
 
 	st, ok := m.desktops[desktopID]
-	return st, ok
+	if !ok {
+		return State{}, false
+	}
+
+	return st.copy(), true
+}
+
+// DesktopIDs returns the ids of the desktops with monocle mode enabled.
+func (m manager) DesktopIDs() []bspc.ID {
+	m.rwMutex.RLock()
+	defer m.rwMutex.RUnlock()
+
+	ids := make([]bspc.ID, 0, len(m.desktops))
+	for id := range m.desktops {
+		ids = append(ids, id)
+	}
+
+	return ids
 }
 
 func (m manager) Set(desktopID bspc.ID, st State) {
@@ -52,12 +77,12 @@ func (m manager) Set(desktopID bspc.ID, st State) {
 
 	if _, ok := m.desktops[desktopID]; !ok {
 		m.desktops[desktopID] = st
-		m.subscriptions.Publish(topic.MonocleEnabled, st)
+		m.publish(EnabledTopic, Event{DesktopID: desktopID, State: st})
 		return
 	}
 
 	m.desktops[desktopID] = st
-	m.subscriptions.Publish(topic.MonocleStateChanged, st)
+	m.publish(ChangedTopic, Event{DesktopID: desktopID, State: st})
 }
 
 func (m manager) Delete(desktopID bspc.ID) {
@@ -67,5 +92,29 @@ func (m manager) Delete(desktopID bspc.ID) {
 	prevState := m.desktops[desktopID]
 
 	delete(m.desktops, desktopID)
-	m.subscriptions.Publish(topic.MonocleDisabled, prevState)
+	m.publish(DisabledTopic, Event{DesktopID: desktopID, State: prevState})
+}
+
+func (st State) copy() State {
+	var selectedNodeID *bspc.ID
+	if st.SelectedNodeID != nil {
+		id := *st.SelectedNodeID
+		selectedNodeID = &id
+	}
+
+	var hiddenNodeIDs []bspc.ID
+	if st.HiddenNodeIDs != nil {
+		hiddenNodeIDs = append(make([]bspc.ID, 0, len(st.HiddenNodeIDs)), st.HiddenNodeIDs...)
+	}
+
+	return State{
+		SelectedNodeID: selectedNodeID,
+		HiddenNodeIDs:  hiddenNodeIDs,
+	}
+}
+
+// publish publishes the event both to the topic and to its desktop's topic.
+func (m manager) publish(t subscription.Topic[Event], ev Event) {
+	t.Publish(m.subscriptions, ev)
+	DesktopTopic(ev.DesktopID, t).Publish(m.subscriptions, ev)
 }
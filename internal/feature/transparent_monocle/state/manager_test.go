@@ -9,7 +9,6 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/diogox/bspm/internal/feature/transparent_monocle/state"
-	"github.com/diogox/bspm/internal/feature/transparent_monocle/topic"
 	"github.com/diogox/bspm/internal/subscription"
 )
 
@@ -55,7 +54,8 @@ func TestTransparentMonocle_Set(t *testing.T) {
 			)
 
 			mockSubscriptions := subscription.NewMockManager(ctrl)
-			mockSubscriptions.EXPECT().Publish(topic.MonocleEnabled, st)
+			mockSubscriptions.EXPECT().Publish(state.EnabledTopic.Name(), state.Event{DesktopID: desktopID, State: st})
+			mockSubscriptions.EXPECT().Publish(state.DesktopTopic(desktopID, state.EnabledTopic).Name(), state.Event{DesktopID: desktopID, State: st})
 
 			state.NewTransparentMonocle(mockSubscriptions).WithState(initial).Set(desktopID, st)
 
@@ -79,7 +79,8 @@ func TestTransparentMonocle_Set(t *testing.T) {
 			)
 
 			mockSubscriptions := subscription.NewMockManager(ctrl)
-			mockSubscriptions.EXPECT().Publish(topic.MonocleStateChanged, st)
+			mockSubscriptions.EXPECT().Publish(state.ChangedTopic.Name(), state.Event{DesktopID: desktopID, State: st})
+			mockSubscriptions.EXPECT().Publish(state.DesktopTopic(desktopID, state.ChangedTopic).Name(), state.Event{DesktopID: desktopID, State: st})
 
 			state.NewTransparentMonocle(mockSubscriptions).WithState(initial).Set(desktopID, st)
 
@@ -107,8 +108,16 @@ func TestTransparentMonocle_Delete(t *testing.T) {
 		)
 
 		mockSubscriptions := subscription.NewMockManager(ctrl)
-		mockSubscriptions.EXPECT().Publish(topic.MonocleDisabled, st)
+		mockSubscriptions.EXPECT().Publish(state.DisabledTopic.Name(), state.Event{DesktopID: desktopID, State: st})
+		mockSubscriptions.EXPECT().Publish(state.DesktopTopic(desktopID, state.DisabledTopic).Name(), state.Event{DesktopID: desktopID, State: st})
 
 		state.NewTransparentMonocle(mockSubscriptions).WithState(initial).Delete(desktopID)
 	})
 }
+
+func TestDesktopTopic(t *testing.T) {
+	t.Run("should scope topic to desktop", func(t *testing.T) {
+		got := state.DesktopTopic(bspc.ID(0x00200002), state.EnabledTopic)
+		assert.Equal(t, subscription.Name("desktop/0x00200002/monocle/enabled"), got.Name())
+	})
+}
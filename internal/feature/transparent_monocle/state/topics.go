@@ -0,0 +1,23 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/diogox/bspc-go"
+
+	"github.com/diogox/bspm/internal/subscription"
+)
+
+// Topics are declared here, instead of in the topic package, since their payload is defined in this package.
+// Each event is also published to its desktop's topic (see DesktopTopic).
+const (
+	EnabledTopic  subscription.Topic[Event] = "monocle/enabled"
+	DisabledTopic subscription.Topic[Event] = "monocle/disabled"
+	ChangedTopic  subscription.Topic[Event] = "monocle/state_changed"
+)
+
+// DesktopTopic returns the topic scoped to a single desktop (e.g. desktop/0x00200002/monocle/enabled),
+// so that everything about a desktop can be subscribed to with desktop/<id>/*.
+func DesktopTopic[T any](desktopID bspc.ID, t subscription.Topic[T]) subscription.Topic[T] {
+	return t.Under("desktop", fmt.Sprintf("0x%08X", uint(desktopID)))
+}
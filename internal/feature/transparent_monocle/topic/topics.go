@@ -1,10 +1,17 @@
 package topic
 
-import "github.com/diogox/bspm/internal/subscription"
+import (
+	"github.com/diogox/bspc-go"
 
+	"github.com/diogox/bspm/internal/subscription"
+)
+
+// The monocle state topics are declared in the state package, alongside their payload.
 const (
-	MonocleEnabled             subscription.Topic = "monocle_enabled"
-	MonocleDisabled            subscription.Topic = "monocle_disabled"
-	MonocleStateChanged        subscription.Topic = "monocle_state_changed"
-	MonocleDesktopFocusChanged subscription.Topic = "monocle_focused_desktop_changed"
+	// MonocleDesktopFocusChanged is published, and retained, whenever the focused desktop changes.
+	MonocleDesktopFocusChanged subscription.Topic[bspc.EventDesktopFocus] = "monocle/focused_desktop_changed"
+
+	// MonocleNodeCount is the number of nodes in the focused desktop's monocle mode, or -1 if it's disabled.
+	// It's retained, so subscribers get the current count straight away.
+	MonocleNodeCount subscription.Topic[int] = "monocle/node_count"
 )
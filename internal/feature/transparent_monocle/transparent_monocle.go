@@ -4,6 +4,7 @@
 package transparentmonocle
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -14,7 +15,10 @@ import (
 	"github.com/diogox/bspm/internal/subscription"
 
 	"github.com/diogox/bspm/internal/bspwm"
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
 	"github.com/diogox/bspm/internal/bspwm/filter"
+	bspwmnode "github.com/diogox/bspm/internal/bspwm/node"
+	bspwmtree "github.com/diogox/bspm/internal/bspwm/tree"
 
 	"github.com/diogox/bspm/internal/feature/transparent_monocle/state"
 	"github.com/diogox/bspm/internal/log"
@@ -25,68 +29,55 @@ type (
 		ToggleCurrentDesktop() error
 		FocusPreviousHiddenNode() error
 		FocusNextHiddenNode() error
-		SubscribeNodeCount() chan int
+		// FocusNode focuses the node, along with its desktop. If it's hidden in a monocle stack, it's made the stack's
+		// selected node first, showing it instead of the node that was selected. bspwm won't focus nodes hidden by
+		// anything else, so ErrNodeHidden is returned for them.
+		FocusNode(nodeID bspc.ID) error
+		SubscribeNodeCount(ctx context.Context) chan int
 	}
 
 	transparentMonocle struct {
 		logger        *log.Logger
 		service       bspwm.Service
+		tree          bspwmtree.Tree
 		desktops      state.Manager
 		subscriptions subscription.Manager
+		actor         actor
 	}
 )
 
-var ErrFeatureNotEnabled = errors.New("feature not enabled in current desktop")
+var (
+	ErrFeatureNotEnabled = errors.New("feature not enabled in current desktop")
+	ErrNodeHidden        = errors.New("node is hidden")
+)
 
 func Start(
 	logger *log.Logger,
 	desktops state.Manager,
 	service bspwm.Service,
+	tree bspwmtree.Tree,
 	subscriptions subscription.Manager,
 ) (Feature, func(), error) {
-	service.Events().On(bspc.EventTypeNodeAdd, func(eventPayload interface{}) error {
-		payload, ok := eventPayload.(bspc.EventNodeAdd)
-		if !ok {
-			return errors.New("invalid event payload")
-		}
-
-		if err := handleNodeAdded(logger, service, desktops, payload.DesktopID, payload.NodeID); err != nil {
-			logger.Error("failed to handle added node",
-				zap.Uint("desktop_id", uint(payload.DesktopID)),
-				zap.Error(err),
-			)
+	act, stopActor := startActor()
 
-			return err
+	bspwmevent.On(service.Events(), func(payload bspc.EventNodeAdd) error {
+		if err := handleNodeAdded(logger, service, tree, desktops, payload.DesktopID, payload.NodeID); err != nil {
+			return fmt.Errorf("failed to handle added node: %w", err)
 		}
 
 		return nil
-	})
+	}, act.middleware(), bspwmevent.DesktopFilter(isMonocled(desktops)))
 
-	service.Events().On(bspc.EventTypeNodeRemove, func(eventPayload interface{}) error {
-		payload, ok := eventPayload.(bspc.EventNodeRemove)
-		if !ok {
-			return errors.New("invalid event payload")
-		}
-
-		err := handleNodeRemoved(logger, service, desktops, payload.DesktopID, payload.NodeID)
-		if err != nil {
-			logger.Error("failed to handle removed node",
-				zap.Uint("desktop_id", uint(payload.DesktopID)),
-				zap.Error(err),
-			)
-
-			return err
+	bspwmevent.On(service.Events(), func(payload bspc.EventNodeRemove) error {
+		if err := handleNodeRemoved(logger, service, desktops, payload.DesktopID, payload.NodeID); err != nil {
+			return fmt.Errorf("failed to handle removed node: %w", err)
 		}
 
 		return nil
-	})
+	}, act.middleware(), bspwmevent.DesktopFilter(isMonocled(desktops)))
 
-	service.Events().On(bspc.EventTypeNodeTransfer, func(eventPayload interface{}) error {
+	bspwmevent.On(service.Events(), func(payload bspc.EventNodeTransfer) error {
 		// TODO: Add unit tests for this event
-		payload, ok := eventPayload.(bspc.EventNodeTransfer)
-		if !ok {
-			return errors.New("invalid event payload")
-		}
 
 		// The source node id is the id of the node being transferred.
 		// It's unclear what the destination node id is.
@@ -96,239 +87,394 @@ func Start(
 		transferredNodeID := payload.SourceNodeID
 
 		if err := handleNodeRemoved(logger, service, desktops, payload.SourceDesktopID, transferredNodeID); err != nil {
-			logger.Error("failed to handle node transfers at source",
-				zap.Uint("desktop_id", uint(payload.SourceDesktopID)),
-				zap.Error(err),
-			)
-
-			return err
+			return fmt.Errorf("failed to handle node transfer at source: %w", err)
 		}
 
-		if err := handleNodeAdded(logger, service, desktops, payload.DestinationDesktopID, transferredNodeID); err != nil {
-			logger.Error("failed to handle node transfer at destination",
-				zap.Uint("desktop_id", uint(payload.SourceDesktopID)),
-				zap.Error(err),
-			)
-
-			return err
+		if err := handleNodeAdded(logger, service, tree, desktops, payload.DestinationDesktopID, transferredNodeID); err != nil {
+			return fmt.Errorf("failed to handle node transfer at destination: %w", err)
 		}
 
 		return nil
-	})
+	}, act.middleware(), bspwmevent.DesktopFilter(isMonocled(desktops)))
 
-	service.Events().On(bspc.EventTypeNodeSwap, func(eventPayload interface{}) error {
-		// TODO: this one is HUGE. Need to move this to a private method, or a function.
+	bspwmevent.On(service.Events(), func(payload bspc.EventNodeSwap) error {
 		// TODO: Add unit tests for this event
+		return handleNodeSwap(logger, service, tree, desktops, payload)
+	}, act.middleware(), bspwmevent.DesktopFilter(isMonocled(desktops)))
 
-		payload, ok := eventPayload.(bspc.EventNodeSwap)
-		if !ok {
-			return errors.New("invalid event payload")
-		}
+	// Needed to trigger subscriptions when changing monocle mode instances (between desktops).
+	bspwmevent.On(service.Events(), func(payload bspc.EventDesktopFocus) error {
+		topic.MonocleDesktopFocusChanged.PublishRetained(subscriptions, payload)
+		return nil
+	})
 
-		if payload.SourceDesktopID == payload.DestinationDesktopID {
-			// TODO: Is this even possible?
-			// It's not going to affect this mode. Move on.
+	bspwmevent.On(service.Events(), func(payload bspc.EventNodeState) error {
+		if payload.State != bspc.StateTypeFloating {
+			// Ignore state change
 			return nil
 		}
 
-		// TODO: Use this strategy in the other log instances.
-		loggerOpts := []zap.Field{
-			zap.Uint("source_desktop_id", uint(payload.SourceDesktopID)),
-			zap.Uint("destination_desktop_id", uint(payload.DestinationDesktopID)),
-			zap.Uint("source_node_id", uint(payload.SourceNodeID)),
-			zap.Uint("destination_node_id", uint(payload.DestinationNodeID)),
-		}
-
-		var isSourceDesktopMonocled bool
-		if _, ok := desktops.Get(payload.SourceDesktopID); ok {
-			isSourceDesktopMonocled = true
-		}
+		switch payload.WasEnabled {
+		case true:
+			if err := handleNodeRemoved(logger, service, desktops, payload.DesktopID, payload.NodeID); err != nil {
+				return fmt.Errorf("failed to handle removing floating node: %w", err)
+			}
 
-		var isDestinationDesktopMonocled bool
-		if _, ok := desktops.Get(payload.DestinationDesktopID); ok {
-			isDestinationDesktopMonocled = true
+		case false:
+			if err := handleNodeAdded(logger, service, tree, desktops, payload.DesktopID, payload.NodeID); err != nil {
+				return fmt.Errorf("failed to handle adding un-floated node: %w", err)
+			}
 		}
 
-		if !isSourceDesktopMonocled && !isDestinationDesktopMonocled {
-			// None of them are in monocle mode. Ignore.
-			return nil
-		}
+		return nil
+	}, act.middleware(), bspwmevent.DesktopFilter(isMonocled(desktops)))
 
-		// TODO: This gets called in handleNodeAdded. Is there a way I can reuse this there?
-		sourceNode, err := service.Nodes().Get(filter.NodeID(payload.SourceNodeID))
+	// Events might have been missed while the subscription was broken.
+	service.Events().OnReconnect(func() error {
+		err := act.do(func() error {
+			return reconcile(logger, service, tree, desktops)
+		})
 		if err != nil {
-			logger.Error("failed to get source node info", append(loggerOpts, zap.Error(err))...)
+			logger.Error("failed to reconcile transparent monocle state", zap.Error(err))
 			return err
 		}
 
-		destinationNode, err := service.Nodes().Get(filter.NodeID(payload.DestinationNodeID))
-		if err != nil {
-			logger.Error("failed to get destination node info", append(loggerOpts, zap.Error(err))...)
-			return err
-		}
+		return nil
+	})
 
-		var (
-			sourceNodes      = sourceNode.LeafNodes()
-			destinationNodes = destinationNode.LeafNodes()
-		)
+	ctx, cancelNodeCount := context.WithCancel(context.Background())
+	publishNodeCount(ctx, logger, tree, desktops, subscriptions)
 
-		for _, n := range sourceNodes {
-			// We can't add hidden nodes to a desktop
-			if n.Hidden {
-				if err := service.Nodes().SetVisibility(n.ID, true); err != nil {
-					logger.Error("failed to show hidden node being swapped",
-						append(loggerOpts, zap.Error(err))...,
-					)
-					// TODO: At this point, the mode might be crashed. How to handle this gracefully?
-					//  Same for other errors below. Saga pattern won't help here, I think.
-					return err
-				}
+	cancelEvents, err := service.Events().Start()
+	if err != nil {
+		cancelNodeCount()
+		stopActor()
+		return nil, nil, fmt.Errorf("failed to start event manager")
+	}
+
+	cancelFunc := func() {
+		cancelNodeCount()
+		cancelEvents()
+		stopActor()
+	}
+
+	return &transparentMonocle{
+		logger:        logger,
+		service:       service,
+		tree:          tree,
+		desktops:      desktops,
+		subscriptions: subscriptions,
+		actor:         act,
+	}, cancelFunc, nil
+}
+
+// publishNodeCount keeps the node count topic up to date with the focused desktop's state, until the context is done.
+func publishNodeCount(
+	ctx context.Context,
+	logger *log.Logger,
+	tree bspwmtree.Tree,
+	desktops state.Manager,
+	subscriptions subscription.Manager,
+) {
+	var (
+		changedCh      = state.ChangedTopic.Subscribe(ctx, subscriptions)
+		enabledCh      = state.EnabledTopic.Subscribe(ctx, subscriptions)
+		disabledCh     = state.DisabledTopic.Subscribe(ctx, subscriptions)
+		desktopFocusCh = topic.MonocleDesktopFocusChanged.Subscribe(ctx, subscriptions)
+		reconnectedCh  = bspwmevent.ReconnectedTopic.Subscribe(ctx, subscriptions)
+	)
+
+	go func() {
+		focusedDesktopID := bspc.NilID
+		getFocusedDesktop := func() {
+			focusedDesktop, err := tree.FocusedDesktop()
+			if err != nil {
+				logger.Error("failed to get focused desktop", zap.Error(err))
+				return
 			}
+
+			focusedDesktopID = focusedDesktop.ID
 		}
 
-		for _, n := range destinationNodes {
-			// We can't add hidden nodes to a desktop
-			if n.Hidden {
-				if err := service.Nodes().SetVisibility(n.ID, true); err != nil {
-					logger.Error("failed to show hidden node being swapped",
-						append(loggerOpts, zap.Error(err))...,
-					)
+		getFocusedDesktop()
 
-					return err
+		publish := func() {
+			count := -1 // Mode is disabled
+			if st, ok := desktops.Get(focusedDesktopID); ok {
+				count = len(st.HiddenNodeIDs)
+				if st.SelectedNodeID != nil {
+					count++
 				}
 			}
-		}
 
-		st, err := service.State()
-		if err != nil {
-			logger.Error("failed to retrieve bspwm's current state",
-				append(loggerOpts, zap.Error(err))...,
-			)
+			topic.MonocleNodeCount.PublishRetained(subscriptions, count)
 		}
 
-		for _, n := range sourceNodes {
-			if err := handleNodeRemoved(logger, service, desktops, payload.SourceDesktopID, n.ID); err != nil {
-				logger.Error("failed to handle node swap (across desktops) source node removal at source desktop",
-					append(loggerOpts, zap.Error(err))...,
-				)
+		// Publish current number of nodes
+		publish()
+
+		for {
+			// The state is read again, instead of taken from the event, since it might have changed in the meantime.
+			var desktopID bspc.ID
 
-				return err
+			select {
+			case <-ctx.Done():
+				return
+			case ev := <-changedCh:
+				desktopID = ev.DesktopID
+			case ev := <-enabledCh:
+				desktopID = ev.DesktopID
+			case ev := <-disabledCh:
+				desktopID = ev.DesktopID
+			case ev := <-desktopFocusCh:
+				focusedDesktopID = ev.DesktopID
+				desktopID = ev.DesktopID
+			case <-reconnectedCh:
+				// Focus might have changed while disconnected.
+				getFocusedDesktop()
+				desktopID = focusedDesktopID
+			}
+
+			if ctx.Err() != nil {
+				// Subscriptions are closed once the context is done.
+				return
 			}
-		}
-		for _, n := range destinationNodes {
-			if err := handleNodeRemoved(logger, service, desktops, payload.DestinationDesktopID, n.ID); err != nil {
-				logger.Error("failed to handle node swap (across desktops) destination node added at destination desktop",
-					append(loggerOpts, zap.Error(err))...,
-				)
 
-				return err
+			if desktopID == focusedDesktopID {
+				publish()
 			}
 		}
+	}()
+}
 
-		if len(sourceNodes) == 1 {
-			focusedIndex, ok := findMostRecentlyFocusedNode(st.OrderedFocusHistory(), payload.SourceDesktopID, sourceNodes)
-			if ok {
-				focusedNode := sourceNodes[focusedIndex]
+// reconcile brings the state of every desktop in monocle mode up to date with bspwm's.
+func reconcile(logger *log.Logger, service bspwm.Service, tree bspwmtree.Tree, desktops state.Manager) error {
+	bspwmState, err := service.State()
+	if err != nil {
+		return err
+	}
 
-				// Move node to focus to the end of the slice so it gets called last. (giving it focus)
-				sourceNodes = append(sourceNodes[:focusedIndex], sourceNodes[focusedIndex+1:]...)
-				sourceNodes = append(sourceNodes, focusedNode)
-			}
+	existing := make(map[bspc.ID]bspc.Desktop)
+	for _, monitor := range bspwmState.Monitors {
+		for _, desktop := range monitor.Desktops {
+			existing[desktop.ID] = desktop
 		}
+	}
 
-		if len(destinationNodes) == 1 {
-			focusedIndex, ok := findMostRecentlyFocusedNode(st.OrderedFocusHistory(), payload.DestinationDesktopID, destinationNodes)
-			if ok {
-				focusedNode := destinationNodes[focusedIndex]
+	for _, desktopID := range desktops.DesktopIDs() {
+		desktop, ok := existing[desktopID]
+		if !ok {
+			desktops.Delete(desktopID)
+			continue
+		}
 
-				// Move node to focus to the end of the slice so it gets called last. (giving it focus)
-				destinationNodes = append(destinationNodes[:focusedIndex], destinationNodes[focusedIndex+1:]...)
-				destinationNodes = append(destinationNodes, focusedNode)
-			}
+		if err := reconcileDesktop(logger, service, tree, desktops, desktop); err != nil {
+			return fmt.Errorf("failed to reconcile desktop %d: %w", desktopID, err)
 		}
+	}
 
-		for _, n := range sourceNodes {
-			if err := handleNodeAdded(logger, service, desktops, payload.DestinationDesktopID, n.ID); err != nil {
-				logger.Error("failed to handle node swap (across desktops) source node added at destination desktop",
-					append(loggerOpts, zap.Error(err))...,
-				)
+	return nil
+}
 
-				return err
-			}
+func reconcileDesktop(
+	logger *log.Logger,
+	service bspwm.Service,
+	tree bspwmtree.Tree,
+	desktops state.Manager,
+	desktop bspc.Desktop,
+) error {
+	st, ok := desktops.Get(desktop.ID)
+	if !ok {
+		return nil
+	}
+
+	tiledNodes := make(map[bspc.ID]bspc.Node)
+	for _, n := range desktop.Root.LeafNodes() {
+		if n.Client.State != bspc.StateTypeFloating {
+			tiledNodes[n.ID] = n
 		}
-		for _, n := range destinationNodes {
-			if err := handleNodeAdded(logger, service, desktops, payload.SourceDesktopID, n.ID); err != nil {
-				logger.Error("failed to handle node swap (across desktops) destination node added at source desktop",
-					append(loggerOpts, zap.Error(err))...,
-				)
+	}
 
-				return err
-			}
+	knownNodeIDs := make(map[bspc.ID]struct{}, len(st.HiddenNodeIDs)+1)
+	for _, id := range st.HiddenNodeIDs {
+		knownNodeIDs[id] = struct{}{}
+	}
+
+	if st.SelectedNodeID != nil {
+		knownNodeIDs[*st.SelectedNodeID] = struct{}{}
+	}
+
+	for id := range knownNodeIDs {
+		if _, ok := tiledNodes[id]; ok {
+			continue
 		}
 
-		return nil
-	})
+		if err := handleNodeRemoved(logger, service, desktops, desktop.ID, id); err != nil {
+			return err
+		}
+	}
 
-	// TODO: I should extract these callback definitions to where they make the most sense.
-	// Needed to trigger subscriptions when changing monocle mode instances (between desktops).
-	service.Events().On(bspc.EventTypeDesktopFocus, func(eventPayload interface{}) error {
-		subscriptions.Publish(topic.MonocleDesktopFocusChanged, nil)
-		return nil
-	})
+	// The focused node is added last, so that it ends up selected.
+	var addedNodeIDs []bspc.ID
+	for _, n := range desktop.Root.LeafNodes() {
+		_, isKnown := knownNodeIDs[n.ID]
+		_, isTiled := tiledNodes[n.ID]
 
-	service.Events().On(bspc.EventTypeNodeState, func(eventPayload interface{}) error {
-		payload, ok := eventPayload.(bspc.EventNodeState)
-		if !ok {
-			return errors.New("invalid event payload")
+		if isKnown || !isTiled || n.ID == desktop.FocusedNodeID {
+			continue
 		}
 
-		if payload.State != bspc.StateTypeFloating {
-			// Ignore state change
-			return nil
+		addedNodeIDs = append(addedNodeIDs, n.ID)
+	}
+
+	if _, isKnown := knownNodeIDs[desktop.FocusedNodeID]; !isKnown {
+		if _, isTiled := tiledNodes[desktop.FocusedNodeID]; isTiled {
+			addedNodeIDs = append(addedNodeIDs, desktop.FocusedNodeID)
 		}
+	}
 
-		if _, ok := desktops.Get(payload.DesktopID); !ok {
-			return nil
+	for _, id := range addedNodeIDs {
+		if err := handleNodeAdded(logger, service, tree, desktops, desktop.ID, id); err != nil {
+			return err
 		}
+	}
 
-		switch payload.WasEnabled {
-		case true:
-			err := handleNodeRemoved(logger, service, desktops, payload.DesktopID, payload.NodeID)
-			if err != nil {
-				logger.Error("failed to handle removing floating node",
-					zap.Uint("desktop_id", uint(payload.DesktopID)),
-					zap.Error(err),
-				)
+	// Make sure only the selected node is visible.
+	st, _ = desktops.Get(desktop.ID)
 
-				return err
-			}
+	var changes []bspwmnode.Visibility
+	for _, id := range st.HiddenNodeIDs {
+		if n, ok := tiledNodes[id]; ok && !n.Hidden {
+			changes = append(changes, bspwmnode.Visibility{NodeID: id, IsVisible: false})
+		}
+	}
 
-		case false:
-			err := handleNodeAdded(logger, service, desktops, payload.DesktopID, payload.NodeID)
-			if err != nil {
-				logger.Error("failed to handle adding un-floated node",
-					zap.Uint("desktop_id", uint(payload.DesktopID)),
-					zap.Error(err),
-				)
+	if st.SelectedNodeID != nil {
+		if n, ok := tiledNodes[*st.SelectedNodeID]; ok && n.Hidden {
+			changes = append(changes, bspwmnode.Visibility{NodeID: n.ID, IsVisible: true})
+		}
+	}
 
-				return err
-			}
+	if len(changes) != 0 {
+		if err := service.Nodes().SetVisibilities(changes...); err != nil {
+			return fmt.Errorf("failed to fix node visibility: %w", err)
 		}
+	}
+
+	return nil
+}
+
+// isMonocled returns a function that tells whether a desktop is in monocle mode, to filter out events that don't affect it.
+func isMonocled(desktops state.Manager) func(desktopID bspc.ID) bool {
+	return func(desktopID bspc.ID) bool {
+		_, ok := desktops.Get(desktopID)
+		return ok
+	}
+}
 
+// handleNodeSwap moves the nodes swapped across desktops, at least one of them in monocle mode, from one desktop's
+// state to the other's.
+func handleNodeSwap(
+	logger *log.Logger,
+	service bspwm.Service,
+	tree bspwmtree.Tree,
+	desktops state.Manager,
+	payload bspc.EventNodeSwap,
+) error {
+	if payload.SourceDesktopID == payload.DestinationDesktopID {
+		// TODO: Is this even possible?
+		// It's not going to affect this mode. Move on.
 		return nil
-	})
+	}
 
-	cancelFunc, err := service.Events().Start()
+	// TODO: This gets called in handleNodeAdded. Is there a way I can reuse this there?
+	sourceNode, err := tree.Node(payload.SourceNodeID)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to start event manager")
+		return fmt.Errorf("failed to get source node info: %w", err)
 	}
 
-	return &transparentMonocle{
-		logger:        logger,
-		service:       service,
-		desktops:      desktops,
-		subscriptions: subscriptions,
-	}, cancelFunc, nil
+	destinationNode, err := tree.Node(payload.DestinationNodeID)
+	if err != nil {
+		return fmt.Errorf("failed to get destination node info: %w", err)
+	}
+
+	var (
+		sourceNodes      = sourceNode.LeafNodes()
+		destinationNodes = destinationNode.LeafNodes()
+	)
+
+	// We can't add hidden nodes to a desktop
+	var shown []bspwmnode.Visibility
+	for _, nodes := range [][]bspc.Node{sourceNodes, destinationNodes} {
+		for _, n := range nodes {
+			if n.Hidden {
+				shown = append(shown, bspwmnode.Visibility{NodeID: n.ID, IsVisible: true})
+			}
+		}
+	}
+
+	if len(shown) != 0 {
+		if err := service.Nodes().SetVisibilities(shown...); err != nil {
+			// TODO: At this point, the mode might be crashed. How to handle this gracefully?
+			//  Same for other errors below. Saga pattern won't help here, I think.
+			return fmt.Errorf("failed to show hidden nodes being swapped: %w", err)
+		}
+	}
+
+	st, err := service.State()
+	if err != nil {
+		logger.Error("failed to retrieve bspwm's current state",
+			zap.Uint("source_desktop_id", uint(payload.SourceDesktopID)),
+			zap.Uint("destination_desktop_id", uint(payload.DestinationDesktopID)),
+			zap.Error(err),
+		)
+	}
+
+	for _, n := range sourceNodes {
+		if err := handleNodeRemoved(logger, service, desktops, payload.SourceDesktopID, n.ID); err != nil {
+			return fmt.Errorf("failed to handle node swap (across desktops) source node removal at source desktop: %w", err)
+		}
+	}
+	for _, n := range destinationNodes {
+		if err := handleNodeRemoved(logger, service, desktops, payload.DestinationDesktopID, n.ID); err != nil {
+			return fmt.Errorf("failed to handle node swap (across desktops) destination node removal at destination desktop: %w", err)
+		}
+	}
+
+	if len(sourceNodes) == 1 {
+		focusedIndex, ok := findMostRecentlyFocusedNode(st.OrderedFocusHistory(), payload.SourceDesktopID, sourceNodes)
+		if ok {
+			focusedNode := sourceNodes[focusedIndex]
+
+			// Move node to focus to the end of the slice so it gets called last. (giving it focus)
+			sourceNodes = append(sourceNodes[:focusedIndex], sourceNodes[focusedIndex+1:]...)
+			sourceNodes = append(sourceNodes, focusedNode)
+		}
+	}
+
+	if len(destinationNodes) == 1 {
+		focusedIndex, ok := findMostRecentlyFocusedNode(st.OrderedFocusHistory(), payload.DestinationDesktopID, destinationNodes)
+		if ok {
+			focusedNode := destinationNodes[focusedIndex]
+
+			// Move node to focus to the end of the slice so it gets called last. (giving it focus)
+			destinationNodes = append(destinationNodes[:focusedIndex], destinationNodes[focusedIndex+1:]...)
+			destinationNodes = append(destinationNodes, focusedNode)
+		}
+	}
+
+	for _, n := range sourceNodes {
+		if err := handleNodeAdded(logger, service, tree, desktops, payload.DestinationDesktopID, n.ID); err != nil {
+			return fmt.Errorf("failed to handle node swap (across desktops) source node added at destination desktop: %w", err)
+		}
+	}
+	for _, n := range destinationNodes {
+		if err := handleNodeAdded(logger, service, tree, desktops, payload.SourceDesktopID, n.ID); err != nil {
+			return fmt.Errorf("failed to handle node swap (across desktops) destination node added at source desktop: %w", err)
+		}
+	}
+
+	return nil
 }
 
 func handleNodeRemoved(
@@ -400,11 +546,12 @@ func handleNodeRemoved(
 func handleNodeAdded(
 	logger *log.Logger,
 	service bspwm.Service,
+	tree bspwmtree.Tree,
 	desktops state.Manager,
 	desktopID bspc.ID,
 	nodeID bspc.ID,
 ) error {
-	addedNode, err := service.Nodes().Get(filter.NodeID(nodeID))
+	addedNode, err := tree.Node(nodeID)
 	if err != nil {
 		return fmt.Errorf("failed to get added node: %w", err)
 	}
@@ -442,7 +589,11 @@ func handleNodeAdded(
 }
 
 func (tm transparentMonocle) ToggleCurrentDesktop() error {
-	desktop, err := tm.service.Desktops().Get(filter.DesktopFocused)
+	return tm.actor.do(tm.toggleCurrentDesktop)
+}
+
+func (tm transparentMonocle) toggleCurrentDesktop() error {
+	desktop, err := tm.tree.FocusedDesktop()
 	if err != nil {
 		return fmt.Errorf("failed to get current desktop: %w", err)
 	}
@@ -484,6 +635,7 @@ func (tm transparentMonocle) enableMode(desktop bspc.Desktop) error {
 			selectedNodeID = &biggestNode.ID
 		}
 
+		var hidden []bspwmnode.Visibility
 		for id, n := range leafNodes {
 			if id == *selectedNodeID {
 				continue
@@ -493,12 +645,15 @@ func (tm transparentMonocle) enableMode(desktop bspc.Desktop) error {
 				continue
 			}
 
-			if err := tm.service.Nodes().SetVisibility(id, false); err != nil {
-				return fmt.Errorf("failed to hide node: %w", err)
-			}
-
+			hidden = append(hidden, bspwmnode.Visibility{NodeID: id, IsVisible: false})
 			hiddenNodeIDs = append(hiddenNodeIDs, id)
 		}
+
+		if len(hidden) != 0 {
+			if err := tm.service.Nodes().SetVisibilities(hidden...); err != nil {
+				return fmt.Errorf("failed to hide nodes: %w", err)
+			}
+		}
 	}
 
 	tm.desktops.Set(desktop.ID, state.State{
@@ -510,9 +665,14 @@ func (tm transparentMonocle) enableMode(desktop bspc.Desktop) error {
 }
 
 func (tm transparentMonocle) disableMode(st state.State) error {
-	for _, n := range st.HiddenNodeIDs {
-		if err := tm.service.Nodes().SetVisibility(n, true); err != nil {
-			return fmt.Errorf("failed to show node: %w", err)
+	shown := make([]bspwmnode.Visibility, 0, len(st.HiddenNodeIDs))
+	for _, id := range st.HiddenNodeIDs {
+		shown = append(shown, bspwmnode.Visibility{NodeID: id, IsVisible: true})
+	}
+
+	if len(shown) != 0 {
+		if err := tm.service.Nodes().SetVisibilities(shown...); err != nil {
+			return fmt.Errorf("failed to show nodes: %w", err)
 		}
 	}
 
@@ -524,7 +684,11 @@ func (tm transparentMonocle) disableMode(st state.State) error {
 }
 
 func (tm transparentMonocle) FocusPreviousHiddenNode() error {
-	desktop, err := tm.service.Desktops().Get(filter.DesktopFocused)
+	return tm.actor.do(tm.focusPreviousHiddenNode)
+}
+
+func (tm transparentMonocle) focusPreviousHiddenNode() error {
+	desktop, err := tm.tree.FocusedDesktop()
 	if err != nil {
 		return fmt.Errorf("failed to get current desktop state: %v", err)
 	}
@@ -540,12 +704,8 @@ func (tm transparentMonocle) FocusPreviousHiddenNode() error {
 	}
 
 	nextNodeID := st.HiddenNodeIDs[len(st.HiddenNodeIDs)-1]
-	if err := tm.service.Nodes().SetVisibility(nextNodeID, true); err != nil {
-		return fmt.Errorf("failed to un-hide %d node: %v", nextNodeID, err)
-	}
-
-	if err := tm.service.Nodes().SetVisibility(*st.SelectedNodeID, false); err != nil {
-		return fmt.Errorf("failed to hide %d node: %v", st.SelectedNodeID, err)
+	if err := tm.swapVisibleNode(*st.SelectedNodeID, nextNodeID); err != nil {
+		return err
 	}
 
 	tm.desktops.Set(desktop.ID, state.State{
@@ -557,7 +717,11 @@ func (tm transparentMonocle) FocusPreviousHiddenNode() error {
 }
 
 func (tm transparentMonocle) FocusNextHiddenNode() error {
-	desktop, err := tm.service.Desktops().Get(filter.DesktopFocused)
+	return tm.actor.do(tm.focusNextHiddenNode)
+}
+
+func (tm transparentMonocle) focusNextHiddenNode() error {
+	desktop, err := tm.tree.FocusedDesktop()
 	if err != nil {
 		return fmt.Errorf("failed to get current desktop state: %v", err)
 	}
@@ -573,12 +737,8 @@ func (tm transparentMonocle) FocusNextHiddenNode() error {
 	}
 
 	nextNodeID := st.HiddenNodeIDs[0]
-	if err := tm.service.Nodes().SetVisibility(nextNodeID, true); err != nil {
-		return fmt.Errorf("failed to show %d node: %v", nextNodeID, err)
-	}
-
-	if err := tm.service.Nodes().SetVisibility(*st.SelectedNodeID, false); err != nil {
-		return fmt.Errorf("failed to hide %d node: %v", st.SelectedNodeID, err)
+	if err := tm.swapVisibleNode(*st.SelectedNodeID, nextNodeID); err != nil {
+		return err
 	}
 
 	tm.desktops.Set(desktop.ID, state.State{
@@ -589,63 +749,97 @@ func (tm transparentMonocle) FocusNextHiddenNode() error {
 	return nil
 }
 
-func (tm transparentMonocle) SubscribeNodeCount() chan int {
-	var (
-		stateCh        = tm.subscriptions.Subscribe(topic.MonocleStateChanged)
-		enabledCh      = tm.subscriptions.Subscribe(topic.MonocleEnabled)
-		disabledCh     = tm.subscriptions.Subscribe(topic.MonocleDisabled)
-		desktopFocusCh = tm.subscriptions.Subscribe(topic.MonocleDesktopFocusChanged)
-	)
+func (tm transparentMonocle) FocusNode(nodeID bspc.ID) error {
+	return tm.actor.do(func() error {
+		return tm.focusNode(nodeID)
+	})
+}
 
-	var (
-		countCh               = make(chan int, 1)
-		publishCountFromState = func(st state.State) {
-			count := len(st.HiddenNodeIDs)
-			if st.SelectedNodeID != nil {
-				count++
-			}
+func (tm transparentMonocle) focusNode(nodeID bspc.ID) error {
+	isStacked, err := tm.selectNode(nodeID)
+	if err != nil {
+		return err
+	}
 
-			countCh <- count
-		}
-		getAndPublishCount = func() {
-			focusedDesktop, err := tm.service.Desktops().Get(filter.DesktopFocused)
-			if err == nil { // TODO: Log if there's an error?
-				currentState, ok := tm.desktops.Get(focusedDesktop.ID)
-				switch ok {
-				case true:
-					publishCountFromState(currentState)
-				case false:
-					// Mode is disabled
-					countCh <- -1
-				}
-			}
+	if !isStacked {
+		if n, err := tm.tree.Node(nodeID); err == nil && n.Hidden {
+			return fmt.Errorf("%w: %d", ErrNodeHidden, nodeID)
 		}
-	)
+	}
 
-	// Publish current number of nodes
-	getAndPublishCount()
+	// Focusing it also focuses its desktop, if it's on another one.
+	if err := tm.service.Nodes().Focus(nodeID); err != nil {
+		return fmt.Errorf("failed to focus node %d: %w", nodeID, err)
+	}
 
-	go func() {
-		for {
-			select {
-			case payload := <-stateCh:
-				updatedState := payload.(state.State)
-				publishCountFromState(updatedState)
+	return nil
+}
 
-			case payload := <-enabledCh:
-				updatedState := payload.(state.State)
-				publishCountFromState(updatedState)
+// selectNode makes the node the selected one of the monocle stack it's hidden in. It returns false if the node
+// isn't hidden in a stack, leaving it alone.
+func (tm transparentMonocle) selectNode(nodeID bspc.ID) (bool, error) {
+	for _, desktopID := range tm.desktops.DesktopIDs() {
+		st, _ := tm.desktops.Get(desktopID)
 
-			case <-desktopFocusCh:
-				getAndPublishCount()
+		i := indexOf(st.HiddenNodeIDs, nodeID)
+		if i < 0 {
+			continue
+		}
 
-			case <-disabledCh:
-				countCh <- -1
+		if st.SelectedNodeID == nil {
+			if err := tm.service.Nodes().SetVisibility(nodeID, true); err != nil {
+				return false, fmt.Errorf("failed to show node %d: %w", nodeID, err)
 			}
+
+			tm.desktops.Set(desktopID, state.State{
+				SelectedNodeID: &nodeID,
+				HiddenNodeIDs:  removeFromSlice(st.HiddenNodeIDs, nodeID),
+			})
+
+			return true, nil
 		}
-	}()
 
-	return countCh
+		if err := tm.swapVisibleNode(*st.SelectedNodeID, nodeID); err != nil {
+			return false, err
+		}
+
+		// The stack is rotated, rather than reordered, so cycling through it still goes through the nodes in the
+		// same order.
+		hiddenNodeIDs := make([]bspc.ID, 0, len(st.HiddenNodeIDs))
+		hiddenNodeIDs = append(hiddenNodeIDs, st.HiddenNodeIDs[i+1:]...)
+		hiddenNodeIDs = append(hiddenNodeIDs, *st.SelectedNodeID)
+		hiddenNodeIDs = append(hiddenNodeIDs, st.HiddenNodeIDs[:i]...)
+
+		tm.desktops.Set(desktopID, state.State{
+			SelectedNodeID: &nodeID,
+			HiddenNodeIDs:  hiddenNodeIDs,
+		})
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// swapVisibleNode shows the next node before hiding the current one, so the desktop is never left empty on screen.
+func (tm transparentMonocle) swapVisibleNode(currentNodeID, nextNodeID bspc.ID) error {
+	err := tm.service.Nodes().SetVisibilities(
+		bspwmnode.Visibility{NodeID: nextNodeID, IsVisible: true},
+		bspwmnode.Visibility{NodeID: currentNodeID, IsVisible: false},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to show node %d instead of node %d: %w", nextNodeID, currentNodeID, err)
+	}
+
+	return nil
+}
+
+// SubscribeNodeCount returns a channel with the number of nodes in the focused desktop's monocle mode,
+// every time it changes. It returns -1 when the mode is disabled.
+// The channel is closed, and the underlying subscription cancelled, when the context is done.
+func (tm transparentMonocle) SubscribeNodeCount(ctx context.Context) chan int {
+	// Only the latest count matters, so there's no point in queueing messages.
+	return topic.MonocleNodeCount.Subscribe(ctx, tm.subscriptions, subscription.WithPolicy(subscription.PolicyCoalesce))
 }
 
 func removeFromSlice(slice []bspc.ID, toRemove bspc.ID) []bspc.ID {
@@ -661,6 +855,16 @@ func removeFromSlice(slice []bspc.ID, toRemove bspc.ID) []bspc.ID {
 	return ss
 }
 
+func indexOf(slice []bspc.ID, id bspc.ID) int {
+	for i, v := range slice {
+		if v == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
 // findMostRecentlyFocusedNode returns the node from the provided slice that shows up first in the focused node history.
 func findMostRecentlyFocusedNode(focusHistory []bspc.StateFocusHistoryEntry, relevantDesktopID bspc.ID, nodes []bspc.Node) (int, bool) {
 	for _, prevFocusedNode := range focusHistory {
@@ -1,7 +1,10 @@
 package transparentmonocle_test
 
 import (
+	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/diogox/bspc-go"
 	"github.com/golang/mock/gomock"
@@ -10,9 +13,13 @@ import (
 	"go.uber.org/zap/zaptest"
 
 	"github.com/diogox/bspm/internal/bspwm"
+	bspwmdesktop "github.com/diogox/bspm/internal/bspwm/desktop"
 	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	bspwmnode "github.com/diogox/bspm/internal/bspwm/node"
+	bspwmtree "github.com/diogox/bspm/internal/bspwm/tree"
 	transparentmonocle "github.com/diogox/bspm/internal/feature/transparent_monocle"
 	"github.com/diogox/bspm/internal/feature/transparent_monocle/state"
+	"github.com/diogox/bspm/internal/feature/transparent_monocle/topic"
 	"github.com/diogox/bspm/internal/log"
 	"github.com/diogox/bspm/internal/subscription"
 )
@@ -29,32 +36,412 @@ func TestNewTransparentMonocle(t *testing.T) {
 			mockService       = bspwm.NewMockService(ctrl)
 			mockState         = state.NewMockManager(ctrl)
 			mockSubscriptions = subscription.NewMockManager(ctrl)
+			mockTree          = bspwmtree.NewMockTree(ctrl)
+			desktopID         = bspc.ID(1)
+			published         = make(chan struct{})
 		)
 
 		mockService.EXPECT().
 			Events().
 			Return(mockEventManager).
-			Times(7)
+			Times(8)
 		mockEventManager.EXPECT().
-			On(bspc.EventTypeNodeAdd, gomock.Any())
+			On(bspc.EventTypeNodeAdd, gomock.Any(), gomock.Any(), gomock.Any())
 		mockEventManager.EXPECT().
-			On(bspc.EventTypeNodeRemove, gomock.Any())
+			On(bspc.EventTypeNodeRemove, gomock.Any(), gomock.Any(), gomock.Any())
 		mockEventManager.EXPECT().
-			On(bspc.EventTypeNodeTransfer, gomock.Any())
+			On(bspc.EventTypeNodeTransfer, gomock.Any(), gomock.Any(), gomock.Any())
 		mockEventManager.EXPECT().
-			On(bspc.EventTypeNodeSwap, gomock.Any())
+			On(bspc.EventTypeNodeSwap, gomock.Any(), gomock.Any(), gomock.Any())
 		mockEventManager.EXPECT().
 			On(bspc.EventTypeDesktopFocus, gomock.Any())
 		mockEventManager.EXPECT().
-			On(bspc.EventTypeNodeState, gomock.Any())
+			On(bspc.EventTypeNodeState, gomock.Any(), gomock.Any(), gomock.Any())
+		mockEventManager.EXPECT().
+			OnReconnect(gomock.Any())
+		mockEventManager.EXPECT().
+			Start().
+			Return(func() {}, nil)
+		mockSubscriptions.EXPECT().
+			Subscribe(gomock.Any(), gomock.Any()).
+			Return(make(chan interface{})).
+			Times(5)
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID}, nil)
+		mockState.EXPECT().
+			Get(desktopID).
+			Return(state.State{HiddenNodeIDs: []bspc.ID{3}}, true)
+		mockSubscriptions.EXPECT().
+			PublishRetained(topic.MonocleNodeCount.Name(), 1).
+			Do(func(subscription.Name, interface{}) {
+				close(published)
+			})
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, cancel, err := transparentmonocle.Start(logger, mockState, mockService, mockTree, mockSubscriptions)
+		require.NoError(t, err)
+		defer cancel()
+
+		select {
+		case <-published:
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for node count")
+		}
+	})
+}
+
+func TestTransparentMonocle_Concurrency(t *testing.T) {
+	t.Run("should not lose nodes when cycling while nodes are added", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockClient    = bspwmevent.NewMockClient(ctrl)
+			mockService   = bspwm.NewMockService(ctrl)
+			mockDesktops  = bspwmdesktop.NewMockService(ctrl)
+			mockNodes     = bspwmnode.NewMockService(ctrl)
+			mockTree      = bspwmtree.NewMockTree(ctrl)
+			subscriptions = subscription.NewManager()
+			desktops      = state.NewTransparentMonocle(subscriptions)
+			nodeAddCh     = make(chan bspc.Event)
+			desktopID     = bspc.ID(1)
+			tiled         = &bspc.NodeClient{State: bspc.StateTypeTiled}
+		)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		mockClient.EXPECT().
+			SubscribeEvents(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(eventType bspc.EventType, moreEventTypes ...bspc.EventType) (chan bspc.Event, chan error, error) {
+				for _, et := range append([]bspc.EventType{eventType}, moreEventTypes...) {
+					if et == bspc.EventTypeNodeAdd {
+						return nodeAddCh, make(chan error), nil
+					}
+				}
+
+				return make(chan bspc.Event), make(chan error), nil
+			}).
+			AnyTimes()
+		mockClient.EXPECT().
+			Query(gomock.Any(), gomock.Any()).
+			Return(nil).
+			AnyTimes()
+
+		mockService.EXPECT().
+			Events().
+			Return(bspwmevent.NewManager(logger, mockClient, subscriptions, bspwmevent.NewEchoes())).
+			AnyTimes()
+		mockService.EXPECT().
+			Desktops().
+			Return(mockDesktops).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+
+		desktop := bspc.Desktop{
+			ID:            desktopID,
+			FocusedNodeID: 1,
+			Root: bspc.Node{
+				FirstChild: &bspc.Node{ID: 1, Client: tiled},
+				SecondChild: &bspc.Node{
+					FirstChild:  &bspc.Node{ID: 2, Client: tiled},
+					SecondChild: &bspc.Node{ID: 3, Client: tiled},
+				},
+			},
+		}
+
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(desktop, nil).
+			AnyTimes()
+		mockDesktops.EXPECT().
+			SetLayout(gomock.Any(), gomock.Any()).
+			Return(nil).
+			AnyTimes()
+
+		mockTree.EXPECT().
+			Node(gomock.Any()).
+			Return(bspc.Node{Client: tiled}, nil).
+			AnyTimes()
+
+		// Stands in for the round trip to bspwm, which is what gives concurrent changes room to interleave.
+		roundTrip := func() { time.Sleep(100 * time.Microsecond) }
+
+		mockNodes.EXPECT().
+			SetVisibility(gomock.Any(), gomock.Any()).
+			Do(func(bspc.ID, bool) { roundTrip() }).
+			Return(nil).
+			AnyTimes()
+		mockNodes.EXPECT().
+			SetVisibilities(gomock.Any()).
+			DoAndReturn(func(...bspwmnode.Visibility) error {
+				roundTrip()
+				return nil
+			}).
+			AnyTimes()
+
+		monocle, cancel, err := transparentmonocle.Start(logger, desktops, mockService, mockTree, subscriptions)
+		require.NoError(t, err)
+		defer cancel()
+
+		require.NoError(t, monocle.ToggleCurrentDesktop())
+
+		const (
+			cyclers         = 4
+			cyclesPerCycler = 50
+			addedNodes      = 100
+		)
+
+		expected := map[bspc.ID]struct{}{1: {}, 2: {}, 3: {}}
+		for i := 0; i < addedNodes; i++ {
+			expected[bspc.ID(100+i)] = struct{}{}
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < cyclers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				for j := 0; j < cyclesPerCycler; j++ {
+					cycle := monocle.FocusNextHiddenNode
+					if (i+j)%2 == 0 {
+						cycle = monocle.FocusPreviousHiddenNode
+					}
+
+					assert.NoError(t, cycle())
+				}
+			}(i)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < addedNodes; i++ {
+				nodeAddCh <- bspc.Event{
+					Type:    bspc.EventTypeNodeAdd,
+					Payload: bspc.EventNodeAdd{DesktopID: desktopID, NodeID: bspc.ID(100 + i)},
+				}
+			}
+		}()
+
+		wg.Wait()
+
+		// The last events might still be being handled.
+		require.Eventually(t, func() bool {
+			st, ok := desktops.Get(desktopID)
+			return ok && st.SelectedNodeID != nil && len(st.HiddenNodeIDs)+1 == len(expected)
+		}, time.Second, time.Millisecond)
+
+		st, _ := desktops.Get(desktopID)
+
+		got := map[bspc.ID]struct{}{*st.SelectedNodeID: {}}
+		for _, id := range st.HiddenNodeIDs {
+			_, isDuplicate := got[id]
+			assert.False(t, isDuplicate, "node %d is in the state more than once", id)
+
+			got[id] = struct{}{}
+		}
+
+		assert.Equal(t, expected, got)
+	})
+}
+
+func TestTransparentMonocle_FocusNode(t *testing.T) {
+	const desktopID = bspc.ID(1)
+
+	t.Run("should make the hidden node the selected one, keeping the stack's order", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			subscriptions    = subscription.NewManager()
+			desktops         = state.NewTransparentMonocle(subscriptions)
+			selected         = bspc.ID(1)
+		)
+
+		desktops.Set(desktopID, state.State{
+			SelectedNodeID: &selected,
+			HiddenNodeIDs:  []bspc.ID{2, 3, 4},
+		})
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any()).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			OnReconnect(gomock.Any())
 		mockEventManager.EXPECT().
 			Start().
-			Return(nil, nil)
+			Return(func() {}, nil)
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID}, nil).
+			AnyTimes()
+		gomock.InOrder(
+			mockNodes.EXPECT().
+				SetVisibilities(
+					bspwmnode.Visibility{NodeID: 3, IsVisible: true},
+					bspwmnode.Visibility{NodeID: 1, IsVisible: false},
+				).
+				Return(nil),
+			mockNodes.EXPECT().
+				Focus(bspc.ID(3)).
+				Return(nil),
+		)
 
 		logger, err := log.New(zaptest.NewLogger(t), false)
 		require.NoError(t, err)
 
-		_, _, err = transparentmonocle.Start(logger, mockState, mockService, mockSubscriptions)
-		assert.NoError(t, err)
+		monocle, cancel, err := transparentmonocle.Start(logger, desktops, mockService, mockTree, subscriptions)
+		require.NoError(t, err)
+		defer cancel()
+
+		require.NoError(t, monocle.FocusNode(3))
+
+		st, _ := desktops.Get(desktopID)
+		require.NotNil(t, st.SelectedNodeID)
+		assert.Equal(t, bspc.ID(3), *st.SelectedNodeID)
+		assert.Equal(t, []bspc.ID{4, 1, 2}, st.HiddenNodeIDs)
+	})
+	t.Run("should focus nodes that aren't hidden in a stack without changing it", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			subscriptions    = subscription.NewManager()
+			desktops         = state.NewTransparentMonocle(subscriptions)
+			selected         = bspc.ID(1)
+		)
+
+		desktops.Set(desktopID, state.State{
+			SelectedNodeID: &selected,
+			HiddenNodeIDs:  []bspc.ID{2, 3, 4},
+		})
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any()).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			OnReconnect(gomock.Any())
+		mockEventManager.EXPECT().
+			Start().
+			Return(func() {}, nil)
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID}, nil).
+			AnyTimes()
+		mockTree.EXPECT().
+			Node(bspc.ID(5)).
+			Return(bspc.Node{ID: 5}, nil)
+		mockNodes.EXPECT().
+			Focus(bspc.ID(5)).
+			Return(nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		monocle, cancel, err := transparentmonocle.Start(logger, desktops, mockService, mockTree, subscriptions)
+		require.NoError(t, err)
+		defer cancel()
+
+		require.NoError(t, monocle.FocusNode(5))
+
+		st, _ := desktops.Get(desktopID)
+		require.NotNil(t, st.SelectedNodeID)
+		assert.Equal(t, selected, *st.SelectedNodeID)
+		assert.Equal(t, []bspc.ID{2, 3, 4}, st.HiddenNodeIDs)
+	})
+	t.Run("should return error for nodes hidden outside monocle stacks", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			mockService      = bspwm.NewMockService(ctrl)
+			mockEventManager = bspwmevent.NewMockManager(ctrl)
+			mockNodes        = bspwmnode.NewMockService(ctrl)
+			mockTree         = bspwmtree.NewMockTree(ctrl)
+			subscriptions    = subscription.NewManager()
+			desktops         = state.NewTransparentMonocle(subscriptions)
+			selected         = bspc.ID(1)
+		)
+
+		desktops.Set(desktopID, state.State{
+			SelectedNodeID: &selected,
+			HiddenNodeIDs:  []bspc.ID{2, 3, 4},
+		})
+
+		mockService.EXPECT().
+			Events().
+			Return(mockEventManager).
+			AnyTimes()
+		mockService.EXPECT().
+			Nodes().
+			Return(mockNodes).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any()).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			On(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			AnyTimes()
+		mockEventManager.EXPECT().
+			OnReconnect(gomock.Any())
+		mockEventManager.EXPECT().
+			Start().
+			Return(func() {}, nil)
+		mockTree.EXPECT().
+			FocusedDesktop().
+			Return(bspc.Desktop{ID: desktopID}, nil).
+			AnyTimes()
+		mockTree.EXPECT().
+			Node(bspc.ID(5)).
+			Return(bspc.Node{ID: 5, Hidden: true}, nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		monocle, cancel, err := transparentmonocle.Start(logger, desktops, mockService, mockTree, subscriptions)
+		require.NoError(t, err)
+		defer cancel()
+
+		err = monocle.FocusNode(5)
+		assert.True(t, errors.Is(err, transparentmonocle.ErrNodeHidden))
 	})
 }
@@ -15,6 +15,8 @@ import (
 	status "google.golang.org/grpc/status"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -30,6 +32,76 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+type Topic int32
+
+const (
+	Topic_TOPIC_INVALID                       Topic = 0
+	Topic_TOPIC_MONOCLE_ENABLED               Topic = 1
+	Topic_TOPIC_MONOCLE_DISABLED              Topic = 2
+	Topic_TOPIC_MONOCLE_STATE_CHANGED         Topic = 3
+	Topic_TOPIC_MONOCLE_DESKTOP_FOCUS_CHANGED Topic = 4
+	Topic_TOPIC_BSPWM_RECONNECTED             Topic = 5
+	Topic_TOPIC_SCRATCHPAD_STATE_CHANGED      Topic = 6
+	Topic_TOPIC_LAYOUT_CHANGED                Topic = 7
+	Topic_TOPIC_TABBED_CHANGED                Topic = 8
+	Topic_TOPIC_MINIMIZED_CHANGED             Topic = 9
+)
+
+// Enum value maps for Topic.
+var (
+	Topic_name = map[int32]string{
+		0: "TOPIC_INVALID",
+		1: "TOPIC_MONOCLE_ENABLED",
+		2: "TOPIC_MONOCLE_DISABLED",
+		3: "TOPIC_MONOCLE_STATE_CHANGED",
+		4: "TOPIC_MONOCLE_DESKTOP_FOCUS_CHANGED",
+		5: "TOPIC_BSPWM_RECONNECTED",
+		6: "TOPIC_SCRATCHPAD_STATE_CHANGED",
+		7: "TOPIC_LAYOUT_CHANGED",
+		8: "TOPIC_TABBED_CHANGED",
+		9: "TOPIC_MINIMIZED_CHANGED",
+	}
+	Topic_value = map[string]int32{
+		"TOPIC_INVALID":                       0,
+		"TOPIC_MONOCLE_ENABLED":               1,
+		"TOPIC_MONOCLE_DISABLED":              2,
+		"TOPIC_MONOCLE_STATE_CHANGED":         3,
+		"TOPIC_MONOCLE_DESKTOP_FOCUS_CHANGED": 4,
+		"TOPIC_BSPWM_RECONNECTED":             5,
+		"TOPIC_SCRATCHPAD_STATE_CHANGED":      6,
+		"TOPIC_LAYOUT_CHANGED":                7,
+		"TOPIC_TABBED_CHANGED":                8,
+		"TOPIC_MINIMIZED_CHANGED":             9,
+	}
+)
+
+func (x Topic) Enum() *Topic {
+	p := new(Topic)
+	*p = x
+	return p
+}
+
+func (x Topic) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Topic) Descriptor() protoreflect.EnumDescriptor {
+	return file_bspm_proto_enumTypes[0].Descriptor()
+}
+
+func (Topic) Type() protoreflect.EnumType {
+	return &file_bspm_proto_enumTypes[0]
+}
+
+func (x Topic) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Topic.Descriptor instead.
+func (Topic) EnumDescriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{0}
+}
+
 type MonocleModeSubscriptionType int32
 
 const (
@@ -60,11 +132,11 @@ func (x MonocleModeSubscriptionType) String() string {
 }
 
 func (MonocleModeSubscriptionType) Descriptor() protoreflect.EnumDescriptor {
-	return file_bspm_proto_enumTypes[0].Descriptor()
+	return file_bspm_proto_enumTypes[1].Descriptor()
 }
 
 func (MonocleModeSubscriptionType) Type() protoreflect.EnumType {
-	return &file_bspm_proto_enumTypes[0]
+	return &file_bspm_proto_enumTypes[1]
 }
 
 func (x MonocleModeSubscriptionType) Number() protoreflect.EnumNumber {
@@ -73,7 +145,7 @@ func (x MonocleModeSubscriptionType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use MonocleModeSubscriptionType.Descriptor instead.
 func (MonocleModeSubscriptionType) EnumDescriptor() ([]byte, []int) {
-	return file_bspm_proto_rawDescGZIP(), []int{0}
+	return file_bspm_proto_rawDescGZIP(), []int{1}
 }
 
 type CycleDir int32
@@ -109,11 +181,11 @@ func (x CycleDir) String() string {
 }
 
 func (CycleDir) Descriptor() protoreflect.EnumDescriptor {
-	return file_bspm_proto_enumTypes[1].Descriptor()
+	return file_bspm_proto_enumTypes[2].Descriptor()
 }
 
 func (CycleDir) Type() protoreflect.EnumType {
-	return &file_bspm_proto_enumTypes[1]
+	return &file_bspm_proto_enumTypes[2]
 }
 
 func (x CycleDir) Number() protoreflect.EnumNumber {
@@ -122,19 +194,23 @@ func (x CycleDir) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CycleDir.Descriptor instead.
 func (CycleDir) EnumDescriptor() ([]byte, []int) {
-	return file_bspm_proto_rawDescGZIP(), []int{1}
+	return file_bspm_proto_rawDescGZIP(), []int{2}
 }
 
-type MonocleModeCycleRequest struct {
+type ScratchpadToggleRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CycleDirection CycleDir `protobuf:"varint,1,opt,name=cycle_direction,json=cycleDirection,proto3,enum=ipc.CycleDir" json:"cycle_direction,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Window class of the scratchpad's window. Only needs to be given the first time it's toggled.
+	ClassName string `protobuf:"bytes,2,opt,name=class_name,json=className,proto3" json:"class_name,omitempty"`
+	// Command that launches the scratchpad's window, if there isn't one. Only needs to be given the first time it's toggled.
+	Command string `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`
 }
 
-func (x *MonocleModeCycleRequest) Reset() {
-	*x = MonocleModeCycleRequest{}
+func (x *ScratchpadToggleRequest) Reset() {
+	*x = ScratchpadToggleRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_bspm_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -142,13 +218,13 @@ func (x *MonocleModeCycleRequest) Reset() {
 	}
 }
 
-func (x *MonocleModeCycleRequest) String() string {
+func (x *ScratchpadToggleRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MonocleModeCycleRequest) ProtoMessage() {}
+func (*ScratchpadToggleRequest) ProtoMessage() {}
 
-func (x *MonocleModeCycleRequest) ProtoReflect() protoreflect.Message {
+func (x *ScratchpadToggleRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_bspm_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -160,28 +236,42 @@ func (x *MonocleModeCycleRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MonocleModeCycleRequest.ProtoReflect.Descriptor instead.
-func (*MonocleModeCycleRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ScratchpadToggleRequest.ProtoReflect.Descriptor instead.
+func (*ScratchpadToggleRequest) Descriptor() ([]byte, []int) {
 	return file_bspm_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *MonocleModeCycleRequest) GetCycleDirection() CycleDir {
+func (x *ScratchpadToggleRequest) GetName() string {
 	if x != nil {
-		return x.CycleDirection
+		return x.Name
 	}
-	return CycleDir_CYCLE_DIR_INVALID
+	return ""
 }
 
-type MonocleModeSubscribeRequest struct {
+func (x *ScratchpadToggleRequest) GetClassName() string {
+	if x != nil {
+		return x.ClassName
+	}
+	return ""
+}
+
+func (x *ScratchpadToggleRequest) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+type LayoutSetRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type MonocleModeSubscriptionType `protobuf:"varint,1,opt,name=type,proto3,enum=ipc.MonocleModeSubscriptionType" json:"type,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 }
 
-func (x *MonocleModeSubscribeRequest) Reset() {
-	*x = MonocleModeSubscribeRequest{}
+func (x *LayoutSetRequest) Reset() {
+	*x = LayoutSetRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_bspm_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -189,13 +279,13 @@ func (x *MonocleModeSubscribeRequest) Reset() {
 	}
 }
 
-func (x *MonocleModeSubscribeRequest) String() string {
+func (x *LayoutSetRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MonocleModeSubscribeRequest) ProtoMessage() {}
+func (*LayoutSetRequest) ProtoMessage() {}
 
-func (x *MonocleModeSubscribeRequest) ProtoReflect() protoreflect.Message {
+func (x *LayoutSetRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_bspm_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -207,30 +297,28 @@ func (x *MonocleModeSubscribeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MonocleModeSubscribeRequest.ProtoReflect.Descriptor instead.
-func (*MonocleModeSubscribeRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use LayoutSetRequest.ProtoReflect.Descriptor instead.
+func (*LayoutSetRequest) Descriptor() ([]byte, []int) {
 	return file_bspm_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *MonocleModeSubscribeRequest) GetType() MonocleModeSubscriptionType {
+func (x *LayoutSetRequest) GetName() string {
 	if x != nil {
-		return x.Type
+		return x.Name
 	}
-	return MonocleModeSubscriptionType_MONOCLE_MODE_SUBSCRIPTION_TYPE_INVALID
+	return ""
 }
 
-type MonocleModeSubscribeResponse struct {
+type LayoutMasterCountChangeRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to SubscriptionType:
-	//	*MonocleModeSubscribeResponse_NodeCount
-	SubscriptionType isMonocleModeSubscribeResponse_SubscriptionType `protobuf_oneof:"subscription_type"`
+	Delta int32 `protobuf:"varint,1,opt,name=delta,proto3" json:"delta,omitempty"`
 }
 
-func (x *MonocleModeSubscribeResponse) Reset() {
-	*x = MonocleModeSubscribeResponse{}
+func (x *LayoutMasterCountChangeRequest) Reset() {
+	*x = LayoutMasterCountChangeRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_bspm_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -238,13 +326,13 @@ func (x *MonocleModeSubscribeResponse) Reset() {
 	}
 }
 
-func (x *MonocleModeSubscribeResponse) String() string {
+func (x *LayoutMasterCountChangeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MonocleModeSubscribeResponse) ProtoMessage() {}
+func (*LayoutMasterCountChangeRequest) ProtoMessage() {}
 
-func (x *MonocleModeSubscribeResponse) ProtoReflect() protoreflect.Message {
+func (x *LayoutMasterCountChangeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_bspm_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -256,369 +344,4112 @@ func (x *MonocleModeSubscribeResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MonocleModeSubscribeResponse.ProtoReflect.Descriptor instead.
-func (*MonocleModeSubscribeResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use LayoutMasterCountChangeRequest.ProtoReflect.Descriptor instead.
+func (*LayoutMasterCountChangeRequest) Descriptor() ([]byte, []int) {
 	return file_bspm_proto_rawDescGZIP(), []int{2}
 }
 
-func (m *MonocleModeSubscribeResponse) GetSubscriptionType() isMonocleModeSubscribeResponse_SubscriptionType {
-	if m != nil {
-		return m.SubscriptionType
-	}
-	return nil
-}
-
-func (x *MonocleModeSubscribeResponse) GetNodeCount() int32 {
-	if x, ok := x.GetSubscriptionType().(*MonocleModeSubscribeResponse_NodeCount); ok {
-		return x.NodeCount
+func (x *LayoutMasterCountChangeRequest) GetDelta() int32 {
+	if x != nil {
+		return x.Delta
 	}
 	return 0
 }
 
-type isMonocleModeSubscribeResponse_SubscriptionType interface {
-	isMonocleModeSubscribeResponse_SubscriptionType()
-}
+type LayoutRatioChangeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-type MonocleModeSubscribeResponse_NodeCount struct {
-	NodeCount int32 `protobuf:"varint,1,opt,name=node_count,json=nodeCount,proto3,oneof"`
+	Delta float64 `protobuf:"fixed64,1,opt,name=delta,proto3" json:"delta,omitempty"`
 }
 
-func (*MonocleModeSubscribeResponse_NodeCount) isMonocleModeSubscribeResponse_SubscriptionType() {}
-
-var File_bspm_proto protoreflect.FileDescriptor
+func (x *LayoutRatioChangeRequest) Reset() {
+	*x = LayoutRatioChangeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-var file_bspm_proto_rawDesc = []byte{
-	0x0a, 0x0a, 0x62, 0x73, 0x70, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x69, 0x70,
-	0x63, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x51,
-	0x0a, 0x17, 0x4d, 0x6f, 0x6e, 0x6f, 0x63, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x43, 0x79, 0x63,
-	0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x36, 0x0a, 0x0f, 0x63, 0x79, 0x63,
-	0x6c, 0x65, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x44, 0x69,
-	0x72, 0x52, 0x0e, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x22, 0x53, 0x0a, 0x1b, 0x4d, 0x6f, 0x6e, 0x6f, 0x63, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65,
-	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x34, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20,
-	0x2e, 0x69, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x6f, 0x63, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65,
-	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65,
-	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x54, 0x0a, 0x1c, 0x4d, 0x6f, 0x6e, 0x6f, 0x63, 0x6c,
-	0x65, 0x4d, 0x6f, 0x64, 0x65, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0a, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x09, 0x6e, 0x6f,
-	0x64, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x13, 0x0a, 0x11, 0x73, 0x75, 0x62, 0x73, 0x63,
-	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x2a, 0x78, 0x0a, 0x1b,
-	0x4d, 0x6f, 0x6e, 0x6f, 0x63, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x53, 0x75, 0x62, 0x73, 0x63,
-	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2a, 0x0a, 0x26, 0x4d,
-	0x4f, 0x4e, 0x4f, 0x43, 0x4c, 0x45, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x53, 0x55, 0x42, 0x53,
-	0x43, 0x52, 0x49, 0x50, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x49, 0x4e,
-	0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x00, 0x12, 0x2d, 0x0a, 0x29, 0x4d, 0x4f, 0x4e, 0x4f, 0x43,
-	0x4c, 0x45, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x53, 0x55, 0x42, 0x53, 0x43, 0x52, 0x49, 0x50,
-	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4e, 0x4f, 0x44, 0x45, 0x5f, 0x43,
-	0x4f, 0x55, 0x4e, 0x54, 0x10, 0x01, 0x2a, 0x49, 0x0a, 0x08, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x44,
-	0x69, 0x72, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x59, 0x43, 0x4c, 0x45, 0x5f, 0x44, 0x49, 0x52, 0x5f,
-	0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x59, 0x43,
-	0x4c, 0x45, 0x5f, 0x44, 0x49, 0x52, 0x5f, 0x50, 0x52, 0x45, 0x56, 0x10, 0x01, 0x12, 0x12, 0x0a,
-	0x0e, 0x43, 0x59, 0x43, 0x4c, 0x45, 0x5f, 0x44, 0x49, 0x52, 0x5f, 0x4e, 0x45, 0x58, 0x54, 0x10,
-	0x02, 0x32, 0xf4, 0x01, 0x0a, 0x04, 0x42, 0x53, 0x50, 0x4d, 0x12, 0x43, 0x0a, 0x11, 0x4d, 0x6f,
-	0x6e, 0x6f, 0x63, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x54, 0x6f, 0x67, 0x67, 0x6c, 0x65, 0x12,
-	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12,
-	0x48, 0x0a, 0x10, 0x4d, 0x6f, 0x6e, 0x6f, 0x63, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x43, 0x79,
-	0x63, 0x6c, 0x65, 0x12, 0x1c, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x6f, 0x63, 0x6c,
-	0x65, 0x4d, 0x6f, 0x64, 0x65, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x5d, 0x0a, 0x14, 0x4d, 0x6f, 0x6e,
-	0x6f, 0x63, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
-	0x65, 0x12, 0x20, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x6f, 0x63, 0x6c, 0x65, 0x4d,
-	0x6f, 0x64, 0x65, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x6f, 0x63, 0x6c,
-	0x65, 0x4d, 0x6f, 0x64, 0x65, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x42, 0x09, 0x5a, 0x07, 0x2e, 0x2f, 0x3b, 0x62,
-	0x73, 0x70, 0x6d, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *LayoutRatioChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var (
-	file_bspm_proto_rawDescOnce sync.Once
-	file_bspm_proto_rawDescData = file_bspm_proto_rawDesc
-)
+func (*LayoutRatioChangeRequest) ProtoMessage() {}
 
-func file_bspm_proto_rawDescGZIP() []byte {
-	file_bspm_proto_rawDescOnce.Do(func() {
-		file_bspm_proto_rawDescData = protoimpl.X.CompressGZIP(file_bspm_proto_rawDescData)
-	})
-	return file_bspm_proto_rawDescData
+func (x *LayoutRatioChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var file_bspm_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_bspm_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
-var file_bspm_proto_goTypes = []interface{}{
-	(MonocleModeSubscriptionType)(0),     // 0: ipc.MonocleModeSubscriptionType
-	(CycleDir)(0),                        // 1: ipc.CycleDir
-	(*MonocleModeCycleRequest)(nil),      // 2: ipc.MonocleModeCycleRequest
-	(*MonocleModeSubscribeRequest)(nil),  // 3: ipc.MonocleModeSubscribeRequest
-	(*MonocleModeSubscribeResponse)(nil), // 4: ipc.MonocleModeSubscribeResponse
-	(*empty.Empty)(nil),                  // 5: google.protobuf.Empty
-}
-var file_bspm_proto_depIdxs = []int32{
-	1, // 0: ipc.MonocleModeCycleRequest.cycle_direction:type_name -> ipc.CycleDir
-	0, // 1: ipc.MonocleModeSubscribeRequest.type:type_name -> ipc.MonocleModeSubscriptionType
-	5, // 2: ipc.BSPM.MonocleModeToggle:input_type -> google.protobuf.Empty
-	2, // 3: ipc.BSPM.MonocleModeCycle:input_type -> ipc.MonocleModeCycleRequest
-	3, // 4: ipc.BSPM.MonocleModeSubscribe:input_type -> ipc.MonocleModeSubscribeRequest
-	5, // 5: ipc.BSPM.MonocleModeToggle:output_type -> google.protobuf.Empty
-	5, // 6: ipc.BSPM.MonocleModeCycle:output_type -> google.protobuf.Empty
-	4, // 7: ipc.BSPM.MonocleModeSubscribe:output_type -> ipc.MonocleModeSubscribeResponse
-	5, // [5:8] is the sub-list for method output_type
-	2, // [2:5] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+// Deprecated: Use LayoutRatioChangeRequest.ProtoReflect.Descriptor instead.
+func (*LayoutRatioChangeRequest) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{3}
 }
 
-func init() { file_bspm_proto_init() }
-func file_bspm_proto_init() {
-	if File_bspm_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_bspm_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MonocleModeCycleRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_bspm_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MonocleModeSubscribeRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_bspm_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MonocleModeSubscribeResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-	}
-	file_bspm_proto_msgTypes[2].OneofWrappers = []interface{}{
-		(*MonocleModeSubscribeResponse_NodeCount)(nil),
+func (x *LayoutRatioChangeRequest) GetDelta() float64 {
+	if x != nil {
+		return x.Delta
 	}
-	type x struct{}
-	out := protoimpl.TypeBuilder{
-		File: protoimpl.DescBuilder{
-			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_bspm_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   3,
-			NumExtensions: 0,
-			NumServices:   1,
-		},
-		GoTypes:           file_bspm_proto_goTypes,
-		DependencyIndexes: file_bspm_proto_depIdxs,
-		EnumInfos:         file_bspm_proto_enumTypes,
-		MessageInfos:      file_bspm_proto_msgTypes,
-	}.Build()
-	File_bspm_proto = out.File
-	file_bspm_proto_rawDesc = nil
-	file_bspm_proto_goTypes = nil
-	file_bspm_proto_depIdxs = nil
+	return 0
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConnInterface
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
+type TabbedCycleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-// BSPMClient is the client API for BSPM service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type BSPMClient interface {
-	MonocleModeToggle(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
-	MonocleModeCycle(ctx context.Context, in *MonocleModeCycleRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	MonocleModeSubscribe(ctx context.Context, in *MonocleModeSubscribeRequest, opts ...grpc.CallOption) (BSPM_MonocleModeSubscribeClient, error)
+	CycleDirection CycleDir `protobuf:"varint,1,opt,name=cycle_direction,json=cycleDirection,proto3,enum=ipc.CycleDir" json:"cycle_direction,omitempty"`
 }
 
-type bSPMClient struct {
-	cc grpc.ClientConnInterface
+func (x *TabbedCycleRequest) Reset() {
+	*x = TabbedCycleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func NewBSPMClient(cc grpc.ClientConnInterface) BSPMClient {
-	return &bSPMClient{cc}
+func (x *TabbedCycleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (c *bSPMClient) MonocleModeToggle(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ipc.BSPM/MonocleModeToggle", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
+func (*TabbedCycleRequest) ProtoMessage() {}
+
+func (x *TabbedCycleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TabbedCycleRequest.ProtoReflect.Descriptor instead.
+func (*TabbedCycleRequest) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TabbedCycleRequest) GetCycleDirection() CycleDir {
+	if x != nil {
+		return x.CycleDirection
+	}
+	return CycleDir_CYCLE_DIR_INVALID
+}
+
+type TabbedSelectRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NodeId uint32 `protobuf:"varint,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+}
+
+func (x *TabbedSelectRequest) Reset() {
+	*x = TabbedSelectRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TabbedSelectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TabbedSelectRequest) ProtoMessage() {}
+
+func (x *TabbedSelectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TabbedSelectRequest.ProtoReflect.Descriptor instead.
+func (*TabbedSelectRequest) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TabbedSelectRequest) GetNodeId() uint32 {
+	if x != nil {
+		return x.NodeId
+	}
+	return 0
+}
+
+type MinimizeRestoreRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Zero restores the window that was minimised last.
+	NodeId uint32 `protobuf:"varint,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// Brings the window to the focused desktop, instead of the one it was minimised from.
+	ToFocusedDesktop bool `protobuf:"varint,2,opt,name=to_focused_desktop,json=toFocusedDesktop,proto3" json:"to_focused_desktop,omitempty"`
+}
+
+func (x *MinimizeRestoreRequest) Reset() {
+	*x = MinimizeRestoreRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MinimizeRestoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MinimizeRestoreRequest) ProtoMessage() {}
+
+func (x *MinimizeRestoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MinimizeRestoreRequest.ProtoReflect.Descriptor instead.
+func (*MinimizeRestoreRequest) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MinimizeRestoreRequest) GetNodeId() uint32 {
+	if x != nil {
+		return x.NodeId
+	}
+	return 0
+}
+
+func (x *MinimizeRestoreRequest) GetToFocusedDesktop() bool {
+	if x != nil {
+		return x.ToFocusedDesktop
+	}
+	return false
+}
+
+type MarkSetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A single letter.
+	Mark string `protobuf:"bytes,1,opt,name=mark,proto3" json:"mark,omitempty"`
+}
+
+func (x *MarkSetRequest) Reset() {
+	*x = MarkSetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MarkSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkSetRequest) ProtoMessage() {}
+
+func (x *MarkSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkSetRequest.ProtoReflect.Descriptor instead.
+func (*MarkSetRequest) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MarkSetRequest) GetMark() string {
+	if x != nil {
+		return x.Mark
+	}
+	return ""
+}
+
+type MarkJumpRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mark string `protobuf:"bytes,1,opt,name=mark,proto3" json:"mark,omitempty"`
+}
+
+func (x *MarkJumpRequest) Reset() {
+	*x = MarkJumpRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MarkJumpRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkJumpRequest) ProtoMessage() {}
+
+func (x *MarkJumpRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkJumpRequest.ProtoReflect.Descriptor instead.
+func (*MarkJumpRequest) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MarkJumpRequest) GetMark() string {
+	if x != nil {
+		return x.Mark
+	}
+	return ""
+}
+
+type FocusCycleStepRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CycleDirection CycleDir `protobuf:"varint,1,opt,name=cycle_direction,json=cycleDirection,proto3,enum=ipc.CycleDir" json:"cycle_direction,omitempty"`
+}
+
+func (x *FocusCycleStepRequest) Reset() {
+	*x = FocusCycleStepRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FocusCycleStepRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FocusCycleStepRequest) ProtoMessage() {}
+
+func (x *FocusCycleStepRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FocusCycleStepRequest.ProtoReflect.Descriptor instead.
+func (*FocusCycleStepRequest) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FocusCycleStepRequest) GetCycleDirection() CycleDir {
+	if x != nil {
+		return x.CycleDirection
+	}
+	return CycleDir_CYCLE_DIR_INVALID
+}
+
+type FocusHistoryListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The most recently focused first.
+	Entries []*FocusHistoryEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *FocusHistoryListResponse) Reset() {
+	*x = FocusHistoryListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FocusHistoryListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FocusHistoryListResponse) ProtoMessage() {}
+
+func (x *FocusHistoryListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FocusHistoryListResponse.ProtoReflect.Descriptor instead.
+func (*FocusHistoryListResponse) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FocusHistoryListResponse) GetEntries() []*FocusHistoryEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type FocusHistoryEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NodeId       uint32 `protobuf:"varint,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	DesktopId    uint32 `protobuf:"varint,2,opt,name=desktop_id,json=desktopId,proto3" json:"desktop_id,omitempty"`
+	ClassName    string `protobuf:"bytes,3,opt,name=class_name,json=className,proto3" json:"class_name,omitempty"`
+	InstanceName string `protobuf:"bytes,4,opt,name=instance_name,json=instanceName,proto3" json:"instance_name,omitempty"`
+	// Empty if the window's title couldn't be read.
+	Title     string                 `protobuf:"bytes,5,opt,name=title,proto3" json:"title,omitempty"`
+	FocusedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=focused_at,json=focusedAt,proto3" json:"focused_at,omitempty"`
+	IsHidden  bool                   `protobuf:"varint,7,opt,name=is_hidden,json=isHidden,proto3" json:"is_hidden,omitempty"`
+}
+
+func (x *FocusHistoryEntry) Reset() {
+	*x = FocusHistoryEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FocusHistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FocusHistoryEntry) ProtoMessage() {}
+
+func (x *FocusHistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FocusHistoryEntry.ProtoReflect.Descriptor instead.
+func (*FocusHistoryEntry) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *FocusHistoryEntry) GetNodeId() uint32 {
+	if x != nil {
+		return x.NodeId
+	}
+	return 0
+}
+
+func (x *FocusHistoryEntry) GetDesktopId() uint32 {
+	if x != nil {
+		return x.DesktopId
+	}
+	return 0
+}
+
+func (x *FocusHistoryEntry) GetClassName() string {
+	if x != nil {
+		return x.ClassName
+	}
+	return ""
+}
+
+func (x *FocusHistoryEntry) GetInstanceName() string {
+	if x != nil {
+		return x.InstanceName
+	}
+	return ""
+}
+
+func (x *FocusHistoryEntry) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *FocusHistoryEntry) GetFocusedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FocusedAt
+	}
+	return nil
+}
+
+func (x *FocusHistoryEntry) GetIsHidden() bool {
+	if x != nil {
+		return x.IsHidden
+	}
+	return false
+}
+
+type MonocleModeCycleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CycleDirection CycleDir `protobuf:"varint,1,opt,name=cycle_direction,json=cycleDirection,proto3,enum=ipc.CycleDir" json:"cycle_direction,omitempty"`
+}
+
+func (x *MonocleModeCycleRequest) Reset() {
+	*x = MonocleModeCycleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MonocleModeCycleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonocleModeCycleRequest) ProtoMessage() {}
+
+func (x *MonocleModeCycleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonocleModeCycleRequest.ProtoReflect.Descriptor instead.
+func (*MonocleModeCycleRequest) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MonocleModeCycleRequest) GetCycleDirection() CycleDir {
+	if x != nil {
+		return x.CycleDirection
+	}
+	return CycleDir_CYCLE_DIR_INVALID
+}
+
+type MonocleModeSubscribeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type MonocleModeSubscriptionType `protobuf:"varint,1,opt,name=type,proto3,enum=ipc.MonocleModeSubscriptionType" json:"type,omitempty"`
+}
+
+func (x *MonocleModeSubscribeRequest) Reset() {
+	*x = MonocleModeSubscribeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MonocleModeSubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonocleModeSubscribeRequest) ProtoMessage() {}
+
+func (x *MonocleModeSubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonocleModeSubscribeRequest.ProtoReflect.Descriptor instead.
+func (*MonocleModeSubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MonocleModeSubscribeRequest) GetType() MonocleModeSubscriptionType {
+	if x != nil {
+		return x.Type
+	}
+	return MonocleModeSubscriptionType_MONOCLE_MODE_SUBSCRIPTION_TYPE_INVALID
+}
+
+type MonocleModeSubscribeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to SubscriptionType:
+	//	*MonocleModeSubscribeResponse_NodeCount
+	SubscriptionType isMonocleModeSubscribeResponse_SubscriptionType `protobuf_oneof:"subscription_type"`
+}
+
+func (x *MonocleModeSubscribeResponse) Reset() {
+	*x = MonocleModeSubscribeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MonocleModeSubscribeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonocleModeSubscribeResponse) ProtoMessage() {}
+
+func (x *MonocleModeSubscribeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonocleModeSubscribeResponse.ProtoReflect.Descriptor instead.
+func (*MonocleModeSubscribeResponse) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{14}
+}
+
+func (m *MonocleModeSubscribeResponse) GetSubscriptionType() isMonocleModeSubscribeResponse_SubscriptionType {
+	if m != nil {
+		return m.SubscriptionType
+	}
+	return nil
+}
+
+func (x *MonocleModeSubscribeResponse) GetNodeCount() int32 {
+	if x, ok := x.GetSubscriptionType().(*MonocleModeSubscribeResponse_NodeCount); ok {
+		return x.NodeCount
+	}
+	return 0
+}
+
+type isMonocleModeSubscribeResponse_SubscriptionType interface {
+	isMonocleModeSubscribeResponse_SubscriptionType()
+}
+
+type MonocleModeSubscribeResponse_NodeCount struct {
+	NodeCount int32 `protobuf:"varint,1,opt,name=node_count,json=nodeCount,proto3,oneof"`
+}
+
+func (*MonocleModeSubscribeResponse_NodeCount) isMonocleModeSubscribeResponse_SubscriptionType() {}
+
+type SubscribeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Topics to subscribe to. All topics are streamed if empty.
+	Topics []Topic `protobuf:"varint,1,rep,packed,name=topics,proto3,enum=ipc.Topic" json:"topics,omitempty"`
+	// Desktops to filter messages by. Messages for all desktops are streamed if empty.
+	DesktopIds []uint32 `protobuf:"varint,2,rep,packed,name=desktop_ids,json=desktopIds,proto3" json:"desktop_ids,omitempty"`
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SubscribeRequest) GetTopics() []Topic {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+func (x *SubscribeRequest) GetDesktopIds() []uint32 {
+	if x != nil {
+		return x.DesktopIds
+	}
+	return nil
+}
+
+type SubscribeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Topic Topic `protobuf:"varint,1,opt,name=topic,proto3,enum=ipc.Topic" json:"topic,omitempty"`
+	// Types that are assignable to Payload:
+	//	*SubscribeResponse_MonocleState
+	//	*SubscribeResponse_DesktopFocus
+	//	*SubscribeResponse_BspwmReconnected
+	//	*SubscribeResponse_ScratchpadState
+	//	*SubscribeResponse_LayoutState
+	//	*SubscribeResponse_TabbedState
+	//	*SubscribeResponse_MinimizedState
+	Payload isSubscribeResponse_Payload `protobuf_oneof:"payload"`
+}
+
+func (x *SubscribeResponse) Reset() {
+	*x = SubscribeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeResponse) ProtoMessage() {}
+
+func (x *SubscribeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeResponse.ProtoReflect.Descriptor instead.
+func (*SubscribeResponse) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SubscribeResponse) GetTopic() Topic {
+	if x != nil {
+		return x.Topic
+	}
+	return Topic_TOPIC_INVALID
+}
+
+func (m *SubscribeResponse) GetPayload() isSubscribeResponse_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (x *SubscribeResponse) GetMonocleState() *MonocleState {
+	if x, ok := x.GetPayload().(*SubscribeResponse_MonocleState); ok {
+		return x.MonocleState
+	}
+	return nil
+}
+
+func (x *SubscribeResponse) GetDesktopFocus() *DesktopFocus {
+	if x, ok := x.GetPayload().(*SubscribeResponse_DesktopFocus); ok {
+		return x.DesktopFocus
+	}
+	return nil
+}
+
+func (x *SubscribeResponse) GetBspwmReconnected() *BspwmReconnected {
+	if x, ok := x.GetPayload().(*SubscribeResponse_BspwmReconnected); ok {
+		return x.BspwmReconnected
+	}
+	return nil
+}
+
+func (x *SubscribeResponse) GetScratchpadState() *ScratchpadState {
+	if x, ok := x.GetPayload().(*SubscribeResponse_ScratchpadState); ok {
+		return x.ScratchpadState
+	}
+	return nil
+}
+
+func (x *SubscribeResponse) GetLayoutState() *LayoutState {
+	if x, ok := x.GetPayload().(*SubscribeResponse_LayoutState); ok {
+		return x.LayoutState
+	}
+	return nil
+}
+
+func (x *SubscribeResponse) GetTabbedState() *TabbedState {
+	if x, ok := x.GetPayload().(*SubscribeResponse_TabbedState); ok {
+		return x.TabbedState
+	}
+	return nil
+}
+
+func (x *SubscribeResponse) GetMinimizedState() *MinimizedState {
+	if x, ok := x.GetPayload().(*SubscribeResponse_MinimizedState); ok {
+		return x.MinimizedState
+	}
+	return nil
+}
+
+type isSubscribeResponse_Payload interface {
+	isSubscribeResponse_Payload()
+}
+
+type SubscribeResponse_MonocleState struct {
+	MonocleState *MonocleState `protobuf:"bytes,2,opt,name=monocle_state,json=monocleState,proto3,oneof"`
+}
+
+type SubscribeResponse_DesktopFocus struct {
+	DesktopFocus *DesktopFocus `protobuf:"bytes,3,opt,name=desktop_focus,json=desktopFocus,proto3,oneof"`
+}
+
+type SubscribeResponse_BspwmReconnected struct {
+	BspwmReconnected *BspwmReconnected `protobuf:"bytes,4,opt,name=bspwm_reconnected,json=bspwmReconnected,proto3,oneof"`
+}
+
+type SubscribeResponse_ScratchpadState struct {
+	ScratchpadState *ScratchpadState `protobuf:"bytes,5,opt,name=scratchpad_state,json=scratchpadState,proto3,oneof"`
+}
+
+type SubscribeResponse_LayoutState struct {
+	LayoutState *LayoutState `protobuf:"bytes,6,opt,name=layout_state,json=layoutState,proto3,oneof"`
+}
+
+type SubscribeResponse_TabbedState struct {
+	TabbedState *TabbedState `protobuf:"bytes,7,opt,name=tabbed_state,json=tabbedState,proto3,oneof"`
+}
+
+type SubscribeResponse_MinimizedState struct {
+	MinimizedState *MinimizedState `protobuf:"bytes,8,opt,name=minimized_state,json=minimizedState,proto3,oneof"`
+}
+
+func (*SubscribeResponse_MonocleState) isSubscribeResponse_Payload() {}
+
+func (*SubscribeResponse_DesktopFocus) isSubscribeResponse_Payload() {}
+
+func (*SubscribeResponse_BspwmReconnected) isSubscribeResponse_Payload() {}
+
+func (*SubscribeResponse_ScratchpadState) isSubscribeResponse_Payload() {}
+
+func (*SubscribeResponse_LayoutState) isSubscribeResponse_Payload() {}
+
+func (*SubscribeResponse_TabbedState) isSubscribeResponse_Payload() {}
+
+func (*SubscribeResponse_MinimizedState) isSubscribeResponse_Payload() {}
+
+type MonocleState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DesktopId uint32 `protobuf:"varint,1,opt,name=desktop_id,json=desktopId,proto3" json:"desktop_id,omitempty"`
+	// Zero if there is no selected node.
+	SelectedNodeId uint32   `protobuf:"varint,2,opt,name=selected_node_id,json=selectedNodeId,proto3" json:"selected_node_id,omitempty"`
+	HiddenNodeIds  []uint32 `protobuf:"varint,3,rep,packed,name=hidden_node_ids,json=hiddenNodeIds,proto3" json:"hidden_node_ids,omitempty"`
+}
+
+func (x *MonocleState) Reset() {
+	*x = MonocleState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MonocleState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonocleState) ProtoMessage() {}
+
+func (x *MonocleState) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonocleState.ProtoReflect.Descriptor instead.
+func (*MonocleState) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *MonocleState) GetDesktopId() uint32 {
+	if x != nil {
+		return x.DesktopId
+	}
+	return 0
+}
+
+func (x *MonocleState) GetSelectedNodeId() uint32 {
+	if x != nil {
+		return x.SelectedNodeId
+	}
+	return 0
+}
+
+func (x *MonocleState) GetHiddenNodeIds() []uint32 {
+	if x != nil {
+		return x.HiddenNodeIds
+	}
+	return nil
+}
+
+type DesktopFocus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MonitorId uint32 `protobuf:"varint,1,opt,name=monitor_id,json=monitorId,proto3" json:"monitor_id,omitempty"`
+	DesktopId uint32 `protobuf:"varint,2,opt,name=desktop_id,json=desktopId,proto3" json:"desktop_id,omitempty"`
+}
+
+func (x *DesktopFocus) Reset() {
+	*x = DesktopFocus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DesktopFocus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DesktopFocus) ProtoMessage() {}
+
+func (x *DesktopFocus) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DesktopFocus.ProtoReflect.Descriptor instead.
+func (*DesktopFocus) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DesktopFocus) GetMonitorId() uint32 {
+	if x != nil {
+		return x.MonitorId
+	}
+	return 0
+}
+
+func (x *DesktopFocus) GetDesktopId() uint32 {
+	if x != nil {
+		return x.DesktopId
+	}
+	return 0
+}
+
+type BspwmReconnected struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Number of attempts it took to resubscribe to bspwm's events.
+	Attempts int32 `protobuf:"varint,1,opt,name=attempts,proto3" json:"attempts,omitempty"`
+}
+
+func (x *BspwmReconnected) Reset() {
+	*x = BspwmReconnected{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BspwmReconnected) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BspwmReconnected) ProtoMessage() {}
+
+func (x *BspwmReconnected) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BspwmReconnected.ProtoReflect.Descriptor instead.
+func (*BspwmReconnected) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *BspwmReconnected) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+type ScratchpadState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name      string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ClassName string `protobuf:"bytes,2,opt,name=class_name,json=className,proto3" json:"class_name,omitempty"`
+	Command   string `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`
+	// Zero if the scratchpad has no window.
+	NodeId    uint32 `protobuf:"varint,4,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	IsVisible bool   `protobuf:"varint,5,opt,name=is_visible,json=isVisible,proto3" json:"is_visible,omitempty"`
+}
+
+func (x *ScratchpadState) Reset() {
+	*x = ScratchpadState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScratchpadState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScratchpadState) ProtoMessage() {}
+
+func (x *ScratchpadState) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScratchpadState.ProtoReflect.Descriptor instead.
+func (*ScratchpadState) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ScratchpadState) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ScratchpadState) GetClassName() string {
+	if x != nil {
+		return x.ClassName
+	}
+	return ""
+}
+
+func (x *ScratchpadState) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *ScratchpadState) GetNodeId() uint32 {
+	if x != nil {
+		return x.NodeId
+	}
+	return 0
+}
+
+func (x *ScratchpadState) GetIsVisible() bool {
+	if x != nil {
+		return x.IsVisible
+	}
+	return false
+}
+
+type LayoutState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DesktopId uint32 `protobuf:"varint,1,opt,name=desktop_id,json=desktopId,proto3" json:"desktop_id,omitempty"`
+	// Empty if the desktop has no layout.
+	Name        string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	MasterCount int32   `protobuf:"varint,3,opt,name=master_count,json=masterCount,proto3" json:"master_count,omitempty"`
+	Ratio       float64 `protobuf:"fixed64,4,opt,name=ratio,proto3" json:"ratio,omitempty"`
+}
+
+func (x *LayoutState) Reset() {
+	*x = LayoutState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LayoutState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LayoutState) ProtoMessage() {}
+
+func (x *LayoutState) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LayoutState.ProtoReflect.Descriptor instead.
+func (*LayoutState) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *LayoutState) GetDesktopId() uint32 {
+	if x != nil {
+		return x.DesktopId
+	}
+	return 0
+}
+
+func (x *LayoutState) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LayoutState) GetMasterCount() int32 {
+	if x != nil {
+		return x.MasterCount
+	}
+	return 0
+}
+
+func (x *LayoutState) GetRatio() float64 {
+	if x != nil {
+		return x.Ratio
+	}
+	return 0
+}
+
+type TabbedState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DesktopId  uint32             `protobuf:"varint,1,opt,name=desktop_id,json=desktopId,proto3" json:"desktop_id,omitempty"`
+	Containers []*TabbedContainer `protobuf:"bytes,2,rep,name=containers,proto3" json:"containers,omitempty"`
+}
+
+func (x *TabbedState) Reset() {
+	*x = TabbedState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TabbedState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TabbedState) ProtoMessage() {}
+
+func (x *TabbedState) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TabbedState.ProtoReflect.Descriptor instead.
+func (*TabbedState) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *TabbedState) GetDesktopId() uint32 {
+	if x != nil {
+		return x.DesktopId
+	}
+	return 0
+}
+
+func (x *TabbedState) GetContainers() []*TabbedContainer {
+	if x != nil {
+		return x.Containers
+	}
+	return nil
+}
+
+type TabbedContainer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tabs []*Tab `protobuf:"bytes,1,rep,name=tabs,proto3" json:"tabs,omitempty"`
+}
+
+func (x *TabbedContainer) Reset() {
+	*x = TabbedContainer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TabbedContainer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TabbedContainer) ProtoMessage() {}
+
+func (x *TabbedContainer) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TabbedContainer.ProtoReflect.Descriptor instead.
+func (*TabbedContainer) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *TabbedContainer) GetTabs() []*Tab {
+	if x != nil {
+		return x.Tabs
+	}
+	return nil
+}
+
+type Tab struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NodeId       uint32 `protobuf:"varint,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	ClassName    string `protobuf:"bytes,2,opt,name=class_name,json=className,proto3" json:"class_name,omitempty"`
+	InstanceName string `protobuf:"bytes,3,opt,name=instance_name,json=instanceName,proto3" json:"instance_name,omitempty"`
+	// Empty if the window's title couldn't be read.
+	Title      string `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	IsSelected bool   `protobuf:"varint,5,opt,name=is_selected,json=isSelected,proto3" json:"is_selected,omitempty"`
+}
+
+func (x *Tab) Reset() {
+	*x = Tab{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Tab) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tab) ProtoMessage() {}
+
+func (x *Tab) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tab.ProtoReflect.Descriptor instead.
+func (*Tab) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Tab) GetNodeId() uint32 {
+	if x != nil {
+		return x.NodeId
+	}
+	return 0
+}
+
+func (x *Tab) GetClassName() string {
+	if x != nil {
+		return x.ClassName
+	}
+	return ""
+}
+
+func (x *Tab) GetInstanceName() string {
+	if x != nil {
+		return x.InstanceName
+	}
+	return ""
+}
+
+func (x *Tab) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Tab) GetIsSelected() bool {
+	if x != nil {
+		return x.IsSelected
+	}
+	return false
+}
+
+type MinimizedState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// In the order they were minimised in.
+	Windows []*MinimizedWindow `protobuf:"bytes,1,rep,name=windows,proto3" json:"windows,omitempty"`
+}
+
+func (x *MinimizedState) Reset() {
+	*x = MinimizedState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MinimizedState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MinimizedState) ProtoMessage() {}
+
+func (x *MinimizedState) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MinimizedState.ProtoReflect.Descriptor instead.
+func (*MinimizedState) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *MinimizedState) GetWindows() []*MinimizedWindow {
+	if x != nil {
+		return x.Windows
+	}
+	return nil
+}
+
+type MinimizedWindow struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NodeId       uint32 `protobuf:"varint,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	DesktopId    uint32 `protobuf:"varint,2,opt,name=desktop_id,json=desktopId,proto3" json:"desktop_id,omitempty"`
+	ClassName    string `protobuf:"bytes,3,opt,name=class_name,json=className,proto3" json:"class_name,omitempty"`
+	InstanceName string `protobuf:"bytes,4,opt,name=instance_name,json=instanceName,proto3" json:"instance_name,omitempty"`
+	// Empty if the window's title couldn't be read.
+	Title string `protobuf:"bytes,5,opt,name=title,proto3" json:"title,omitempty"`
+}
+
+func (x *MinimizedWindow) Reset() {
+	*x = MinimizedWindow{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MinimizedWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MinimizedWindow) ProtoMessage() {}
+
+func (x *MinimizedWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MinimizedWindow.ProtoReflect.Descriptor instead.
+func (*MinimizedWindow) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *MinimizedWindow) GetNodeId() uint32 {
+	if x != nil {
+		return x.NodeId
+	}
+	return 0
+}
+
+func (x *MinimizedWindow) GetDesktopId() uint32 {
+	if x != nil {
+		return x.DesktopId
+	}
+	return 0
+}
+
+func (x *MinimizedWindow) GetClassName() string {
+	if x != nil {
+		return x.ClassName
+	}
+	return ""
+}
+
+func (x *MinimizedWindow) GetInstanceName() string {
+	if x != nil {
+		return x.InstanceName
+	}
+	return ""
+}
+
+func (x *MinimizedWindow) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+type EventsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// bspwm event types (e.g. "node_add") to stream. All events are streamed if empty.
+	EventTypes []string `protobuf:"bytes,1,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+}
+
+func (x *EventsRequest) Reset() {
+	*x = EventsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventsRequest) ProtoMessage() {}
+
+func (x *EventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventsRequest.ProtoReflect.Descriptor instead.
+func (*EventsRequest) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *EventsRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+type EventsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EventType string `protobuf:"bytes,1,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	// JSON-encoded event payload.
+	Payload string       `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	Nodes   []*EventNode `protobuf:"bytes,3,rep,name=nodes,proto3" json:"nodes,omitempty"`
+}
+
+func (x *EventsResponse) Reset() {
+	*x = EventsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventsResponse) ProtoMessage() {}
+
+func (x *EventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventsResponse.ProtoReflect.Descriptor instead.
+func (*EventsResponse) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *EventsResponse) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *EventsResponse) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *EventsResponse) GetNodes() []*EventNode {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+type EventNode struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ClassName    string `protobuf:"bytes,2,opt,name=class_name,json=className,proto3" json:"class_name,omitempty"`
+	InstanceName string `protobuf:"bytes,3,opt,name=instance_name,json=instanceName,proto3" json:"instance_name,omitempty"`
+	Title        string `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+}
+
+func (x *EventNode) Reset() {
+	*x = EventNode{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EventNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventNode) ProtoMessage() {}
+
+func (x *EventNode) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventNode.ProtoReflect.Descriptor instead.
+func (*EventNode) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *EventNode) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *EventNode) GetClassName() string {
+	if x != nil {
+		return x.ClassName
+	}
+	return ""
+}
+
+func (x *EventNode) GetInstanceName() string {
+	if x != nil {
+		return x.InstanceName
+	}
+	return ""
+}
+
+func (x *EventNode) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+type MetricsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Topics    []*TopicMetrics    `protobuf:"bytes,1,rep,name=topics,proto3" json:"topics,omitempty"`
+	Callbacks []*CallbackMetrics `protobuf:"bytes,2,rep,name=callbacks,proto3" json:"callbacks,omitempty"`
+}
+
+func (x *MetricsResponse) Reset() {
+	*x = MetricsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MetricsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetricsResponse) ProtoMessage() {}
+
+func (x *MetricsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetricsResponse.ProtoReflect.Descriptor instead.
+func (*MetricsResponse) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *MetricsResponse) GetTopics() []*TopicMetrics {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+func (x *MetricsResponse) GetCallbacks() []*CallbackMetrics {
+	if x != nil {
+		return x.Callbacks
+	}
+	return nil
+}
+
+type TopicMetrics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Internal topic name (e.g. "bspwm/event").
+	Topic string `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	// Messages subscribers missed because they weren't keeping up.
+	Dropped uint64 `protobuf:"varint,2,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	// Subscribers disconnected because they weren't keeping up.
+	Disconnected uint64 `protobuf:"varint,3,opt,name=disconnected,proto3" json:"disconnected,omitempty"`
+}
+
+func (x *TopicMetrics) Reset() {
+	*x = TopicMetrics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopicMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopicMetrics) ProtoMessage() {}
+
+func (x *TopicMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopicMetrics.ProtoReflect.Descriptor instead.
+func (*TopicMetrics) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *TopicMetrics) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *TopicMetrics) GetDropped() uint64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+func (x *TopicMetrics) GetDisconnected() uint64 {
+	if x != nil {
+		return x.Disconnected
+	}
+	return 0
+}
+
+type CallbackMetrics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// bspwm event type the callbacks handle (e.g. "node_add").
+	EventType string `protobuf:"bytes,1,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	// Times the callbacks were called.
+	Calls uint64 `protobuf:"varint,2,opt,name=calls,proto3" json:"calls,omitempty"`
+	// Time spent running the callbacks, in total and in the slowest call.
+	Total *durationpb.Duration `protobuf:"bytes,3,opt,name=total,proto3" json:"total,omitempty"`
+	Max   *durationpb.Duration `protobuf:"bytes,4,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (x *CallbackMetrics) Reset() {
+	*x = CallbackMetrics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspm_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CallbackMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallbackMetrics) ProtoMessage() {}
+
+func (x *CallbackMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_bspm_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallbackMetrics.ProtoReflect.Descriptor instead.
+func (*CallbackMetrics) Descriptor() ([]byte, []int) {
+	return file_bspm_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CallbackMetrics) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *CallbackMetrics) GetCalls() uint64 {
+	if x != nil {
+		return x.Calls
+	}
+	return 0
+}
+
+func (x *CallbackMetrics) GetTotal() *durationpb.Duration {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *CallbackMetrics) GetMax() *durationpb.Duration {
+	if x != nil {
+		return x.Max
+	}
+	return nil
+}
+
+var File_bspm_proto protoreflect.FileDescriptor
+
+var file_bspm_proto_rawDesc = []byte{
+	0x0a, 0x0a, 0x62, 0x73, 0x70, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x69, 0x70,
+	0x63, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0x66, 0x0a, 0x17, 0x53, 0x63, 0x72, 0x61, 0x74, 0x63, 0x68, 0x70, 0x61, 0x64, 0x54, 0x6f, 0x67,
+	0x67, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x22, 0x26, 0x0a, 0x10, 0x4c, 0x61, 0x79, 0x6f, 0x75,
+	0x74, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22,
+	0x36, 0x0a, 0x1e, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x22, 0x30, 0x0a, 0x18, 0x4c, 0x61, 0x79, 0x6f, 0x75,
+	0x74, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x22, 0x4c, 0x0a, 0x12, 0x54, 0x61, 0x62,
+	0x62, 0x65, 0x64, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x36, 0x0a, 0x0f, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x43,
+	0x79, 0x63, 0x6c, 0x65, 0x44, 0x69, 0x72, 0x52, 0x0e, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x44, 0x69,
+	0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x2e, 0x0a, 0x13, 0x54, 0x61, 0x62, 0x62, 0x65,
+	0x64, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17,
+	0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x22, 0x5f, 0x0a, 0x16, 0x4d, 0x69, 0x6e, 0x69, 0x6d,
+	0x69, 0x7a, 0x65, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x74, 0x6f,
+	0x5f, 0x66, 0x6f, 0x63, 0x75, 0x73, 0x65, 0x64, 0x5f, 0x64, 0x65, 0x73, 0x6b, 0x74, 0x6f, 0x70,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x74, 0x6f, 0x46, 0x6f, 0x63, 0x75, 0x73, 0x65,
+	0x64, 0x44, 0x65, 0x73, 0x6b, 0x74, 0x6f, 0x70, 0x22, 0x24, 0x0a, 0x0e, 0x4d, 0x61, 0x72, 0x6b,
+	0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x61,
+	0x72, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x61, 0x72, 0x6b, 0x22, 0x25,
+	0x0a, 0x0f, 0x4d, 0x61, 0x72, 0x6b, 0x4a, 0x75, 0x6d, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x61, 0x72, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6d, 0x61, 0x72, 0x6b, 0x22, 0x4f, 0x0a, 0x15, 0x46, 0x6f, 0x63, 0x75, 0x73, 0x43, 0x79,
+	0x63, 0x6c, 0x65, 0x53, 0x74, 0x65, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x36,
+	0x0a, 0x0f, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x43, 0x79,
+	0x63, 0x6c, 0x65, 0x44, 0x69, 0x72, 0x52, 0x0e, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x44, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x4c, 0x0a, 0x18, 0x46, 0x6f, 0x63, 0x75, 0x73, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x30, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x46, 0x6f, 0x63, 0x75, 0x73, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74,
+	0x72, 0x69, 0x65, 0x73, 0x22, 0xfd, 0x01, 0x0a, 0x11, 0x46, 0x6f, 0x63, 0x75, 0x73, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f,
+	0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6e, 0x6f, 0x64,
+	0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x65, 0x73, 0x6b, 0x74, 0x6f, 0x70, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x64, 0x65, 0x73, 0x6b, 0x74, 0x6f, 0x70,
+	0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x39, 0x0a, 0x0a,
+	0x66, 0x6f, 0x63, 0x75, 0x73, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x66, 0x6f,
+	0x63, 0x75, 0x73, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x73, 0x5f, 0x68, 0x69,
+	0x64, 0x64, 0x65, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x73, 0x48, 0x69,
+	0x64, 0x64, 0x65, 0x6e, 0x22, 0x51, 0x0a, 0x17, 0x4d, 0x6f, 0x6e, 0x6f, 0x63, 0x6c, 0x65, 0x4d,
+	0x6f, 0x64, 0x65, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x36, 0x0a, 0x0f, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x43,
+	0x79, 0x63, 0x6c, 0x65, 0x44, 0x69, 0x72, 0x52, 0x0e, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x44, 0x69,
+	0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x53, 0x0a, 0x1b, 0x4d, 0x6f, 0x6e, 0x6f, 0x63,
+	0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x6f, 0x63,
+	0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x54, 0x0a, 0x1c,
+	0x4d, 0x6f, 0x6e, 0x6f, 0x63, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x53, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0a,
+	0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x48, 0x00, 0x52, 0x09, 0x6e, 0x6f, 0x64, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x13, 0x0a,
+	0x11, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x79,
+	0x70, 0x65, 0x22, 0x57, 0x0a, 0x10, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x0a, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x54, 0x6f, 0x70,
+	0x69, 0x63, 0x52, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x65,
+	0x73, 0x6b, 0x74, 0x6f, 0x70, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52,
+	0x0a, 0x64, 0x65, 0x73, 0x6b, 0x74, 0x6f, 0x70, 0x49, 0x64, 0x73, 0x22, 0xeb, 0x03, 0x0a, 0x11,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x20, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x0a, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x05, 0x74, 0x6f,
+	0x70, 0x69, 0x63, 0x12, 0x38, 0x0a, 0x0d, 0x6d, 0x6f, 0x6e, 0x6f, 0x63, 0x6c, 0x65, 0x5f, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x69, 0x70, 0x63,
+	0x2e, 0x4d, 0x6f, 0x6e, 0x6f, 0x63, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52,
+	0x0c, 0x6d, 0x6f, 0x6e, 0x6f, 0x63, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x38, 0x0a,
+	0x0d, 0x64, 0x65, 0x73, 0x6b, 0x74, 0x6f, 0x70, 0x5f, 0x66, 0x6f, 0x63, 0x75, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x44, 0x65, 0x73, 0x6b, 0x74,
+	0x6f, 0x70, 0x46, 0x6f, 0x63, 0x75, 0x73, 0x48, 0x00, 0x52, 0x0c, 0x64, 0x65, 0x73, 0x6b, 0x74,
+	0x6f, 0x70, 0x46, 0x6f, 0x63, 0x75, 0x73, 0x12, 0x44, 0x0a, 0x11, 0x62, 0x73, 0x70, 0x77, 0x6d,
+	0x5f, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x42, 0x73, 0x70, 0x77, 0x6d, 0x52, 0x65,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x10, 0x62, 0x73, 0x70,
+	0x77, 0x6d, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x41, 0x0a,
+	0x10, 0x73, 0x63, 0x72, 0x61, 0x74, 0x63, 0x68, 0x70, 0x61, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x53, 0x63,
+	0x72, 0x61, 0x74, 0x63, 0x68, 0x70, 0x61, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52,
+	0x0f, 0x73, 0x63, 0x72, 0x61, 0x74, 0x63, 0x68, 0x70, 0x61, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x35, 0x0a, 0x0c, 0x6c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x4c, 0x61, 0x79,
+	0x6f, 0x75, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x6c, 0x61, 0x79, 0x6f,
+	0x75, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x35, 0x0a, 0x0c, 0x74, 0x61, 0x62, 0x62, 0x65,
+	0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x69, 0x70, 0x63, 0x2e, 0x54, 0x61, 0x62, 0x62, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x48,
+	0x00, 0x52, 0x0b, 0x74, 0x61, 0x62, 0x62, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x3e,
+	0x0a, 0x0f, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x4d, 0x69,
+	0x6e, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x0e,
+	0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x42, 0x09,
+	0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x7f, 0x0a, 0x0c, 0x4d, 0x6f, 0x6e,
+	0x6f, 0x63, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x65, 0x73,
+	0x6b, 0x74, 0x6f, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x64,
+	0x65, 0x73, 0x6b, 0x74, 0x6f, 0x70, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x73, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0e, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65,
+	0x49, 0x64, 0x12, 0x26, 0x0a, 0x0f, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x6e, 0x6f, 0x64,
+	0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0d, 0x68, 0x69, 0x64,
+	0x64, 0x65, 0x6e, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x73, 0x22, 0x4c, 0x0a, 0x0c, 0x44, 0x65,
+	0x73, 0x6b, 0x74, 0x6f, 0x70, 0x46, 0x6f, 0x63, 0x75, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x6f,
+	0x6e, 0x69, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09,
+	0x6d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x65, 0x73,
+	0x6b, 0x74, 0x6f, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x64,
+	0x65, 0x73, 0x6b, 0x74, 0x6f, 0x70, 0x49, 0x64, 0x22, 0x2e, 0x0a, 0x10, 0x42, 0x73, 0x70, 0x77,
+	0x6d, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08,
+	0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x22, 0x96, 0x01, 0x0a, 0x0f, 0x53, 0x63, 0x72,
+	0x61, 0x74, 0x63, 0x68, 0x70, 0x61, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x64,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65,
+	0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x76, 0x69, 0x73, 0x69, 0x62, 0x6c, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x73, 0x56, 0x69, 0x73, 0x69, 0x62, 0x6c,
+	0x65, 0x22, 0x79, 0x0a, 0x0b, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x65, 0x73, 0x6b, 0x74, 0x6f, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x64, 0x65, 0x73, 0x6b, 0x74, 0x6f, 0x70, 0x49, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6d, 0x61, 0x73, 0x74, 0x65,
+	0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x22, 0x62, 0x0a, 0x0b,
+	0x54, 0x61, 0x62, 0x62, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x64,
+	0x65, 0x73, 0x6b, 0x74, 0x6f, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x09, 0x64, 0x65, 0x73, 0x6b, 0x74, 0x6f, 0x70, 0x49, 0x64, 0x12, 0x34, 0x0a, 0x0a, 0x63, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x69, 0x70, 0x63, 0x2e, 0x54, 0x61, 0x62, 0x62, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73,
+	0x22, 0x2f, 0x0a, 0x0f, 0x54, 0x61, 0x62, 0x62, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x04, 0x74, 0x61, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x08, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x54, 0x61, 0x62, 0x52, 0x04, 0x74, 0x61, 0x62,
+	0x73, 0x22, 0x99, 0x01, 0x0a, 0x03, 0x54, 0x61, 0x62, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x64,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65,
+	0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x1f, 0x0a, 0x0b,
+	0x69, 0x73, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0a, 0x69, 0x73, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x22, 0x40, 0x0a,
+	0x0e, 0x4d, 0x69, 0x6e, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12,
+	0x2e, 0x0a, 0x07, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x4d, 0x69, 0x6e, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x64,
+	0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x52, 0x07, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x22,
+	0xa3, 0x01, 0x0a, 0x0f, 0x4d, 0x69, 0x6e, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x64, 0x57, 0x69, 0x6e,
+	0x64, 0x6f, 0x77, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a,
+	0x64, 0x65, 0x73, 0x6b, 0x74, 0x6f, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x09, 0x64, 0x65, 0x73, 0x6b, 0x74, 0x6f, 0x70, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63,
+	0x6c, 0x61, 0x73, 0x73, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x74, 0x69, 0x74, 0x6c, 0x65, 0x22, 0x30, 0x0a, 0x0d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73, 0x22, 0x6f, 0x0a, 0x0e, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f,
+	0x61, 0x64, 0x12, 0x24, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0e, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4e, 0x6f, 0x64,
+	0x65, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x75, 0x0a, 0x09, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6c, 0x61, 0x73, 0x73,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74,
+	0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x22,
+	0x70, 0x0a, 0x0f, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x29, 0x0a, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x4d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x12, 0x32, 0x0a,
+	0x09, 0x63, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x4d,
+	0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x09, 0x63, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b,
+	0x73, 0x22, 0x62, 0x0a, 0x0c, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70,
+	0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65,
+	0x64, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x22, 0xa4, 0x01, 0x0a, 0x0f, 0x43, 0x61, 0x6c, 0x6c, 0x62, 0x61,
+	0x63, 0x6b, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x61, 0x6c, 0x6c,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x12, 0x2f,
+	0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12,
+	0x2b, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x2a, 0xad, 0x02, 0x0a,
+	0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x11, 0x0a, 0x0d, 0x54, 0x4f, 0x50, 0x49, 0x43, 0x5f,
+	0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x54, 0x4f, 0x50,
+	0x49, 0x43, 0x5f, 0x4d, 0x4f, 0x4e, 0x4f, 0x43, 0x4c, 0x45, 0x5f, 0x45, 0x4e, 0x41, 0x42, 0x4c,
+	0x45, 0x44, 0x10, 0x01, 0x12, 0x1a, 0x0a, 0x16, 0x54, 0x4f, 0x50, 0x49, 0x43, 0x5f, 0x4d, 0x4f,
+	0x4e, 0x4f, 0x43, 0x4c, 0x45, 0x5f, 0x44, 0x49, 0x53, 0x41, 0x42, 0x4c, 0x45, 0x44, 0x10, 0x02,
+	0x12, 0x1f, 0x0a, 0x1b, 0x54, 0x4f, 0x50, 0x49, 0x43, 0x5f, 0x4d, 0x4f, 0x4e, 0x4f, 0x43, 0x4c,
+	0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10,
+	0x03, 0x12, 0x27, 0x0a, 0x23, 0x54, 0x4f, 0x50, 0x49, 0x43, 0x5f, 0x4d, 0x4f, 0x4e, 0x4f, 0x43,
+	0x4c, 0x45, 0x5f, 0x44, 0x45, 0x53, 0x4b, 0x54, 0x4f, 0x50, 0x5f, 0x46, 0x4f, 0x43, 0x55, 0x53,
+	0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10, 0x04, 0x12, 0x1b, 0x0a, 0x17, 0x54, 0x4f,
+	0x50, 0x49, 0x43, 0x5f, 0x42, 0x53, 0x50, 0x57, 0x4d, 0x5f, 0x52, 0x45, 0x43, 0x4f, 0x4e, 0x4e,
+	0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x05, 0x12, 0x22, 0x0a, 0x1e, 0x54, 0x4f, 0x50, 0x49, 0x43,
+	0x5f, 0x53, 0x43, 0x52, 0x41, 0x54, 0x43, 0x48, 0x50, 0x41, 0x44, 0x5f, 0x53, 0x54, 0x41, 0x54,
+	0x45, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10, 0x06, 0x12, 0x18, 0x0a, 0x14, 0x54,
+	0x4f, 0x50, 0x49, 0x43, 0x5f, 0x4c, 0x41, 0x59, 0x4f, 0x55, 0x54, 0x5f, 0x43, 0x48, 0x41, 0x4e,
+	0x47, 0x45, 0x44, 0x10, 0x07, 0x12, 0x18, 0x0a, 0x14, 0x54, 0x4f, 0x50, 0x49, 0x43, 0x5f, 0x54,
+	0x41, 0x42, 0x42, 0x45, 0x44, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10, 0x08, 0x12,
+	0x1b, 0x0a, 0x17, 0x54, 0x4f, 0x50, 0x49, 0x43, 0x5f, 0x4d, 0x49, 0x4e, 0x49, 0x4d, 0x49, 0x5a,
+	0x45, 0x44, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10, 0x09, 0x2a, 0x78, 0x0a, 0x1b,
+	0x4d, 0x6f, 0x6e, 0x6f, 0x63, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x53, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2a, 0x0a, 0x26, 0x4d,
+	0x4f, 0x4e, 0x4f, 0x43, 0x4c, 0x45, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x53, 0x55, 0x42, 0x53,
+	0x43, 0x52, 0x49, 0x50, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x49, 0x4e,
+	0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x00, 0x12, 0x2d, 0x0a, 0x29, 0x4d, 0x4f, 0x4e, 0x4f, 0x43,
+	0x4c, 0x45, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x53, 0x55, 0x42, 0x53, 0x43, 0x52, 0x49, 0x50,
+	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4e, 0x4f, 0x44, 0x45, 0x5f, 0x43,
+	0x4f, 0x55, 0x4e, 0x54, 0x10, 0x01, 0x2a, 0x49, 0x0a, 0x08, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x44,
+	0x69, 0x72, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x59, 0x43, 0x4c, 0x45, 0x5f, 0x44, 0x49, 0x52, 0x5f,
+	0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x59, 0x43,
+	0x4c, 0x45, 0x5f, 0x44, 0x49, 0x52, 0x5f, 0x50, 0x52, 0x45, 0x56, 0x10, 0x01, 0x12, 0x12, 0x0a,
+	0x0e, 0x43, 0x59, 0x43, 0x4c, 0x45, 0x5f, 0x44, 0x49, 0x52, 0x5f, 0x4e, 0x45, 0x58, 0x54, 0x10,
+	0x02, 0x32, 0xa0, 0x03, 0x0a, 0x04, 0x42, 0x53, 0x50, 0x4d, 0x12, 0x43, 0x0a, 0x11, 0x4d, 0x6f,
+	0x6e, 0x6f, 0x63, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x54, 0x6f, 0x67, 0x67, 0x6c, 0x65, 0x12,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12,
+	0x48, 0x0a, 0x10, 0x4d, 0x6f, 0x6e, 0x6f, 0x63, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x43, 0x79,
+	0x63, 0x6c, 0x65, 0x12, 0x1c, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x6f, 0x63, 0x6c,
+	0x65, 0x4d, 0x6f, 0x64, 0x65, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x5d, 0x0a, 0x14, 0x4d, 0x6f, 0x6e,
+	0x6f, 0x63, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x12, 0x20, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x6f, 0x63, 0x6c, 0x65, 0x4d,
+	0x6f, 0x64, 0x65, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x6f, 0x63, 0x6c,
+	0x65, 0x4d, 0x6f, 0x64, 0x65, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x3c, 0x0a, 0x09, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x15, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x69,
+	0x70, 0x63, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x33, 0x0a, 0x06, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73,
+	0x12, 0x12, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x37, 0x0a, 0x07, 0x4d,
+	0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x14,
+	0x2e, 0x69, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x32, 0x56, 0x0a, 0x0a, 0x53, 0x63, 0x72, 0x61, 0x74, 0x63, 0x68, 0x70,
+	0x61, 0x64, 0x12, 0x48, 0x0a, 0x10, 0x53, 0x63, 0x72, 0x61, 0x74, 0x63, 0x68, 0x70, 0x61, 0x64,
+	0x54, 0x6f, 0x67, 0x67, 0x6c, 0x65, 0x12, 0x1c, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x53, 0x63, 0x72,
+	0x61, 0x74, 0x63, 0x68, 0x70, 0x61, 0x64, 0x54, 0x6f, 0x67, 0x67, 0x6c, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x32, 0xa6, 0x03, 0x0a,
+	0x06, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x12, 0x3a, 0x0a, 0x09, 0x4c, 0x61, 0x79, 0x6f, 0x75,
+	0x74, 0x53, 0x65, 0x74, 0x12, 0x15, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x4c, 0x61, 0x79, 0x6f, 0x75,
+	0x74, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x12, 0x3c, 0x0a, 0x0a, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x4e, 0x65, 0x78,
+	0x74, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x12, 0x3d, 0x0a, 0x0b, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x55, 0x6e, 0x73, 0x65, 0x74,
+	0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x12, 0x3f, 0x0a, 0x0d, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74,
+	0x65, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x12, 0x56, 0x0a, 0x17, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x4d, 0x61, 0x73, 0x74, 0x65,
+	0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x23, 0x2e, 0x69,
+	0x70, 0x63, 0x2e, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x4a, 0x0a, 0x11, 0x4c, 0x61, 0x79,
+	0x6f, 0x75, 0x74, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1d,
+	0x2e, 0x69, 0x70, 0x63, 0x2e, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x52, 0x61, 0x74, 0x69, 0x6f,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x32, 0xca, 0x01, 0x0a, 0x06, 0x54, 0x61, 0x62, 0x62, 0x65, 0x64,
+	0x12, 0x3e, 0x0a, 0x0c, 0x54, 0x61, 0x62, 0x62, 0x65, 0x64, 0x54, 0x6f, 0x67, 0x67, 0x6c, 0x65,
+	0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x12, 0x3e, 0x0a, 0x0b, 0x54, 0x61, 0x62, 0x62, 0x65, 0x64, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x12,
+	0x17, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x54, 0x61, 0x62, 0x62, 0x65, 0x64, 0x43, 0x79, 0x63, 0x6c,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x12, 0x40, 0x0a, 0x0c, 0x54, 0x61, 0x62, 0x62, 0x65, 0x64, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x12, 0x18, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x54, 0x61, 0x62, 0x62, 0x65, 0x64, 0x53, 0x65, 0x6c,
+	0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x32, 0x95, 0x01, 0x0a, 0x08, 0x4d, 0x69, 0x6e, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x12,
+	0x41, 0x0a, 0x0f, 0x4d, 0x69, 0x6e, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x46, 0x6f, 0x63, 0x75, 0x73,
+	0x65, 0x64, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x12, 0x46, 0x0a, 0x0f, 0x4d, 0x69, 0x6e, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x52, 0x65,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x1b, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x4d, 0x69, 0x6e, 0x69,
+	0x6d, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x32, 0x78, 0x0a, 0x04, 0x4d, 0x61,
+	0x72, 0x6b, 0x12, 0x36, 0x0a, 0x07, 0x4d, 0x61, 0x72, 0x6b, 0x53, 0x65, 0x74, 0x12, 0x13, 0x2e,
+	0x69, 0x70, 0x63, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x38, 0x0a, 0x08, 0x4d, 0x61,
+	0x72, 0x6b, 0x4a, 0x75, 0x6d, 0x70, 0x12, 0x14, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x4d, 0x61, 0x72,
+	0x6b, 0x4a, 0x75, 0x6d, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x32, 0xa3, 0x03, 0x0a, 0x0c, 0x46, 0x6f, 0x63, 0x75, 0x73, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x3b, 0x0a, 0x09, 0x46, 0x6f, 0x63, 0x75, 0x73, 0x42, 0x61,
+	0x63, 0x6b, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x12, 0x3e, 0x0a, 0x0c, 0x46, 0x6f, 0x63, 0x75, 0x73, 0x46, 0x6f, 0x72, 0x77, 0x61,
+	0x72, 0x64, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x12, 0x41, 0x0a, 0x0f, 0x46, 0x6f, 0x63, 0x75, 0x73, 0x43, 0x79, 0x63, 0x6c, 0x65,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x44, 0x0a, 0x0e, 0x46, 0x6f, 0x63, 0x75, 0x73, 0x43, 0x79,
+	0x63, 0x6c, 0x65, 0x53, 0x74, 0x65, 0x70, 0x12, 0x1a, 0x2e, 0x69, 0x70, 0x63, 0x2e, 0x46, 0x6f,
+	0x63, 0x75, 0x73, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x53, 0x74, 0x65, 0x70, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x42, 0x0a, 0x10, 0x46,
+	0x6f, 0x63, 0x75, 0x73, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12,
+	0x49, 0x0a, 0x10, 0x46, 0x6f, 0x63, 0x75, 0x73, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x4c,
+	0x69, 0x73, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1d, 0x2e, 0x69, 0x70,
+	0x63, 0x2e, 0x46, 0x6f, 0x63, 0x75, 0x73, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x4c, 0x69,
+	0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x09, 0x5a, 0x07, 0x2e, 0x2f,
+	0x3b, 0x62, 0x73, 0x70, 0x6d, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_bspm_proto_rawDescOnce sync.Once
+	file_bspm_proto_rawDescData = file_bspm_proto_rawDesc
+)
+
+func file_bspm_proto_rawDescGZIP() []byte {
+	file_bspm_proto_rawDescOnce.Do(func() {
+		file_bspm_proto_rawDescData = protoimpl.X.CompressGZIP(file_bspm_proto_rawDescData)
+	})
+	return file_bspm_proto_rawDescData
+}
+
+var file_bspm_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_bspm_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
+var file_bspm_proto_goTypes = []interface{}{
+	(Topic)(0),                             // 0: ipc.Topic
+	(MonocleModeSubscriptionType)(0),       // 1: ipc.MonocleModeSubscriptionType
+	(CycleDir)(0),                          // 2: ipc.CycleDir
+	(*ScratchpadToggleRequest)(nil),        // 3: ipc.ScratchpadToggleRequest
+	(*LayoutSetRequest)(nil),               // 4: ipc.LayoutSetRequest
+	(*LayoutMasterCountChangeRequest)(nil), // 5: ipc.LayoutMasterCountChangeRequest
+	(*LayoutRatioChangeRequest)(nil),       // 6: ipc.LayoutRatioChangeRequest
+	(*TabbedCycleRequest)(nil),             // 7: ipc.TabbedCycleRequest
+	(*TabbedSelectRequest)(nil),            // 8: ipc.TabbedSelectRequest
+	(*MinimizeRestoreRequest)(nil),         // 9: ipc.MinimizeRestoreRequest
+	(*MarkSetRequest)(nil),                 // 10: ipc.MarkSetRequest
+	(*MarkJumpRequest)(nil),                // 11: ipc.MarkJumpRequest
+	(*FocusCycleStepRequest)(nil),          // 12: ipc.FocusCycleStepRequest
+	(*FocusHistoryListResponse)(nil),       // 13: ipc.FocusHistoryListResponse
+	(*FocusHistoryEntry)(nil),              // 14: ipc.FocusHistoryEntry
+	(*MonocleModeCycleRequest)(nil),        // 15: ipc.MonocleModeCycleRequest
+	(*MonocleModeSubscribeRequest)(nil),    // 16: ipc.MonocleModeSubscribeRequest
+	(*MonocleModeSubscribeResponse)(nil),   // 17: ipc.MonocleModeSubscribeResponse
+	(*SubscribeRequest)(nil),               // 18: ipc.SubscribeRequest
+	(*SubscribeResponse)(nil),              // 19: ipc.SubscribeResponse
+	(*MonocleState)(nil),                   // 20: ipc.MonocleState
+	(*DesktopFocus)(nil),                   // 21: ipc.DesktopFocus
+	(*BspwmReconnected)(nil),               // 22: ipc.BspwmReconnected
+	(*ScratchpadState)(nil),                // 23: ipc.ScratchpadState
+	(*LayoutState)(nil),                    // 24: ipc.LayoutState
+	(*TabbedState)(nil),                    // 25: ipc.TabbedState
+	(*TabbedContainer)(nil),                // 26: ipc.TabbedContainer
+	(*Tab)(nil),                            // 27: ipc.Tab
+	(*MinimizedState)(nil),                 // 28: ipc.MinimizedState
+	(*MinimizedWindow)(nil),                // 29: ipc.MinimizedWindow
+	(*EventsRequest)(nil),                  // 30: ipc.EventsRequest
+	(*EventsResponse)(nil),                 // 31: ipc.EventsResponse
+	(*EventNode)(nil),                      // 32: ipc.EventNode
+	(*MetricsResponse)(nil),                // 33: ipc.MetricsResponse
+	(*TopicMetrics)(nil),                   // 34: ipc.TopicMetrics
+	(*CallbackMetrics)(nil),                // 35: ipc.CallbackMetrics
+	(*timestamppb.Timestamp)(nil),          // 36: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),            // 37: google.protobuf.Duration
+	(*empty.Empty)(nil),                    // 38: google.protobuf.Empty
+}
+var file_bspm_proto_depIdxs = []int32{
+	2,  // 0: ipc.TabbedCycleRequest.cycle_direction:type_name -> ipc.CycleDir
+	2,  // 1: ipc.FocusCycleStepRequest.cycle_direction:type_name -> ipc.CycleDir
+	14, // 2: ipc.FocusHistoryListResponse.entries:type_name -> ipc.FocusHistoryEntry
+	36, // 3: ipc.FocusHistoryEntry.focused_at:type_name -> google.protobuf.Timestamp
+	2,  // 4: ipc.MonocleModeCycleRequest.cycle_direction:type_name -> ipc.CycleDir
+	1,  // 5: ipc.MonocleModeSubscribeRequest.type:type_name -> ipc.MonocleModeSubscriptionType
+	0,  // 6: ipc.SubscribeRequest.topics:type_name -> ipc.Topic
+	0,  // 7: ipc.SubscribeResponse.topic:type_name -> ipc.Topic
+	20, // 8: ipc.SubscribeResponse.monocle_state:type_name -> ipc.MonocleState
+	21, // 9: ipc.SubscribeResponse.desktop_focus:type_name -> ipc.DesktopFocus
+	22, // 10: ipc.SubscribeResponse.bspwm_reconnected:type_name -> ipc.BspwmReconnected
+	23, // 11: ipc.SubscribeResponse.scratchpad_state:type_name -> ipc.ScratchpadState
+	24, // 12: ipc.SubscribeResponse.layout_state:type_name -> ipc.LayoutState
+	25, // 13: ipc.SubscribeResponse.tabbed_state:type_name -> ipc.TabbedState
+	28, // 14: ipc.SubscribeResponse.minimized_state:type_name -> ipc.MinimizedState
+	26, // 15: ipc.TabbedState.containers:type_name -> ipc.TabbedContainer
+	27, // 16: ipc.TabbedContainer.tabs:type_name -> ipc.Tab
+	29, // 17: ipc.MinimizedState.windows:type_name -> ipc.MinimizedWindow
+	32, // 18: ipc.EventsResponse.nodes:type_name -> ipc.EventNode
+	34, // 19: ipc.MetricsResponse.topics:type_name -> ipc.TopicMetrics
+	35, // 20: ipc.MetricsResponse.callbacks:type_name -> ipc.CallbackMetrics
+	37, // 21: ipc.CallbackMetrics.total:type_name -> google.protobuf.Duration
+	37, // 22: ipc.CallbackMetrics.max:type_name -> google.protobuf.Duration
+	38, // 23: ipc.BSPM.MonocleModeToggle:input_type -> google.protobuf.Empty
+	15, // 24: ipc.BSPM.MonocleModeCycle:input_type -> ipc.MonocleModeCycleRequest
+	16, // 25: ipc.BSPM.MonocleModeSubscribe:input_type -> ipc.MonocleModeSubscribeRequest
+	18, // 26: ipc.BSPM.Subscribe:input_type -> ipc.SubscribeRequest
+	30, // 27: ipc.BSPM.Events:input_type -> ipc.EventsRequest
+	38, // 28: ipc.BSPM.Metrics:input_type -> google.protobuf.Empty
+	3,  // 29: ipc.Scratchpad.ScratchpadToggle:input_type -> ipc.ScratchpadToggleRequest
+	4,  // 30: ipc.Layout.LayoutSet:input_type -> ipc.LayoutSetRequest
+	38, // 31: ipc.Layout.LayoutNext:input_type -> google.protobuf.Empty
+	38, // 32: ipc.Layout.LayoutUnset:input_type -> google.protobuf.Empty
+	38, // 33: ipc.Layout.LayoutPromote:input_type -> google.protobuf.Empty
+	5,  // 34: ipc.Layout.LayoutMasterCountChange:input_type -> ipc.LayoutMasterCountChangeRequest
+	6,  // 35: ipc.Layout.LayoutRatioChange:input_type -> ipc.LayoutRatioChangeRequest
+	38, // 36: ipc.Tabbed.TabbedToggle:input_type -> google.protobuf.Empty
+	7,  // 37: ipc.Tabbed.TabbedCycle:input_type -> ipc.TabbedCycleRequest
+	8,  // 38: ipc.Tabbed.TabbedSelect:input_type -> ipc.TabbedSelectRequest
+	38, // 39: ipc.Minimize.MinimizeFocused:input_type -> google.protobuf.Empty
+	9,  // 40: ipc.Minimize.MinimizeRestore:input_type -> ipc.MinimizeRestoreRequest
+	10, // 41: ipc.Mark.MarkSet:input_type -> ipc.MarkSetRequest
+	11, // 42: ipc.Mark.MarkJump:input_type -> ipc.MarkJumpRequest
+	38, // 43: ipc.FocusHistory.FocusBack:input_type -> google.protobuf.Empty
+	38, // 44: ipc.FocusHistory.FocusForward:input_type -> google.protobuf.Empty
+	38, // 45: ipc.FocusHistory.FocusCycleStart:input_type -> google.protobuf.Empty
+	12, // 46: ipc.FocusHistory.FocusCycleStep:input_type -> ipc.FocusCycleStepRequest
+	38, // 47: ipc.FocusHistory.FocusCycleCommit:input_type -> google.protobuf.Empty
+	38, // 48: ipc.FocusHistory.FocusHistoryList:input_type -> google.protobuf.Empty
+	38, // 49: ipc.BSPM.MonocleModeToggle:output_type -> google.protobuf.Empty
+	38, // 50: ipc.BSPM.MonocleModeCycle:output_type -> google.protobuf.Empty
+	17, // 51: ipc.BSPM.MonocleModeSubscribe:output_type -> ipc.MonocleModeSubscribeResponse
+	19, // 52: ipc.BSPM.Subscribe:output_type -> ipc.SubscribeResponse
+	31, // 53: ipc.BSPM.Events:output_type -> ipc.EventsResponse
+	33, // 54: ipc.BSPM.Metrics:output_type -> ipc.MetricsResponse
+	38, // 55: ipc.Scratchpad.ScratchpadToggle:output_type -> google.protobuf.Empty
+	38, // 56: ipc.Layout.LayoutSet:output_type -> google.protobuf.Empty
+	38, // 57: ipc.Layout.LayoutNext:output_type -> google.protobuf.Empty
+	38, // 58: ipc.Layout.LayoutUnset:output_type -> google.protobuf.Empty
+	38, // 59: ipc.Layout.LayoutPromote:output_type -> google.protobuf.Empty
+	38, // 60: ipc.Layout.LayoutMasterCountChange:output_type -> google.protobuf.Empty
+	38, // 61: ipc.Layout.LayoutRatioChange:output_type -> google.protobuf.Empty
+	38, // 62: ipc.Tabbed.TabbedToggle:output_type -> google.protobuf.Empty
+	38, // 63: ipc.Tabbed.TabbedCycle:output_type -> google.protobuf.Empty
+	38, // 64: ipc.Tabbed.TabbedSelect:output_type -> google.protobuf.Empty
+	38, // 65: ipc.Minimize.MinimizeFocused:output_type -> google.protobuf.Empty
+	38, // 66: ipc.Minimize.MinimizeRestore:output_type -> google.protobuf.Empty
+	38, // 67: ipc.Mark.MarkSet:output_type -> google.protobuf.Empty
+	38, // 68: ipc.Mark.MarkJump:output_type -> google.protobuf.Empty
+	38, // 69: ipc.FocusHistory.FocusBack:output_type -> google.protobuf.Empty
+	38, // 70: ipc.FocusHistory.FocusForward:output_type -> google.protobuf.Empty
+	38, // 71: ipc.FocusHistory.FocusCycleStart:output_type -> google.protobuf.Empty
+	38, // 72: ipc.FocusHistory.FocusCycleStep:output_type -> google.protobuf.Empty
+	38, // 73: ipc.FocusHistory.FocusCycleCommit:output_type -> google.protobuf.Empty
+	13, // 74: ipc.FocusHistory.FocusHistoryList:output_type -> ipc.FocusHistoryListResponse
+	49, // [49:75] is the sub-list for method output_type
+	23, // [23:49] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
+}
+
+func init() { file_bspm_proto_init() }
+func file_bspm_proto_init() {
+	if File_bspm_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_bspm_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScratchpadToggleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LayoutSetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LayoutMasterCountChangeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LayoutRatioChangeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TabbedCycleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TabbedSelectRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MinimizeRestoreRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MarkSetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MarkJumpRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FocusCycleStepRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FocusHistoryListResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FocusHistoryEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MonocleModeCycleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MonocleModeSubscribeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MonocleModeSubscribeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MonocleState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DesktopFocus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BspwmReconnected); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScratchpadState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LayoutState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TabbedState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TabbedContainer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Tab); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MinimizedState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MinimizedWindow); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EventsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EventsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EventNode); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MetricsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopicMetrics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspm_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CallbackMetrics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_bspm_proto_msgTypes[14].OneofWrappers = []interface{}{
+		(*MonocleModeSubscribeResponse_NodeCount)(nil),
+	}
+	file_bspm_proto_msgTypes[16].OneofWrappers = []interface{}{
+		(*SubscribeResponse_MonocleState)(nil),
+		(*SubscribeResponse_DesktopFocus)(nil),
+		(*SubscribeResponse_BspwmReconnected)(nil),
+		(*SubscribeResponse_ScratchpadState)(nil),
+		(*SubscribeResponse_LayoutState)(nil),
+		(*SubscribeResponse_TabbedState)(nil),
+		(*SubscribeResponse_MinimizedState)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_bspm_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   33,
+			NumExtensions: 0,
+			NumServices:   7,
+		},
+		GoTypes:           file_bspm_proto_goTypes,
+		DependencyIndexes: file_bspm_proto_depIdxs,
+		EnumInfos:         file_bspm_proto_enumTypes,
+		MessageInfos:      file_bspm_proto_msgTypes,
+	}.Build()
+	File_bspm_proto = out.File
+	file_bspm_proto_rawDesc = nil
+	file_bspm_proto_goTypes = nil
+	file_bspm_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// BSPMClient is the client API for BSPM service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type BSPMClient interface {
+	MonocleModeToggle(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	MonocleModeCycle(ctx context.Context, in *MonocleModeCycleRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	MonocleModeSubscribe(ctx context.Context, in *MonocleModeSubscribeRequest, opts ...grpc.CallOption) (BSPM_MonocleModeSubscribeClient, error)
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (BSPM_SubscribeClient, error)
+	Events(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (BSPM_EventsClient, error)
+	Metrics(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*MetricsResponse, error)
+}
+
+type bSPMClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBSPMClient(cc grpc.ClientConnInterface) BSPMClient {
+	return &bSPMClient{cc}
+}
+
+func (c *bSPMClient) MonocleModeToggle(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ipc.BSPM/MonocleModeToggle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
 func (c *bSPMClient) MonocleModeCycle(ctx context.Context, in *MonocleModeCycleRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ipc.BSPM/MonocleModeCycle", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/ipc.BSPM/MonocleModeCycle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bSPMClient) MonocleModeSubscribe(ctx context.Context, in *MonocleModeSubscribeRequest, opts ...grpc.CallOption) (BSPM_MonocleModeSubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BSPM_serviceDesc.Streams[0], "/ipc.BSPM/MonocleModeSubscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bSPMMonocleModeSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BSPM_MonocleModeSubscribeClient interface {
+	Recv() (*MonocleModeSubscribeResponse, error)
+	grpc.ClientStream
+}
+
+type bSPMMonocleModeSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *bSPMMonocleModeSubscribeClient) Recv() (*MonocleModeSubscribeResponse, error) {
+	m := new(MonocleModeSubscribeResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *bSPMClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (BSPM_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BSPM_serviceDesc.Streams[1], "/ipc.BSPM/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bSPMSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BSPM_SubscribeClient interface {
+	Recv() (*SubscribeResponse, error)
+	grpc.ClientStream
+}
+
+type bSPMSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *bSPMSubscribeClient) Recv() (*SubscribeResponse, error) {
+	m := new(SubscribeResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *bSPMClient) Events(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (BSPM_EventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BSPM_serviceDesc.Streams[2], "/ipc.BSPM/Events", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bSPMEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BSPM_EventsClient interface {
+	Recv() (*EventsResponse, error)
+	grpc.ClientStream
+}
+
+type bSPMEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *bSPMEventsClient) Recv() (*EventsResponse, error) {
+	m := new(EventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *bSPMClient) Metrics(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*MetricsResponse, error) {
+	out := new(MetricsResponse)
+	err := c.cc.Invoke(ctx, "/ipc.BSPM/Metrics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BSPMServer is the server API for BSPM service.
+type BSPMServer interface {
+	MonocleModeToggle(context.Context, *empty.Empty) (*empty.Empty, error)
+	MonocleModeCycle(context.Context, *MonocleModeCycleRequest) (*empty.Empty, error)
+	MonocleModeSubscribe(*MonocleModeSubscribeRequest, BSPM_MonocleModeSubscribeServer) error
+	Subscribe(*SubscribeRequest, BSPM_SubscribeServer) error
+	Events(*EventsRequest, BSPM_EventsServer) error
+	Metrics(context.Context, *empty.Empty) (*MetricsResponse, error)
+}
+
+// UnimplementedBSPMServer can be embedded to have forward compatible implementations.
+type UnimplementedBSPMServer struct {
+}
+
+func (*UnimplementedBSPMServer) MonocleModeToggle(context.Context, *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MonocleModeToggle not implemented")
+}
+func (*UnimplementedBSPMServer) MonocleModeCycle(context.Context, *MonocleModeCycleRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MonocleModeCycle not implemented")
+}
+func (*UnimplementedBSPMServer) MonocleModeSubscribe(*MonocleModeSubscribeRequest, BSPM_MonocleModeSubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method MonocleModeSubscribe not implemented")
+}
+func (*UnimplementedBSPMServer) Subscribe(*SubscribeRequest, BSPM_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (*UnimplementedBSPMServer) Events(*EventsRequest, BSPM_EventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method Events not implemented")
+}
+func (*UnimplementedBSPMServer) Metrics(context.Context, *empty.Empty) (*MetricsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Metrics not implemented")
+}
+
+func RegisterBSPMServer(s *grpc.Server, srv BSPMServer) {
+	s.RegisterService(&_BSPM_serviceDesc, srv)
+}
+
+func _BSPM_MonocleModeToggle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BSPMServer).MonocleModeToggle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ipc.BSPM/MonocleModeToggle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BSPMServer).MonocleModeToggle(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BSPM_MonocleModeCycle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MonocleModeCycleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BSPMServer).MonocleModeCycle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ipc.BSPM/MonocleModeCycle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BSPMServer).MonocleModeCycle(ctx, req.(*MonocleModeCycleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BSPM_MonocleModeSubscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MonocleModeSubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BSPMServer).MonocleModeSubscribe(m, &bSPMMonocleModeSubscribeServer{stream})
+}
+
+type BSPM_MonocleModeSubscribeServer interface {
+	Send(*MonocleModeSubscribeResponse) error
+	grpc.ServerStream
+}
+
+type bSPMMonocleModeSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *bSPMMonocleModeSubscribeServer) Send(m *MonocleModeSubscribeResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BSPM_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BSPMServer).Subscribe(m, &bSPMSubscribeServer{stream})
+}
+
+type BSPM_SubscribeServer interface {
+	Send(*SubscribeResponse) error
+	grpc.ServerStream
+}
+
+type bSPMSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *bSPMSubscribeServer) Send(m *SubscribeResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BSPM_Events_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BSPMServer).Events(m, &bSPMEventsServer{stream})
+}
+
+type BSPM_EventsServer interface {
+	Send(*EventsResponse) error
+	grpc.ServerStream
+}
+
+type bSPMEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *bSPMEventsServer) Send(m *EventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BSPM_Metrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BSPMServer).Metrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ipc.BSPM/Metrics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BSPMServer).Metrics(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _BSPM_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "ipc.BSPM",
+	HandlerType: (*BSPMServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "MonocleModeToggle",
+			Handler:    _BSPM_MonocleModeToggle_Handler,
+		},
+		{
+			MethodName: "MonocleModeCycle",
+			Handler:    _BSPM_MonocleModeCycle_Handler,
+		},
+		{
+			MethodName: "Metrics",
+			Handler:    _BSPM_Metrics_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "MonocleModeSubscribe",
+			Handler:       _BSPM_MonocleModeSubscribe_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Subscribe",
+			Handler:       _BSPM_Subscribe_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Events",
+			Handler:       _BSPM_Events_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "bspm.proto",
+}
+
+// ScratchpadClient is the client API for Scratchpad service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ScratchpadClient interface {
+	ScratchpadToggle(ctx context.Context, in *ScratchpadToggleRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type scratchpadClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewScratchpadClient(cc grpc.ClientConnInterface) ScratchpadClient {
+	return &scratchpadClient{cc}
+}
+
+func (c *scratchpadClient) ScratchpadToggle(ctx context.Context, in *ScratchpadToggleRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ipc.Scratchpad/ScratchpadToggle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScratchpadServer is the server API for Scratchpad service.
+type ScratchpadServer interface {
+	ScratchpadToggle(context.Context, *ScratchpadToggleRequest) (*empty.Empty, error)
+}
+
+// UnimplementedScratchpadServer can be embedded to have forward compatible implementations.
+type UnimplementedScratchpadServer struct {
+}
+
+func (*UnimplementedScratchpadServer) ScratchpadToggle(context.Context, *ScratchpadToggleRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScratchpadToggle not implemented")
+}
+
+func RegisterScratchpadServer(s *grpc.Server, srv ScratchpadServer) {
+	s.RegisterService(&_Scratchpad_serviceDesc, srv)
+}
+
+func _Scratchpad_ScratchpadToggle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScratchpadToggleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScratchpadServer).ScratchpadToggle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ipc.Scratchpad/ScratchpadToggle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScratchpadServer).ScratchpadToggle(ctx, req.(*ScratchpadToggleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Scratchpad_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "ipc.Scratchpad",
+	HandlerType: (*ScratchpadServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ScratchpadToggle",
+			Handler:    _Scratchpad_ScratchpadToggle_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "bspm.proto",
+}
+
+// LayoutClient is the client API for Layout service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type LayoutClient interface {
+	LayoutSet(ctx context.Context, in *LayoutSetRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	LayoutNext(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	LayoutUnset(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	LayoutPromote(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	LayoutMasterCountChange(ctx context.Context, in *LayoutMasterCountChangeRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	LayoutRatioChange(ctx context.Context, in *LayoutRatioChangeRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type layoutClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLayoutClient(cc grpc.ClientConnInterface) LayoutClient {
+	return &layoutClient{cc}
+}
+
+func (c *layoutClient) LayoutSet(ctx context.Context, in *LayoutSetRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ipc.Layout/LayoutSet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *layoutClient) LayoutNext(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ipc.Layout/LayoutNext", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *layoutClient) LayoutUnset(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ipc.Layout/LayoutUnset", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *layoutClient) LayoutPromote(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ipc.Layout/LayoutPromote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *layoutClient) LayoutMasterCountChange(ctx context.Context, in *LayoutMasterCountChangeRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ipc.Layout/LayoutMasterCountChange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *layoutClient) LayoutRatioChange(ctx context.Context, in *LayoutRatioChangeRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ipc.Layout/LayoutRatioChange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LayoutServer is the server API for Layout service.
+type LayoutServer interface {
+	LayoutSet(context.Context, *LayoutSetRequest) (*empty.Empty, error)
+	LayoutNext(context.Context, *empty.Empty) (*empty.Empty, error)
+	LayoutUnset(context.Context, *empty.Empty) (*empty.Empty, error)
+	LayoutPromote(context.Context, *empty.Empty) (*empty.Empty, error)
+	LayoutMasterCountChange(context.Context, *LayoutMasterCountChangeRequest) (*empty.Empty, error)
+	LayoutRatioChange(context.Context, *LayoutRatioChangeRequest) (*empty.Empty, error)
+}
+
+// UnimplementedLayoutServer can be embedded to have forward compatible implementations.
+type UnimplementedLayoutServer struct {
+}
+
+func (*UnimplementedLayoutServer) LayoutSet(context.Context, *LayoutSetRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LayoutSet not implemented")
+}
+func (*UnimplementedLayoutServer) LayoutNext(context.Context, *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LayoutNext not implemented")
+}
+func (*UnimplementedLayoutServer) LayoutUnset(context.Context, *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LayoutUnset not implemented")
+}
+func (*UnimplementedLayoutServer) LayoutPromote(context.Context, *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LayoutPromote not implemented")
+}
+func (*UnimplementedLayoutServer) LayoutMasterCountChange(context.Context, *LayoutMasterCountChangeRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LayoutMasterCountChange not implemented")
+}
+func (*UnimplementedLayoutServer) LayoutRatioChange(context.Context, *LayoutRatioChangeRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LayoutRatioChange not implemented")
+}
+
+func RegisterLayoutServer(s *grpc.Server, srv LayoutServer) {
+	s.RegisterService(&_Layout_serviceDesc, srv)
+}
+
+func _Layout_LayoutSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LayoutSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LayoutServer).LayoutSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ipc.Layout/LayoutSet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LayoutServer).LayoutSet(ctx, req.(*LayoutSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Layout_LayoutNext_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LayoutServer).LayoutNext(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ipc.Layout/LayoutNext",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LayoutServer).LayoutNext(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Layout_LayoutUnset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LayoutServer).LayoutUnset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ipc.Layout/LayoutUnset",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LayoutServer).LayoutUnset(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Layout_LayoutPromote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LayoutServer).LayoutPromote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ipc.Layout/LayoutPromote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LayoutServer).LayoutPromote(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Layout_LayoutMasterCountChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LayoutMasterCountChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LayoutServer).LayoutMasterCountChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ipc.Layout/LayoutMasterCountChange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LayoutServer).LayoutMasterCountChange(ctx, req.(*LayoutMasterCountChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Layout_LayoutRatioChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LayoutRatioChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LayoutServer).LayoutRatioChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ipc.Layout/LayoutRatioChange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LayoutServer).LayoutRatioChange(ctx, req.(*LayoutRatioChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Layout_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "ipc.Layout",
+	HandlerType: (*LayoutServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "LayoutSet",
+			Handler:    _Layout_LayoutSet_Handler,
+		},
+		{
+			MethodName: "LayoutNext",
+			Handler:    _Layout_LayoutNext_Handler,
+		},
+		{
+			MethodName: "LayoutUnset",
+			Handler:    _Layout_LayoutUnset_Handler,
+		},
+		{
+			MethodName: "LayoutPromote",
+			Handler:    _Layout_LayoutPromote_Handler,
+		},
+		{
+			MethodName: "LayoutMasterCountChange",
+			Handler:    _Layout_LayoutMasterCountChange_Handler,
+		},
+		{
+			MethodName: "LayoutRatioChange",
+			Handler:    _Layout_LayoutRatioChange_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "bspm.proto",
+}
+
+// TabbedClient is the client API for Tabbed service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type TabbedClient interface {
+	TabbedToggle(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	TabbedCycle(ctx context.Context, in *TabbedCycleRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	TabbedSelect(ctx context.Context, in *TabbedSelectRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type tabbedClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTabbedClient(cc grpc.ClientConnInterface) TabbedClient {
+	return &tabbedClient{cc}
+}
+
+func (c *tabbedClient) TabbedToggle(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ipc.Tabbed/TabbedToggle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tabbedClient) TabbedCycle(ctx context.Context, in *TabbedCycleRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ipc.Tabbed/TabbedCycle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tabbedClient) TabbedSelect(ctx context.Context, in *TabbedSelectRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ipc.Tabbed/TabbedSelect", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TabbedServer is the server API for Tabbed service.
+type TabbedServer interface {
+	TabbedToggle(context.Context, *empty.Empty) (*empty.Empty, error)
+	TabbedCycle(context.Context, *TabbedCycleRequest) (*empty.Empty, error)
+	TabbedSelect(context.Context, *TabbedSelectRequest) (*empty.Empty, error)
+}
+
+// UnimplementedTabbedServer can be embedded to have forward compatible implementations.
+type UnimplementedTabbedServer struct {
+}
+
+func (*UnimplementedTabbedServer) TabbedToggle(context.Context, *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TabbedToggle not implemented")
+}
+func (*UnimplementedTabbedServer) TabbedCycle(context.Context, *TabbedCycleRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TabbedCycle not implemented")
+}
+func (*UnimplementedTabbedServer) TabbedSelect(context.Context, *TabbedSelectRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TabbedSelect not implemented")
+}
+
+func RegisterTabbedServer(s *grpc.Server, srv TabbedServer) {
+	s.RegisterService(&_Tabbed_serviceDesc, srv)
+}
+
+func _Tabbed_TabbedToggle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TabbedServer).TabbedToggle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ipc.Tabbed/TabbedToggle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TabbedServer).TabbedToggle(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tabbed_TabbedCycle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TabbedCycleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TabbedServer).TabbedCycle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ipc.Tabbed/TabbedCycle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TabbedServer).TabbedCycle(ctx, req.(*TabbedCycleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tabbed_TabbedSelect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TabbedSelectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TabbedServer).TabbedSelect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ipc.Tabbed/TabbedSelect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TabbedServer).TabbedSelect(ctx, req.(*TabbedSelectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Tabbed_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "ipc.Tabbed",
+	HandlerType: (*TabbedServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "TabbedToggle",
+			Handler:    _Tabbed_TabbedToggle_Handler,
+		},
+		{
+			MethodName: "TabbedCycle",
+			Handler:    _Tabbed_TabbedCycle_Handler,
+		},
+		{
+			MethodName: "TabbedSelect",
+			Handler:    _Tabbed_TabbedSelect_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "bspm.proto",
+}
+
+// MinimizeClient is the client API for Minimize service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type MinimizeClient interface {
+	MinimizeFocused(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	MinimizeRestore(ctx context.Context, in *MinimizeRestoreRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type minimizeClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMinimizeClient(cc grpc.ClientConnInterface) MinimizeClient {
+	return &minimizeClient{cc}
+}
+
+func (c *minimizeClient) MinimizeFocused(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ipc.Minimize/MinimizeFocused", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *minimizeClient) MinimizeRestore(ctx context.Context, in *MinimizeRestoreRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ipc.Minimize/MinimizeRestore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MinimizeServer is the server API for Minimize service.
+type MinimizeServer interface {
+	MinimizeFocused(context.Context, *empty.Empty) (*empty.Empty, error)
+	MinimizeRestore(context.Context, *MinimizeRestoreRequest) (*empty.Empty, error)
+}
+
+// UnimplementedMinimizeServer can be embedded to have forward compatible implementations.
+type UnimplementedMinimizeServer struct {
+}
+
+func (*UnimplementedMinimizeServer) MinimizeFocused(context.Context, *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MinimizeFocused not implemented")
+}
+func (*UnimplementedMinimizeServer) MinimizeRestore(context.Context, *MinimizeRestoreRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MinimizeRestore not implemented")
+}
+
+func RegisterMinimizeServer(s *grpc.Server, srv MinimizeServer) {
+	s.RegisterService(&_Minimize_serviceDesc, srv)
+}
+
+func _Minimize_MinimizeFocused_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MinimizeServer).MinimizeFocused(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ipc.Minimize/MinimizeFocused",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MinimizeServer).MinimizeFocused(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Minimize_MinimizeRestore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MinimizeRestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MinimizeServer).MinimizeRestore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ipc.Minimize/MinimizeRestore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MinimizeServer).MinimizeRestore(ctx, req.(*MinimizeRestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Minimize_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "ipc.Minimize",
+	HandlerType: (*MinimizeServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "MinimizeFocused",
+			Handler:    _Minimize_MinimizeFocused_Handler,
+		},
+		{
+			MethodName: "MinimizeRestore",
+			Handler:    _Minimize_MinimizeRestore_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "bspm.proto",
+}
+
+// MarkClient is the client API for Mark service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type MarkClient interface {
+	MarkSet(ctx context.Context, in *MarkSetRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	MarkJump(ctx context.Context, in *MarkJumpRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type markClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMarkClient(cc grpc.ClientConnInterface) MarkClient {
+	return &markClient{cc}
+}
+
+func (c *markClient) MarkSet(ctx context.Context, in *MarkSetRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ipc.Mark/MarkSet", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *bSPMClient) MonocleModeSubscribe(ctx context.Context, in *MonocleModeSubscribeRequest, opts ...grpc.CallOption) (BSPM_MonocleModeSubscribeClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_BSPM_serviceDesc.Streams[0], "/ipc.BSPM/MonocleModeSubscribe", opts...)
+func (c *markClient) MarkJump(ctx context.Context, in *MarkJumpRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ipc.Mark/MarkJump", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &bSPMMonocleModeSubscribeClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
+	return out, nil
+}
+
+// MarkServer is the server API for Mark service.
+type MarkServer interface {
+	MarkSet(context.Context, *MarkSetRequest) (*empty.Empty, error)
+	MarkJump(context.Context, *MarkJumpRequest) (*empty.Empty, error)
+}
+
+// UnimplementedMarkServer can be embedded to have forward compatible implementations.
+type UnimplementedMarkServer struct {
+}
+
+func (*UnimplementedMarkServer) MarkSet(context.Context, *MarkSetRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkSet not implemented")
+}
+func (*UnimplementedMarkServer) MarkJump(context.Context, *MarkJumpRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkJump not implemented")
+}
+
+func RegisterMarkServer(s *grpc.Server, srv MarkServer) {
+	s.RegisterService(&_Mark_serviceDesc, srv)
+}
+
+func _Mark_MarkSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkSetRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
+	if interceptor == nil {
+		return srv.(MarkServer).MarkSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ipc.Mark/MarkSet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarkServer).MarkSet(ctx, req.(*MarkSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mark_MarkJump_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkJumpRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return x, nil
+	if interceptor == nil {
+		return srv.(MarkServer).MarkJump(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ipc.Mark/MarkJump",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarkServer).MarkJump(ctx, req.(*MarkJumpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type BSPM_MonocleModeSubscribeClient interface {
-	Recv() (*MonocleModeSubscribeResponse, error)
-	grpc.ClientStream
+var _Mark_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "ipc.Mark",
+	HandlerType: (*MarkServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "MarkSet",
+			Handler:    _Mark_MarkSet_Handler,
+		},
+		{
+			MethodName: "MarkJump",
+			Handler:    _Mark_MarkJump_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "bspm.proto",
 }
 
-type bSPMMonocleModeSubscribeClient struct {
-	grpc.ClientStream
+// FocusHistoryClient is the client API for FocusHistory service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type FocusHistoryClient interface {
+	FocusBack(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	FocusForward(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	FocusCycleStart(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	FocusCycleStep(ctx context.Context, in *FocusCycleStepRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	FocusCycleCommit(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	FocusHistoryList(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*FocusHistoryListResponse, error)
 }
 
-func (x *bSPMMonocleModeSubscribeClient) Recv() (*MonocleModeSubscribeResponse, error) {
-	m := new(MonocleModeSubscribeResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+type focusHistoryClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFocusHistoryClient(cc grpc.ClientConnInterface) FocusHistoryClient {
+	return &focusHistoryClient{cc}
+}
+
+func (c *focusHistoryClient) FocusBack(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ipc.FocusHistory/FocusBack", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	return m, nil
+	return out, nil
 }
 
-// BSPMServer is the server API for BSPM service.
-type BSPMServer interface {
-	MonocleModeToggle(context.Context, *empty.Empty) (*empty.Empty, error)
-	MonocleModeCycle(context.Context, *MonocleModeCycleRequest) (*empty.Empty, error)
-	MonocleModeSubscribe(*MonocleModeSubscribeRequest, BSPM_MonocleModeSubscribeServer) error
+func (c *focusHistoryClient) FocusForward(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ipc.FocusHistory/FocusForward", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// UnimplementedBSPMServer can be embedded to have forward compatible implementations.
-type UnimplementedBSPMServer struct {
+func (c *focusHistoryClient) FocusCycleStart(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ipc.FocusHistory/FocusCycleStart", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (*UnimplementedBSPMServer) MonocleModeToggle(context.Context, *empty.Empty) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method MonocleModeToggle not implemented")
+func (c *focusHistoryClient) FocusCycleStep(ctx context.Context, in *FocusCycleStepRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ipc.FocusHistory/FocusCycleStep", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (*UnimplementedBSPMServer) MonocleModeCycle(context.Context, *MonocleModeCycleRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method MonocleModeCycle not implemented")
+
+func (c *focusHistoryClient) FocusCycleCommit(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ipc.FocusHistory/FocusCycleCommit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (*UnimplementedBSPMServer) MonocleModeSubscribe(*MonocleModeSubscribeRequest, BSPM_MonocleModeSubscribeServer) error {
-	return status.Errorf(codes.Unimplemented, "method MonocleModeSubscribe not implemented")
+
+func (c *focusHistoryClient) FocusHistoryList(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*FocusHistoryListResponse, error) {
+	out := new(FocusHistoryListResponse)
+	err := c.cc.Invoke(ctx, "/ipc.FocusHistory/FocusHistoryList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func RegisterBSPMServer(s *grpc.Server, srv BSPMServer) {
-	s.RegisterService(&_BSPM_serviceDesc, srv)
+// FocusHistoryServer is the server API for FocusHistory service.
+type FocusHistoryServer interface {
+	FocusBack(context.Context, *empty.Empty) (*empty.Empty, error)
+	FocusForward(context.Context, *empty.Empty) (*empty.Empty, error)
+	FocusCycleStart(context.Context, *empty.Empty) (*empty.Empty, error)
+	FocusCycleStep(context.Context, *FocusCycleStepRequest) (*empty.Empty, error)
+	FocusCycleCommit(context.Context, *empty.Empty) (*empty.Empty, error)
+	FocusHistoryList(context.Context, *empty.Empty) (*FocusHistoryListResponse, error)
 }
 
-func _BSPM_MonocleModeToggle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+// UnimplementedFocusHistoryServer can be embedded to have forward compatible implementations.
+type UnimplementedFocusHistoryServer struct {
+}
+
+func (*UnimplementedFocusHistoryServer) FocusBack(context.Context, *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FocusBack not implemented")
+}
+func (*UnimplementedFocusHistoryServer) FocusForward(context.Context, *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FocusForward not implemented")
+}
+func (*UnimplementedFocusHistoryServer) FocusCycleStart(context.Context, *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FocusCycleStart not implemented")
+}
+func (*UnimplementedFocusHistoryServer) FocusCycleStep(context.Context, *FocusCycleStepRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FocusCycleStep not implemented")
+}
+func (*UnimplementedFocusHistoryServer) FocusCycleCommit(context.Context, *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FocusCycleCommit not implemented")
+}
+func (*UnimplementedFocusHistoryServer) FocusHistoryList(context.Context, *empty.Empty) (*FocusHistoryListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FocusHistoryList not implemented")
+}
+
+func RegisterFocusHistoryServer(s *grpc.Server, srv FocusHistoryServer) {
+	s.RegisterService(&_FocusHistory_serviceDesc, srv)
+}
+
+func _FocusHistory_FocusBack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(empty.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BSPMServer).MonocleModeToggle(ctx, in)
+		return srv.(FocusHistoryServer).FocusBack(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ipc.BSPM/MonocleModeToggle",
+		FullMethod: "/ipc.FocusHistory/FocusBack",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BSPMServer).MonocleModeToggle(ctx, req.(*empty.Empty))
+		return srv.(FocusHistoryServer).FocusBack(ctx, req.(*empty.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BSPM_MonocleModeCycle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MonocleModeCycleRequest)
+func _FocusHistory_FocusForward_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BSPMServer).MonocleModeCycle(ctx, in)
+		return srv.(FocusHistoryServer).FocusForward(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ipc.BSPM/MonocleModeCycle",
+		FullMethod: "/ipc.FocusHistory/FocusForward",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BSPMServer).MonocleModeCycle(ctx, req.(*MonocleModeCycleRequest))
+		return srv.(FocusHistoryServer).FocusForward(ctx, req.(*empty.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BSPM_MonocleModeSubscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(MonocleModeSubscribeRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _FocusHistory_FocusCycleStart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(BSPMServer).MonocleModeSubscribe(m, &bSPMMonocleModeSubscribeServer{stream})
+	if interceptor == nil {
+		return srv.(FocusHistoryServer).FocusCycleStart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ipc.FocusHistory/FocusCycleStart",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FocusHistoryServer).FocusCycleStart(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type BSPM_MonocleModeSubscribeServer interface {
-	Send(*MonocleModeSubscribeResponse) error
-	grpc.ServerStream
+func _FocusHistory_FocusCycleStep_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FocusCycleStepRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FocusHistoryServer).FocusCycleStep(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ipc.FocusHistory/FocusCycleStep",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FocusHistoryServer).FocusCycleStep(ctx, req.(*FocusCycleStepRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type bSPMMonocleModeSubscribeServer struct {
-	grpc.ServerStream
+func _FocusHistory_FocusCycleCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FocusHistoryServer).FocusCycleCommit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ipc.FocusHistory/FocusCycleCommit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FocusHistoryServer).FocusCycleCommit(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *bSPMMonocleModeSubscribeServer) Send(m *MonocleModeSubscribeResponse) error {
-	return x.ServerStream.SendMsg(m)
+func _FocusHistory_FocusHistoryList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FocusHistoryServer).FocusHistoryList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ipc.FocusHistory/FocusHistoryList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FocusHistoryServer).FocusHistoryList(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-var _BSPM_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "ipc.BSPM",
-	HandlerType: (*BSPMServer)(nil),
+var _FocusHistory_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "ipc.FocusHistory",
+	HandlerType: (*FocusHistoryServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "MonocleModeToggle",
-			Handler:    _BSPM_MonocleModeToggle_Handler,
+			MethodName: "FocusBack",
+			Handler:    _FocusHistory_FocusBack_Handler,
 		},
 		{
-			MethodName: "MonocleModeCycle",
-			Handler:    _BSPM_MonocleModeCycle_Handler,
+			MethodName: "FocusForward",
+			Handler:    _FocusHistory_FocusForward_Handler,
 		},
-	},
-	Streams: []grpc.StreamDesc{
 		{
-			StreamName:    "MonocleModeSubscribe",
-			Handler:       _BSPM_MonocleModeSubscribe_Handler,
-			ServerStreams: true,
+			MethodName: "FocusCycleStart",
+			Handler:    _FocusHistory_FocusCycleStart_Handler,
+		},
+		{
+			MethodName: "FocusCycleStep",
+			Handler:    _FocusHistory_FocusCycleStep_Handler,
+		},
+		{
+			MethodName: "FocusCycleCommit",
+			Handler:    _FocusHistory_FocusCycleCommit_Handler,
+		},
+		{
+			MethodName: "FocusHistoryList",
+			Handler:    _FocusHistory_FocusHistoryList_Handler,
 		},
 	},
+	Streams:  []grpc.StreamDesc{},
 	Metadata: "bspm.proto",
 }
@@ -11,7 +11,29 @@ import (
 	"github.com/diogox/bspm/internal/grpc/bspm"
 )
 
-type Client bspm.BSPMClient
+type (
+	Client interface {
+		bspm.BSPMClient
+		bspm.ScratchpadClient
+		bspm.LayoutClient
+		bspm.TabbedClient
+		bspm.MinimizeClient
+		bspm.MarkClient
+		bspm.FocusHistoryClient
+		Close() error
+	}
+
+	client struct {
+		bspm.BSPMClient
+		bspm.ScratchpadClient
+		bspm.LayoutClient
+		bspm.TabbedClient
+		bspm.MinimizeClient
+		bspm.MarkClient
+		bspm.FocusHistoryClient
+		conn *grpc.ClientConn
+	}
+)
 
 func NewClient() (Client, error) {
 	timeout := 1 * time.Second
@@ -26,5 +48,19 @@ func NewClient() (Client, error) {
 		return nil, fmt.Errorf("failed to connect to server: %w", err)
 	}
 
-	return bspm.NewBSPMClient(conn), nil
+	return client{
+		BSPMClient:         bspm.NewBSPMClient(conn),
+		ScratchpadClient:   bspm.NewScratchpadClient(conn),
+		LayoutClient:       bspm.NewLayoutClient(conn),
+		TabbedClient:       bspm.NewTabbedClient(conn),
+		MinimizeClient:     bspm.NewMinimizeClient(conn),
+		MarkClient:         bspm.NewMarkClient(conn),
+		FocusHistoryClient: bspm.NewFocusHistoryClient(conn),
+		conn:               conn,
+	}, nil
+}
+
+// Close closes the connection to the server.
+func (c client) Close() error {
+	return c.conn.Close()
 }
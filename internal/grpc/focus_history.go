@@ -0,0 +1,98 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	focushistory "github.com/diogox/bspm/internal/feature/focus_history"
+	"github.com/diogox/bspm/internal/grpc/bspm"
+	"github.com/diogox/bspm/internal/log"
+)
+
+type focusHistoryServer struct {
+	logger       *log.Logger
+	focusHistory focushistory.Feature
+}
+
+func (s *focusHistoryServer) FocusBack(context.Context, *empty.Empty) (*empty.Empty, error) {
+	if err := s.focusHistory.Back(); err != nil {
+		s.logger.Error("failed to go back in focus history", zap.Error(err))
+		return nil, fmt.Errorf("failed to go back in focus history: %w", err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *focusHistoryServer) FocusForward(context.Context, *empty.Empty) (*empty.Empty, error) {
+	if err := s.focusHistory.Forward(); err != nil {
+		s.logger.Error("failed to go forward in focus history", zap.Error(err))
+		return nil, fmt.Errorf("failed to go forward in focus history: %w", err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *focusHistoryServer) FocusCycleStart(context.Context, *empty.Empty) (*empty.Empty, error) {
+	if err := s.focusHistory.CycleStart(); err != nil {
+		s.logger.Error("failed to start focus cycle", zap.Error(err))
+		return nil, fmt.Errorf("failed to start focus cycle: %w", err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *focusHistoryServer) FocusCycleStep(_ context.Context, req *bspm.FocusCycleStepRequest) (*empty.Empty, error) {
+	var offset int
+
+	switch req.GetCycleDirection() {
+	case bspm.CycleDir_CYCLE_DIR_PREV:
+		offset = -1
+	case bspm.CycleDir_CYCLE_DIR_NEXT:
+		offset = 1
+	default:
+		return nil, errors.New("invalid focus cycling direction")
+	}
+
+	if err := s.focusHistory.CycleStep(offset); err != nil {
+		s.logger.Error("failed to step through focus cycle", zap.Error(err))
+		return nil, fmt.Errorf("failed to step through focus cycle: %w", err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *focusHistoryServer) FocusCycleCommit(context.Context, *empty.Empty) (*empty.Empty, error) {
+	if err := s.focusHistory.CycleCommit(); err != nil {
+		s.logger.Error("failed to commit focus cycle", zap.Error(err))
+		return nil, fmt.Errorf("failed to commit focus cycle: %w", err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *focusHistoryServer) FocusHistoryList(context.Context, *empty.Empty) (*bspm.FocusHistoryListResponse, error) {
+	entries := s.focusHistory.List()
+
+	res := &bspm.FocusHistoryListResponse{
+		Entries: make([]*bspm.FocusHistoryEntry, 0, len(entries)),
+	}
+
+	for _, e := range entries {
+		res.Entries = append(res.Entries, &bspm.FocusHistoryEntry{
+			NodeId:       uint32(e.NodeID),
+			DesktopId:    uint32(e.DesktopID),
+			ClassName:    e.ClassName,
+			InstanceName: e.InstanceName,
+			Title:        e.Title,
+			FocusedAt:    timestamppb.New(e.FocusedAt),
+			IsHidden:     e.IsHidden,
+		})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,323 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/diogox/bspc-go"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	focushistory "github.com/diogox/bspm/internal/feature/focus_history"
+	"github.com/diogox/bspm/internal/grpc"
+	"github.com/diogox/bspm/internal/grpc/bspm"
+	"github.com/diogox/bspm/internal/log"
+)
+
+func TestFocusHistoryServer_FocusBack(t *testing.T) {
+	t.Run("should go back", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockFocusHistory := focushistory.NewMockFeature(ctrl)
+		mockFocusHistory.EXPECT().
+			Back().
+			Return(nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestFocusHistoryServer(logger, mockFocusHistory).
+			FocusBack(context.Background(), &empty.Empty{})
+		assert.NoError(t, err)
+	})
+	t.Run("should return error when feature returns error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+
+		mockFocusHistory := focushistory.NewMockFeature(ctrl)
+		mockFocusHistory.EXPECT().
+			Back().
+			Return(expectedErr)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestFocusHistoryServer(logger, mockFocusHistory).
+			FocusBack(context.Background(), &empty.Empty{})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, expectedErr))
+	})
+}
+
+func TestFocusHistoryServer_FocusForward(t *testing.T) {
+	t.Run("should go forward", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockFocusHistory := focushistory.NewMockFeature(ctrl)
+		mockFocusHistory.EXPECT().
+			Forward().
+			Return(nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestFocusHistoryServer(logger, mockFocusHistory).
+			FocusForward(context.Background(), &empty.Empty{})
+		assert.NoError(t, err)
+	})
+	t.Run("should return error when feature returns error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+
+		mockFocusHistory := focushistory.NewMockFeature(ctrl)
+		mockFocusHistory.EXPECT().
+			Forward().
+			Return(expectedErr)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestFocusHistoryServer(logger, mockFocusHistory).
+			FocusForward(context.Background(), &empty.Empty{})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, expectedErr))
+	})
+}
+
+func TestFocusHistoryServer_FocusCycleStart(t *testing.T) {
+	t.Run("should start cycle", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockFocusHistory := focushistory.NewMockFeature(ctrl)
+		mockFocusHistory.EXPECT().
+			CycleStart().
+			Return(nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestFocusHistoryServer(logger, mockFocusHistory).
+			FocusCycleStart(context.Background(), &empty.Empty{})
+		assert.NoError(t, err)
+	})
+	t.Run("should return error when feature returns error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+
+		mockFocusHistory := focushistory.NewMockFeature(ctrl)
+		mockFocusHistory.EXPECT().
+			CycleStart().
+			Return(expectedErr)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestFocusHistoryServer(logger, mockFocusHistory).
+			FocusCycleStart(context.Background(), &empty.Empty{})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, expectedErr))
+	})
+}
+
+func TestFocusHistoryServer_FocusCycleStep(t *testing.T) {
+	t.Run("should step through cycle", func(t *testing.T) {
+		t.Run("to next window", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockFocusHistory := focushistory.NewMockFeature(ctrl)
+			mockFocusHistory.EXPECT().
+				CycleStep(1).
+				Return(nil)
+
+			logger, err := log.New(zaptest.NewLogger(t), false)
+			require.NoError(t, err)
+
+			_, err = grpc.
+				NewTestFocusHistoryServer(logger, mockFocusHistory).
+				FocusCycleStep(context.Background(), &bspm.FocusCycleStepRequest{
+					CycleDirection: bspm.CycleDir_CYCLE_DIR_NEXT,
+				})
+			assert.NoError(t, err)
+		})
+		t.Run("to previous window", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockFocusHistory := focushistory.NewMockFeature(ctrl)
+			mockFocusHistory.EXPECT().
+				CycleStep(-1).
+				Return(nil)
+
+			logger, err := log.New(zaptest.NewLogger(t), false)
+			require.NoError(t, err)
+
+			_, err = grpc.
+				NewTestFocusHistoryServer(logger, mockFocusHistory).
+				FocusCycleStep(context.Background(), &bspm.FocusCycleStepRequest{
+					CycleDirection: bspm.CycleDir_CYCLE_DIR_PREV,
+				})
+			assert.NoError(t, err)
+		})
+	})
+	t.Run("should return error when feature returns error", func(t *testing.T) {
+		t.Run("when stepping to next window", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			expectedErr := errors.New("error")
+
+			mockFocusHistory := focushistory.NewMockFeature(ctrl)
+			mockFocusHistory.EXPECT().
+				CycleStep(1).
+				Return(expectedErr)
+
+			logger, err := log.New(zaptest.NewLogger(t), false)
+			require.NoError(t, err)
+
+			_, err = grpc.
+				NewTestFocusHistoryServer(logger, mockFocusHistory).
+				FocusCycleStep(context.Background(), &bspm.FocusCycleStepRequest{
+					CycleDirection: bspm.CycleDir_CYCLE_DIR_NEXT,
+				})
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, expectedErr))
+		})
+		t.Run("when stepping to previous window", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			expectedErr := errors.New("error")
+
+			mockFocusHistory := focushistory.NewMockFeature(ctrl)
+			mockFocusHistory.EXPECT().
+				CycleStep(-1).
+				Return(expectedErr)
+
+			logger, err := log.New(zaptest.NewLogger(t), false)
+			require.NoError(t, err)
+
+			_, err = grpc.
+				NewTestFocusHistoryServer(logger, mockFocusHistory).
+				FocusCycleStep(context.Background(), &bspm.FocusCycleStepRequest{
+					CycleDirection: bspm.CycleDir_CYCLE_DIR_PREV,
+				})
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, expectedErr))
+		})
+	})
+	t.Run("should return error when direction is invalid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestFocusHistoryServer(logger, focushistory.NewMockFeature(ctrl)).
+			FocusCycleStep(context.Background(), &bspm.FocusCycleStepRequest{})
+		assert.Error(t, err)
+	})
+}
+
+func TestFocusHistoryServer_FocusCycleCommit(t *testing.T) {
+	t.Run("should commit cycle", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockFocusHistory := focushistory.NewMockFeature(ctrl)
+		mockFocusHistory.EXPECT().
+			CycleCommit().
+			Return(nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestFocusHistoryServer(logger, mockFocusHistory).
+			FocusCycleCommit(context.Background(), &empty.Empty{})
+		assert.NoError(t, err)
+	})
+	t.Run("should return error when feature returns error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+
+		mockFocusHistory := focushistory.NewMockFeature(ctrl)
+		mockFocusHistory.EXPECT().
+			CycleCommit().
+			Return(expectedErr)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestFocusHistoryServer(logger, mockFocusHistory).
+			FocusCycleCommit(context.Background(), &empty.Empty{})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, expectedErr))
+	})
+}
+
+func TestFocusHistoryServer_FocusHistoryList(t *testing.T) {
+	t.Run("should list focus history", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		focusedAt := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		mockFocusHistory := focushistory.NewMockFeature(ctrl)
+		mockFocusHistory.EXPECT().
+			List().
+			Return([]focushistory.Entry{
+				{
+					NodeID:       bspc.ID(1),
+					DesktopID:    bspc.ID(2),
+					FocusedAt:    focusedAt,
+					ClassName:    "Alacritty",
+					InstanceName: "alacritty",
+					Title:        "vim",
+					IsHidden:     true,
+				},
+			})
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		res, err := grpc.
+			NewTestFocusHistoryServer(logger, mockFocusHistory).
+			FocusHistoryList(context.Background(), &empty.Empty{})
+		require.NoError(t, err)
+		require.Len(t, res.GetEntries(), 1)
+
+		entry := res.GetEntries()[0]
+		assert.Equal(t, uint32(1), entry.GetNodeId())
+		assert.Equal(t, uint32(2), entry.GetDesktopId())
+		assert.Equal(t, "Alacritty", entry.GetClassName())
+		assert.Equal(t, "alacritty", entry.GetInstanceName())
+		assert.Equal(t, "vim", entry.GetTitle())
+		assert.True(t, entry.GetFocusedAt().AsTime().Equal(focusedAt))
+		assert.True(t, entry.GetIsHidden())
+	})
+}
@@ -0,0 +1,75 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"go.uber.org/zap"
+
+	"github.com/diogox/bspm/internal/feature/layout"
+	"github.com/diogox/bspm/internal/grpc/bspm"
+	"github.com/diogox/bspm/internal/log"
+)
+
+type layoutServer struct {
+	logger  *log.Logger
+	layouts layout.Feature
+}
+
+func (s *layoutServer) LayoutSet(_ context.Context, req *bspm.LayoutSetRequest) (*empty.Empty, error) {
+	if err := s.layouts.Set(req.GetName()); err != nil {
+		s.logger.Error("failed to set layout", zap.String("name", req.GetName()), zap.Error(err))
+		return nil, fmt.Errorf("failed to set layout %s: %w", req.GetName(), err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *layoutServer) LayoutNext(context.Context, *empty.Empty) (*empty.Empty, error) {
+	if err := s.layouts.Next(); err != nil {
+		s.logger.Error("failed to cycle layout", zap.Error(err))
+		return nil, fmt.Errorf("failed to cycle layout: %w", err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *layoutServer) LayoutUnset(context.Context, *empty.Empty) (*empty.Empty, error) {
+	if err := s.layouts.Unset(); err != nil {
+		s.logger.Error("failed to unset layout", zap.Error(err))
+		return nil, fmt.Errorf("failed to unset layout: %w", err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *layoutServer) LayoutPromote(context.Context, *empty.Empty) (*empty.Empty, error) {
+	if err := s.layouts.Promote(); err != nil {
+		s.logger.Error("failed to promote node", zap.Error(err))
+		return nil, fmt.Errorf("failed to promote node: %w", err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *layoutServer) LayoutMasterCountChange(
+	_ context.Context,
+	req *bspm.LayoutMasterCountChangeRequest,
+) (*empty.Empty, error) {
+	if err := s.layouts.ChangeMasterCount(int(req.GetDelta())); err != nil {
+		s.logger.Error("failed to change master count", zap.Int32("delta", req.GetDelta()), zap.Error(err))
+		return nil, fmt.Errorf("failed to change master count: %w", err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *layoutServer) LayoutRatioChange(_ context.Context, req *bspm.LayoutRatioChangeRequest) (*empty.Empty, error) {
+	if err := s.layouts.ChangeRatio(req.GetDelta()); err != nil {
+		s.logger.Error("failed to change ratio", zap.Float64("delta", req.GetDelta()), zap.Error(err))
+		return nil, fmt.Errorf("failed to change ratio: %w", err)
+	}
+
+	return &empty.Empty{}, nil
+}
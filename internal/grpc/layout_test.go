@@ -0,0 +1,258 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/diogox/bspm/internal/feature/layout"
+	"github.com/diogox/bspm/internal/grpc"
+	"github.com/diogox/bspm/internal/grpc/bspm"
+	"github.com/diogox/bspm/internal/log"
+)
+
+func TestLayoutServer_LayoutSet(t *testing.T) {
+	t.Run("should set layout", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockLayouts := layout.NewMockFeature(ctrl)
+		mockLayouts.EXPECT().
+			Set(layout.NameMasterStack).
+			Return(nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestLayoutServer(logger, mockLayouts).
+			LayoutSet(context.Background(), &bspm.LayoutSetRequest{Name: layout.NameMasterStack})
+		assert.NoError(t, err)
+	})
+	t.Run("should return error when feature returns error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+
+		mockLayouts := layout.NewMockFeature(ctrl)
+		mockLayouts.EXPECT().
+			Set(layout.NameMasterStack).
+			Return(expectedErr)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestLayoutServer(logger, mockLayouts).
+			LayoutSet(context.Background(), &bspm.LayoutSetRequest{Name: layout.NameMasterStack})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, expectedErr))
+	})
+}
+
+func TestLayoutServer_LayoutNext(t *testing.T) {
+	t.Run("should cycle layout", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockLayouts := layout.NewMockFeature(ctrl)
+		mockLayouts.EXPECT().
+			Next().
+			Return(nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestLayoutServer(logger, mockLayouts).
+			LayoutNext(context.Background(), &empty.Empty{})
+		assert.NoError(t, err)
+	})
+	t.Run("should return error when feature returns error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+
+		mockLayouts := layout.NewMockFeature(ctrl)
+		mockLayouts.EXPECT().
+			Next().
+			Return(expectedErr)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestLayoutServer(logger, mockLayouts).
+			LayoutNext(context.Background(), &empty.Empty{})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, expectedErr))
+	})
+}
+
+func TestLayoutServer_LayoutUnset(t *testing.T) {
+	t.Run("should unset layout", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockLayouts := layout.NewMockFeature(ctrl)
+		mockLayouts.EXPECT().
+			Unset().
+			Return(nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestLayoutServer(logger, mockLayouts).
+			LayoutUnset(context.Background(), &empty.Empty{})
+		assert.NoError(t, err)
+	})
+	t.Run("should return error when feature returns error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+
+		mockLayouts := layout.NewMockFeature(ctrl)
+		mockLayouts.EXPECT().
+			Unset().
+			Return(expectedErr)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestLayoutServer(logger, mockLayouts).
+			LayoutUnset(context.Background(), &empty.Empty{})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, expectedErr))
+	})
+}
+
+func TestLayoutServer_LayoutPromote(t *testing.T) {
+	t.Run("should promote node", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockLayouts := layout.NewMockFeature(ctrl)
+		mockLayouts.EXPECT().
+			Promote().
+			Return(nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestLayoutServer(logger, mockLayouts).
+			LayoutPromote(context.Background(), &empty.Empty{})
+		assert.NoError(t, err)
+	})
+	t.Run("should return error when feature returns error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+
+		mockLayouts := layout.NewMockFeature(ctrl)
+		mockLayouts.EXPECT().
+			Promote().
+			Return(expectedErr)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestLayoutServer(logger, mockLayouts).
+			LayoutPromote(context.Background(), &empty.Empty{})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, expectedErr))
+	})
+}
+
+func TestLayoutServer_LayoutMasterCountChange(t *testing.T) {
+	t.Run("should change master count", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockLayouts := layout.NewMockFeature(ctrl)
+		mockLayouts.EXPECT().
+			ChangeMasterCount(-1).
+			Return(nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestLayoutServer(logger, mockLayouts).
+			LayoutMasterCountChange(context.Background(), &bspm.LayoutMasterCountChangeRequest{Delta: -1})
+		assert.NoError(t, err)
+	})
+	t.Run("should return error when feature returns error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+
+		mockLayouts := layout.NewMockFeature(ctrl)
+		mockLayouts.EXPECT().
+			ChangeMasterCount(-1).
+			Return(expectedErr)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestLayoutServer(logger, mockLayouts).
+			LayoutMasterCountChange(context.Background(), &bspm.LayoutMasterCountChangeRequest{Delta: -1})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, expectedErr))
+	})
+}
+
+func TestLayoutServer_LayoutRatioChange(t *testing.T) {
+	t.Run("should change ratio", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockLayouts := layout.NewMockFeature(ctrl)
+		mockLayouts.EXPECT().
+			ChangeRatio(0.05).
+			Return(nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestLayoutServer(logger, mockLayouts).
+			LayoutRatioChange(context.Background(), &bspm.LayoutRatioChangeRequest{Delta: 0.05})
+		assert.NoError(t, err)
+	})
+	t.Run("should return error when feature returns error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+
+		mockLayouts := layout.NewMockFeature(ctrl)
+		mockLayouts.EXPECT().
+			ChangeRatio(0.05).
+			Return(expectedErr)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestLayoutServer(logger, mockLayouts).
+			LayoutRatioChange(context.Background(), &bspm.LayoutRatioChangeRequest{Delta: 0.05})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, expectedErr))
+	})
+}
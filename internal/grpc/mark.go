@@ -0,0 +1,36 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"go.uber.org/zap"
+
+	"github.com/diogox/bspm/internal/feature/mark"
+	"github.com/diogox/bspm/internal/grpc/bspm"
+	"github.com/diogox/bspm/internal/log"
+)
+
+type markServer struct {
+	logger *log.Logger
+	marks  mark.Feature
+}
+
+func (s *markServer) MarkSet(_ context.Context, req *bspm.MarkSetRequest) (*empty.Empty, error) {
+	if err := s.marks.Set(req.GetMark()); err != nil {
+		s.logger.Error("failed to set mark", zap.String("mark", req.GetMark()), zap.Error(err))
+		return nil, fmt.Errorf("failed to set mark %s: %w", req.GetMark(), err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *markServer) MarkJump(_ context.Context, req *bspm.MarkJumpRequest) (*empty.Empty, error) {
+	if err := s.marks.Jump(req.GetMark()); err != nil {
+		s.logger.Error("failed to jump to mark", zap.String("mark", req.GetMark()), zap.Error(err))
+		return nil, fmt.Errorf("failed to jump to mark %s: %w", req.GetMark(), err)
+	}
+
+	return &empty.Empty{}, nil
+}
@@ -0,0 +1,97 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/diogox/bspm/internal/feature/mark"
+	"github.com/diogox/bspm/internal/grpc"
+	"github.com/diogox/bspm/internal/grpc/bspm"
+	"github.com/diogox/bspm/internal/log"
+)
+
+func TestMarkServer_MarkSet(t *testing.T) {
+	t.Run("should set mark", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMarks := mark.NewMockFeature(ctrl)
+		mockMarks.EXPECT().
+			Set("a").
+			Return(nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestMarkServer(logger, mockMarks).
+			MarkSet(context.Background(), &bspm.MarkSetRequest{Mark: "a"})
+		assert.NoError(t, err)
+	})
+	t.Run("should return error when feature returns error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+
+		mockMarks := mark.NewMockFeature(ctrl)
+		mockMarks.EXPECT().
+			Set("a").
+			Return(expectedErr)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestMarkServer(logger, mockMarks).
+			MarkSet(context.Background(), &bspm.MarkSetRequest{Mark: "a"})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, expectedErr))
+	})
+}
+
+func TestMarkServer_MarkJump(t *testing.T) {
+	t.Run("should jump to mark", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMarks := mark.NewMockFeature(ctrl)
+		mockMarks.EXPECT().
+			Jump("a").
+			Return(nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestMarkServer(logger, mockMarks).
+			MarkJump(context.Background(), &bspm.MarkJumpRequest{Mark: "a"})
+		assert.NoError(t, err)
+	})
+	t.Run("should return error when feature returns error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+
+		mockMarks := mark.NewMockFeature(ctrl)
+		mockMarks.EXPECT().
+			Jump("a").
+			Return(expectedErr)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestMarkServer(logger, mockMarks).
+			MarkJump(context.Background(), &bspm.MarkJumpRequest{Mark: "a"})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, expectedErr))
+	})
+}
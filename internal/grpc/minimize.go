@@ -0,0 +1,46 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/diogox/bspc-go"
+	"github.com/golang/protobuf/ptypes/empty"
+	"go.uber.org/zap"
+
+	"github.com/diogox/bspm/internal/feature/minimize"
+	"github.com/diogox/bspm/internal/grpc/bspm"
+	"github.com/diogox/bspm/internal/log"
+)
+
+type minimizeServer struct {
+	logger    *log.Logger
+	minimizer minimize.Feature
+}
+
+func (s *minimizeServer) MinimizeFocused(context.Context, *empty.Empty) (*empty.Empty, error) {
+	if err := s.minimizer.Minimize(); err != nil {
+		s.logger.Error("failed to minimise window", zap.Error(err))
+		return nil, fmt.Errorf("failed to minimise window: %w", err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *minimizeServer) MinimizeRestore(_ context.Context, req *bspm.MinimizeRestoreRequest) (*empty.Empty, error) {
+	if req.GetNodeId() == uint32(bspc.NilID) {
+		if err := s.minimizer.RestoreLast(req.GetToFocusedDesktop()); err != nil {
+			s.logger.Error("failed to restore last minimised window", zap.Error(err))
+			return nil, fmt.Errorf("failed to restore last minimised window: %w", err)
+		}
+
+		return &empty.Empty{}, nil
+	}
+
+	if err := s.minimizer.Restore(bspc.ID(req.GetNodeId()), req.GetToFocusedDesktop()); err != nil {
+		s.logger.Error("failed to restore window", zap.Uint32("node_id", req.GetNodeId()), zap.Error(err))
+		return nil, fmt.Errorf("failed to restore window %d: %w", req.GetNodeId(), err)
+	}
+
+	return &empty.Empty{}, nil
+}
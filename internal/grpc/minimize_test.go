@@ -0,0 +1,146 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/diogox/bspc-go"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/diogox/bspm/internal/feature/minimize"
+	"github.com/diogox/bspm/internal/grpc"
+	"github.com/diogox/bspm/internal/grpc/bspm"
+	"github.com/diogox/bspm/internal/log"
+)
+
+func TestMinimizeServer_MinimizeFocused(t *testing.T) {
+	t.Run("should minimise focused window", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMinimize := minimize.NewMockFeature(ctrl)
+		mockMinimize.EXPECT().
+			Minimize().
+			Return(nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestMinimizeServer(logger, mockMinimize).
+			MinimizeFocused(context.Background(), &empty.Empty{})
+		assert.NoError(t, err)
+	})
+	t.Run("should return error when feature returns error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+
+		mockMinimize := minimize.NewMockFeature(ctrl)
+		mockMinimize.EXPECT().
+			Minimize().
+			Return(expectedErr)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestMinimizeServer(logger, mockMinimize).
+			MinimizeFocused(context.Background(), &empty.Empty{})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, expectedErr))
+	})
+}
+
+func TestMinimizeServer_MinimizeRestore(t *testing.T) {
+	t.Run("should restore window", func(t *testing.T) {
+		t.Run("last minimised one", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockMinimize := minimize.NewMockFeature(ctrl)
+			mockMinimize.EXPECT().
+				RestoreLast(false).
+				Return(nil)
+
+			logger, err := log.New(zaptest.NewLogger(t), false)
+			require.NoError(t, err)
+
+			_, err = grpc.
+				NewTestMinimizeServer(logger, mockMinimize).
+				MinimizeRestore(context.Background(), &bspm.MinimizeRestoreRequest{})
+			assert.NoError(t, err)
+		})
+		t.Run("to the focused desktop", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockMinimize := minimize.NewMockFeature(ctrl)
+			mockMinimize.EXPECT().
+				Restore(bspc.ID(3), true).
+				Return(nil)
+
+			logger, err := log.New(zaptest.NewLogger(t), false)
+			require.NoError(t, err)
+
+			_, err = grpc.
+				NewTestMinimizeServer(logger, mockMinimize).
+				MinimizeRestore(context.Background(), &bspm.MinimizeRestoreRequest{
+					NodeId:           3,
+					ToFocusedDesktop: true,
+				})
+			assert.NoError(t, err)
+		})
+	})
+	t.Run("should return error when feature returns error", func(t *testing.T) {
+		t.Run("when restoring last minimised one", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			expectedErr := errors.New("error")
+
+			mockMinimize := minimize.NewMockFeature(ctrl)
+			mockMinimize.EXPECT().
+				RestoreLast(false).
+				Return(expectedErr)
+
+			logger, err := log.New(zaptest.NewLogger(t), false)
+			require.NoError(t, err)
+
+			_, err = grpc.
+				NewTestMinimizeServer(logger, mockMinimize).
+				MinimizeRestore(context.Background(), &bspm.MinimizeRestoreRequest{})
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, expectedErr))
+		})
+		t.Run("when restoring to the focused desktop", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			expectedErr := errors.New("error")
+
+			mockMinimize := minimize.NewMockFeature(ctrl)
+			mockMinimize.EXPECT().
+				Restore(bspc.ID(3), true).
+				Return(expectedErr)
+
+			logger, err := log.New(zaptest.NewLogger(t), false)
+			require.NoError(t, err)
+
+			_, err = grpc.
+				NewTestMinimizeServer(logger, mockMinimize).
+				MinimizeRestore(context.Background(), &bspm.MinimizeRestoreRequest{
+					NodeId:           3,
+					ToFocusedDesktop: true,
+				})
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, expectedErr))
+		})
+	})
+}
@@ -0,0 +1,32 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"go.uber.org/zap"
+
+	"github.com/diogox/bspm/internal/feature/scratchpad"
+	"github.com/diogox/bspm/internal/grpc/bspm"
+	"github.com/diogox/bspm/internal/log"
+)
+
+type scratchpadServer struct {
+	logger      *log.Logger
+	scratchpads scratchpad.Feature
+}
+
+func (s *scratchpadServer) ScratchpadToggle(_ context.Context, req *bspm.ScratchpadToggleRequest) (*empty.Empty, error) {
+	definition := scratchpad.Definition{
+		ClassName: req.GetClassName(),
+		Command:   req.GetCommand(),
+	}
+
+	if err := s.scratchpads.Toggle(req.GetName(), definition); err != nil {
+		s.logger.Error("failed to toggle scratchpad", zap.String("name", req.GetName()), zap.Error(err))
+		return nil, fmt.Errorf("failed to toggle scratchpad %s: %w", req.GetName(), err)
+	}
+
+	return &empty.Empty{}, nil
+}
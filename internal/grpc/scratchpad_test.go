@@ -0,0 +1,68 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/diogox/bspm/internal/feature/scratchpad"
+	"github.com/diogox/bspm/internal/grpc"
+	"github.com/diogox/bspm/internal/grpc/bspm"
+	"github.com/diogox/bspm/internal/log"
+)
+
+func TestScratchpadServer_ScratchpadToggle(t *testing.T) {
+	req := &bspm.ScratchpadToggleRequest{
+		Name:      "term",
+		ClassName: "scratch-term",
+		Command:   "alacritty --class scratch-term",
+	}
+
+	definition := scratchpad.Definition{
+		ClassName: "scratch-term",
+		Command:   "alacritty --class scratch-term",
+	}
+
+	t.Run("should toggle scratchpad", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockScratchpads := scratchpad.NewMockFeature(ctrl)
+		mockScratchpads.EXPECT().
+			Toggle("term", definition).
+			Return(nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestScratchpadServer(logger, mockScratchpads).
+			ScratchpadToggle(context.Background(), req)
+		assert.NoError(t, err)
+	})
+	t.Run("should return error when feature returns error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+
+		mockScratchpads := scratchpad.NewMockFeature(ctrl)
+		mockScratchpads.EXPECT().
+			Toggle("term", definition).
+			Return(expectedErr)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestScratchpadServer(logger, mockScratchpads).
+			ScratchpadToggle(context.Background(), req)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, expectedErr))
+	})
+}
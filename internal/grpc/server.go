@@ -1,29 +1,81 @@
-//go:generate mockgen -package grpc -destination bspm_proto_mock.go github.com/diogox/bspm/internal/grpc/bspm BSPM_MonocleModeSubscribeServer
+//go:generate mockgen -package grpc -destination bspm_proto_mock.go github.com/diogox/bspm/internal/grpc/bspm BSPM_MonocleModeSubscribeServer,BSPM_SubscribeServer,BSPM_EventsServer
 
 package grpc
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
+	"sort"
 
+	"github.com/diogox/bspc-go"
 	"github.com/golang/protobuf/ptypes/empty"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
 
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	eventforwarding "github.com/diogox/bspm/internal/feature/event_forwarding"
+	focushistory "github.com/diogox/bspm/internal/feature/focus_history"
+	"github.com/diogox/bspm/internal/feature/layout"
+	"github.com/diogox/bspm/internal/feature/mark"
+	"github.com/diogox/bspm/internal/feature/minimize"
+	"github.com/diogox/bspm/internal/feature/scratchpad"
+	"github.com/diogox/bspm/internal/feature/tabbed"
 	transparentmonocle "github.com/diogox/bspm/internal/feature/transparent_monocle"
 	"github.com/diogox/bspm/internal/grpc/bspm"
 	"github.com/diogox/bspm/internal/log"
+	"github.com/diogox/bspm/internal/subscription"
 )
 
 const unixSocketPath = "/tmp/bspm.socket"
 
-func NewServer(logger *log.Logger, monocleService transparentmonocle.Feature) (func() error, func()) {
+func NewServer(
+	logger *log.Logger,
+	monocleService transparentmonocle.Feature,
+	eventForwarding eventforwarding.Feature,
+	scratchpads scratchpad.Feature,
+	layouts layout.Feature,
+	tabbedContainers tabbed.Feature,
+	minimizer minimize.Feature,
+	marks mark.Feature,
+	focusHistory focushistory.Feature,
+	subscriptions subscription.Manager,
+	timings *bspwmevent.Timings,
+) (func() error, func()) {
 	s := grpc.NewServer()
 	bspm.RegisterBSPMServer(s, &server{
-		logger:         logger,
-		monocleService: monocleService,
+		logger:          logger,
+		monocleService:  monocleService,
+		eventForwarding: eventForwarding,
+		subscriptions:   subscriptions,
+		timings:         timings,
+	})
+	bspm.RegisterScratchpadServer(s, &scratchpadServer{
+		logger:      logger,
+		scratchpads: scratchpads,
+	})
+	bspm.RegisterLayoutServer(s, &layoutServer{
+		logger:  logger,
+		layouts: layouts,
+	})
+	bspm.RegisterTabbedServer(s, &tabbedServer{
+		logger: logger,
+		tabbed: tabbedContainers,
+	})
+	bspm.RegisterMinimizeServer(s, &minimizeServer{
+		logger:    logger,
+		minimizer: minimizer,
+	})
+	bspm.RegisterMarkServer(s, &markServer{
+		logger: logger,
+		marks:  marks,
+	})
+	bspm.RegisterFocusHistoryServer(s, &focusHistoryServer{
+		logger:       logger,
+		focusHistory: focusHistory,
 	})
 
 	var (
@@ -48,8 +100,11 @@ func startServer(s *grpc.Server) error {
 }
 
 type server struct {
-	logger         *log.Logger
-	monocleService transparentmonocle.Feature
+	logger          *log.Logger
+	monocleService  transparentmonocle.Feature
+	eventForwarding eventforwarding.Feature
+	subscriptions   subscription.Manager
+	timings         *bspwmevent.Timings
 }
 
 func (s *server) MonocleModeToggle(context.Context, *empty.Empty) (*empty.Empty, error) {
@@ -83,7 +138,7 @@ func (s *server) MonocleModeCycle(_ context.Context, req *bspm.MonocleModeCycleR
 func (s *server) MonocleModeSubscribe(req *bspm.MonocleModeSubscribeRequest, stream bspm.BSPM_MonocleModeSubscribeServer) error {
 	switch req.Type {
 	case bspm.MonocleModeSubscriptionType_MONOCLE_MODE_SUBSCRIPTION_TYPE_NODE_COUNT:
-		for newCount := range s.monocleService.SubscribeNodeCount() {
+		for newCount := range s.monocleService.SubscribeNodeCount(stream.Context()) {
 			err := stream.Send(&bspm.MonocleModeSubscribeResponse{
 				SubscriptionType: &bspm.MonocleModeSubscribeResponse_NodeCount{
 					NodeCount: int32(newCount),
@@ -100,3 +155,142 @@ func (s *server) MonocleModeSubscribe(req *bspm.MonocleModeSubscribeRequest, str
 
 	return nil
 }
+
+func (s *server) Subscribe(req *bspm.SubscribeRequest, stream bspm.BSPM_SubscribeServer) error {
+	requestedTopics := req.Topics
+	if len(requestedTopics) == 0 {
+		requestedTopics = allTopics
+	}
+
+	desktopIDs := make(map[bspc.ID]struct{}, len(req.DesktopIds))
+	for _, id := range req.DesktopIds {
+		desktopIDs[bspc.ID(id)] = struct{}{}
+	}
+
+	// Subscriptions are cancelled once the client goes away.
+	var (
+		ctx   = stream.Context()
+		resCh = make(chan response)
+	)
+
+	for _, t := range requestedTopics {
+		st, ok := streamTopics[t]
+		if !ok {
+			return fmt.Errorf("invalid subscription topic: %s", t)
+		}
+
+		st.forward(ctx, s.subscriptions, resCh)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case res := <-resCh:
+			_, isRequested := desktopIDs[res.desktopID]
+			if len(desktopIDs) != 0 && res.desktopID != bspc.NilID && !isRequested {
+				continue
+			}
+
+			if err := stream.Send(res.msg); err != nil {
+				return fmt.Errorf("failed to send subscription response: %w", err)
+			}
+		}
+	}
+}
+
+func (s *server) Events(req *bspm.EventsRequest, stream bspm.BSPM_EventsServer) error {
+	eventTypes := make([]bspc.EventType, 0, len(req.EventTypes))
+	for _, t := range req.EventTypes {
+		eventType := bspc.EventType(t)
+		if !eventforwarding.IsForwarded(eventType) {
+			return fmt.Errorf("invalid event type: %s", t)
+		}
+
+		eventTypes = append(eventTypes, eventType)
+	}
+
+	ctx := stream.Context()
+	eventCh := s.eventForwarding.Subscribe(ctx, eventTypes)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case ev, ok := <-eventCh:
+			if !ok {
+				return nil
+			}
+
+			payload, err := json.Marshal(ev.Payload)
+			if err != nil {
+				s.logger.Error("failed to encode event payload",
+					zap.String("event_type", string(ev.Type)),
+					zap.Error(err),
+				)
+
+				continue
+			}
+
+			nodes := make([]*bspm.EventNode, 0, len(ev.Nodes))
+			for _, n := range ev.Nodes {
+				nodes = append(nodes, &bspm.EventNode{
+					Id:           uint32(n.ID),
+					ClassName:    n.ClassName,
+					InstanceName: n.InstanceName,
+					Title:        n.Title,
+				})
+			}
+
+			err = stream.Send(&bspm.EventsResponse{
+				EventType: string(ev.Type),
+				Payload:   string(payload),
+				Nodes:     nodes,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to send event: %w", err)
+			}
+		}
+	}
+}
+
+func (s *server) Metrics(context.Context, *empty.Empty) (*bspm.MetricsResponse, error) {
+	metrics := s.subscriptions.Metrics()
+
+	res := &bspm.MetricsResponse{
+		Topics: make([]*bspm.TopicMetrics, 0, len(metrics)),
+	}
+
+	for t, m := range metrics {
+		res.Topics = append(res.Topics, &bspm.TopicMetrics{
+			Topic:        string(t),
+			Dropped:      m.Dropped,
+			Disconnected: m.Disconnected,
+		})
+	}
+
+	sort.Slice(res.Topics, func(i, j int) bool {
+		return res.Topics[i].Topic < res.Topics[j].Topic
+	})
+
+	if s.timings == nil {
+		return res, nil
+	}
+
+	for eventType, t := range s.timings.Snapshot() {
+		res.Callbacks = append(res.Callbacks, &bspm.CallbackMetrics{
+			EventType: string(eventType),
+			Calls:     t.Calls,
+			Total:     durationpb.New(t.Total),
+			Max:       durationpb.New(t.Max),
+		})
+	}
+
+	sort.Slice(res.Callbacks, func(i, j int) bool {
+		return res.Callbacks[i].EventType < res.Callbacks[j].EventType
+	})
+
+	return res, nil
+}
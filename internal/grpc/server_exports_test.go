@@ -1,13 +1,73 @@
 package grpc
 
 import (
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	eventforwarding "github.com/diogox/bspm/internal/feature/event_forwarding"
+	focushistory "github.com/diogox/bspm/internal/feature/focus_history"
+	"github.com/diogox/bspm/internal/feature/layout"
+	"github.com/diogox/bspm/internal/feature/mark"
+	"github.com/diogox/bspm/internal/feature/minimize"
+	"github.com/diogox/bspm/internal/feature/scratchpad"
+	"github.com/diogox/bspm/internal/feature/tabbed"
 	transparentmonocle "github.com/diogox/bspm/internal/feature/transparent_monocle"
 	"github.com/diogox/bspm/internal/log"
+	"github.com/diogox/bspm/internal/subscription"
 )
 
-func NewTestServer(logger *log.Logger, monocleService transparentmonocle.Feature) *server {
+func NewTestServer(
+	logger *log.Logger,
+	monocleService transparentmonocle.Feature,
+	eventForwarding eventforwarding.Feature,
+	subscriptions subscription.Manager,
+	timings *bspwmevent.Timings,
+) *server {
 	return &server{
-		logger:         logger,
-		monocleService: monocleService,
+		logger:          logger,
+		monocleService:  monocleService,
+		eventForwarding: eventForwarding,
+		subscriptions:   subscriptions,
+		timings:         timings,
+	}
+}
+
+func NewTestScratchpadServer(logger *log.Logger, scratchpads scratchpad.Feature) *scratchpadServer {
+	return &scratchpadServer{
+		logger:      logger,
+		scratchpads: scratchpads,
+	}
+}
+
+func NewTestLayoutServer(logger *log.Logger, layouts layout.Feature) *layoutServer {
+	return &layoutServer{
+		logger:  logger,
+		layouts: layouts,
+	}
+}
+
+func NewTestTabbedServer(logger *log.Logger, tabbedContainers tabbed.Feature) *tabbedServer {
+	return &tabbedServer{
+		logger: logger,
+		tabbed: tabbedContainers,
+	}
+}
+
+func NewTestMinimizeServer(logger *log.Logger, minimizer minimize.Feature) *minimizeServer {
+	return &minimizeServer{
+		logger:    logger,
+		minimizer: minimizer,
+	}
+}
+
+func NewTestMarkServer(logger *log.Logger, marks mark.Feature) *markServer {
+	return &markServer{
+		logger: logger,
+		marks:  marks,
+	}
+}
+
+func NewTestFocusHistoryServer(logger *log.Logger, focusHistory focushistory.Feature) *focusHistoryServer {
+	return &focusHistoryServer{
+		logger:       logger,
+		focusHistory: focusHistory,
 	}
 }
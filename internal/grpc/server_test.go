@@ -4,17 +4,25 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/diogox/bspc-go"
 	"github.com/golang/mock/gomock"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	eventforwarding "github.com/diogox/bspm/internal/feature/event_forwarding"
+	eventforwardingtopic "github.com/diogox/bspm/internal/feature/event_forwarding/topic"
 	transparentmonocle "github.com/diogox/bspm/internal/feature/transparent_monocle"
+	"github.com/diogox/bspm/internal/feature/transparent_monocle/state"
+	"github.com/diogox/bspm/internal/feature/transparent_monocle/topic"
 	"github.com/diogox/bspm/internal/grpc"
 	"github.com/diogox/bspm/internal/grpc/bspm"
 	"github.com/diogox/bspm/internal/log"
+	"github.com/diogox/bspm/internal/subscription"
 )
 
 func TestServer_MonocleModeToggle(t *testing.T) {
@@ -31,7 +39,7 @@ func TestServer_MonocleModeToggle(t *testing.T) {
 		require.NoError(t, err)
 
 		_, err = grpc.
-			NewTestServer(logger, mockService).
+			NewTestServer(logger, mockService, nil, nil, nil).
 			MonocleModeToggle(context.Background(), &empty.Empty{})
 		assert.NoError(t, err)
 	})
@@ -50,7 +58,7 @@ func TestServer_MonocleModeToggle(t *testing.T) {
 		require.NoError(t, err)
 
 		_, err = grpc.
-			NewTestServer(logger, mockService).
+			NewTestServer(logger, mockService, nil, nil, nil).
 			MonocleModeToggle(context.Background(), &empty.Empty{})
 		require.Error(t, err)
 		assert.True(t, errors.Is(err, expectedErr))
@@ -72,7 +80,7 @@ func TestServer_MonocleModeCycle(t *testing.T) {
 			require.NoError(t, err)
 
 			_, err = grpc.
-				NewTestServer(logger, mockService).
+				NewTestServer(logger, mockService, nil, nil, nil).
 				MonocleModeCycle(context.Background(), &bspm.MonocleModeCycleRequest{
 					CycleDirection: bspm.CycleDir_CYCLE_DIR_NEXT,
 				})
@@ -91,7 +99,7 @@ func TestServer_MonocleModeCycle(t *testing.T) {
 			require.NoError(t, err)
 
 			_, err = grpc.
-				NewTestServer(logger, mockService).
+				NewTestServer(logger, mockService, nil, nil, nil).
 				MonocleModeCycle(context.Background(), &bspm.MonocleModeCycleRequest{
 					CycleDirection: bspm.CycleDir_CYCLE_DIR_PREV,
 				})
@@ -114,7 +122,7 @@ func TestServer_MonocleModeCycle(t *testing.T) {
 			require.NoError(t, err)
 
 			_, err = grpc.
-				NewTestServer(logger, mockService).
+				NewTestServer(logger, mockService, nil, nil, nil).
 				MonocleModeCycle(context.Background(), &bspm.MonocleModeCycleRequest{
 					CycleDirection: bspm.CycleDir_CYCLE_DIR_NEXT,
 				})
@@ -136,7 +144,7 @@ func TestServer_MonocleModeCycle(t *testing.T) {
 			require.NoError(t, err)
 
 			_, err = grpc.
-				NewTestServer(logger, mockService).
+				NewTestServer(logger, mockService, nil, nil, nil).
 				MonocleModeCycle(context.Background(), &bspm.MonocleModeCycleRequest{
 					CycleDirection: bspm.CycleDir_CYCLE_DIR_PREV,
 				})
@@ -162,8 +170,11 @@ func TestServer_MonocleModeSubscribe(t *testing.T) {
 		)
 
 		gomock.InOrder(
+			mockGRPCSubscribeServer.EXPECT().
+				Context().
+				Return(context.Background()),
 			mockService.EXPECT().
-				SubscribeNodeCount().
+				SubscribeNodeCount(gomock.Any()).
 				Return(countCh),
 			mockGRPCSubscribeServer.EXPECT().
 				Send(&bspm.MonocleModeSubscribeResponse{
@@ -184,7 +195,7 @@ func TestServer_MonocleModeSubscribe(t *testing.T) {
 		require.NoError(t, err)
 
 		err = grpc.
-			NewTestServer(logger, mockService).
+			NewTestServer(logger, mockService, nil, nil, nil).
 			MonocleModeSubscribe(&bspm.MonocleModeSubscribeRequest{
 				Type: bspm.MonocleModeSubscriptionType_MONOCLE_MODE_SUBSCRIPTION_TYPE_NODE_COUNT,
 			}, mockGRPCSubscribeServer)
@@ -206,8 +217,11 @@ func TestServer_MonocleModeSubscribe(t *testing.T) {
 			)
 
 			gomock.InOrder(
+				mockGRPCSubscribeServer.EXPECT().
+					Context().
+					Return(context.Background()),
 				mockService.EXPECT().
-					SubscribeNodeCount().
+					SubscribeNodeCount(gomock.Any()).
 					Return(countCh),
 				mockGRPCSubscribeServer.EXPECT().
 					Send(gomock.Any()).
@@ -224,7 +238,7 @@ func TestServer_MonocleModeSubscribe(t *testing.T) {
 			require.NoError(t, err)
 
 			err = grpc.
-				NewTestServer(logger, mockService).
+				NewTestServer(logger, mockService, nil, nil, nil).
 				MonocleModeSubscribe(&bspm.MonocleModeSubscribeRequest{
 					Type: bspm.MonocleModeSubscriptionType_MONOCLE_MODE_SUBSCRIPTION_TYPE_NODE_COUNT,
 				}, mockGRPCSubscribeServer)
@@ -244,7 +258,7 @@ func TestServer_MonocleModeSubscribe(t *testing.T) {
 			require.NoError(t, err)
 
 			err = grpc.
-				NewTestServer(logger, mockService).
+				NewTestServer(logger, mockService, nil, nil, nil).
 				MonocleModeSubscribe(&bspm.MonocleModeSubscribeRequest{
 					Type: bspm.MonocleModeSubscriptionType_MONOCLE_MODE_SUBSCRIPTION_TYPE_INVALID,
 				}, mockGRPCSubscribeServer)
@@ -252,3 +266,356 @@ func TestServer_MonocleModeSubscribe(t *testing.T) {
 		})
 	})
 }
+
+func TestServer_Subscribe(t *testing.T) {
+	var (
+		selectedNodeID = bspc.ID(2)
+		st             = state.State{
+			SelectedNodeID: &selectedNodeID,
+			HiddenNodeIDs:  []bspc.ID{3},
+		}
+	)
+
+	t.Run("should stream messages for the requested topics", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			ctx, cancel = context.WithCancel(context.Background())
+			enabledCh   = make(chan interface{}, 1)
+		)
+		defer cancel()
+
+		var (
+			mockSubscriptions       = subscription.NewMockManager(ctrl)
+			mockGRPCSubscribeServer = grpc.NewMockBSPM_SubscribeServer(ctrl)
+		)
+
+		mockGRPCSubscribeServer.EXPECT().
+			Context().
+			Return(ctx)
+		mockSubscriptions.EXPECT().
+			Subscribe(gomock.Any(), state.EnabledTopic.Name()).
+			Return(enabledCh)
+		mockGRPCSubscribeServer.EXPECT().
+			Send(&bspm.SubscribeResponse{
+				Topic: bspm.Topic_TOPIC_MONOCLE_ENABLED,
+				Payload: &bspm.SubscribeResponse_MonocleState{
+					MonocleState: &bspm.MonocleState{
+						DesktopId:      1,
+						SelectedNodeId: 2,
+						HiddenNodeIds:  []uint32{3},
+					},
+				},
+			}).
+			Do(func(interface{}) {
+				// End test
+				cancel()
+			}).
+			Return(nil)
+
+		enabledCh <- state.Event{DesktopID: bspc.ID(1), State: st}
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		err = grpc.
+			NewTestServer(logger, nil, nil, mockSubscriptions, nil).
+			Subscribe(&bspm.SubscribeRequest{
+				Topics: []bspm.Topic{bspm.Topic_TOPIC_MONOCLE_ENABLED},
+			}, mockGRPCSubscribeServer)
+		require.NoError(t, err)
+	})
+	t.Run("should skip messages for other desktops", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			ctx, cancel    = context.WithCancel(context.Background())
+			desktopFocusCh = make(chan interface{}, 2)
+		)
+		defer cancel()
+
+		var (
+			mockSubscriptions       = subscription.NewMockManager(ctrl)
+			mockGRPCSubscribeServer = grpc.NewMockBSPM_SubscribeServer(ctrl)
+		)
+
+		mockGRPCSubscribeServer.EXPECT().
+			Context().
+			Return(ctx)
+		mockSubscriptions.EXPECT().
+			Subscribe(gomock.Any(), topic.MonocleDesktopFocusChanged.Name()).
+			Return(desktopFocusCh)
+		mockGRPCSubscribeServer.EXPECT().
+			Send(&bspm.SubscribeResponse{
+				Topic: bspm.Topic_TOPIC_MONOCLE_DESKTOP_FOCUS_CHANGED,
+				Payload: &bspm.SubscribeResponse_DesktopFocus{
+					DesktopFocus: &bspm.DesktopFocus{
+						MonitorId: 1,
+						DesktopId: 3,
+					},
+				},
+			}).
+			Do(func(interface{}) {
+				// End test
+				cancel()
+			}).
+			Return(nil)
+
+		desktopFocusCh <- bspc.EventDesktopFocus{MonitorID: bspc.ID(1), DesktopID: bspc.ID(2)}
+		desktopFocusCh <- bspc.EventDesktopFocus{MonitorID: bspc.ID(1), DesktopID: bspc.ID(3)}
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		err = grpc.
+			NewTestServer(logger, nil, nil, mockSubscriptions, nil).
+			Subscribe(&bspm.SubscribeRequest{
+				Topics:     []bspm.Topic{bspm.Topic_TOPIC_MONOCLE_DESKTOP_FOCUS_CHANGED},
+				DesktopIds: []uint32{3},
+			}, mockGRPCSubscribeServer)
+		require.NoError(t, err)
+	})
+	t.Run("should return error when", func(t *testing.T) {
+		t.Run("sending subscription message fails", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var (
+				disabledCh  = make(chan interface{}, 1)
+				expectedErr = errors.New("error")
+			)
+
+			var (
+				mockSubscriptions       = subscription.NewMockManager(ctrl)
+				mockGRPCSubscribeServer = grpc.NewMockBSPM_SubscribeServer(ctrl)
+			)
+
+			mockGRPCSubscribeServer.EXPECT().
+				Context().
+				Return(context.Background())
+			mockSubscriptions.EXPECT().
+				Subscribe(gomock.Any(), state.DisabledTopic.Name()).
+				Return(disabledCh)
+			mockGRPCSubscribeServer.EXPECT().
+				Send(gomock.Any()).
+				Return(expectedErr)
+
+			disabledCh <- state.Event{DesktopID: bspc.ID(1), State: st}
+
+			logger, err := log.New(zaptest.NewLogger(t), false)
+			require.NoError(t, err)
+
+			err = grpc.
+				NewTestServer(logger, nil, nil, mockSubscriptions, nil).
+				Subscribe(&bspm.SubscribeRequest{
+					Topics: []bspm.Topic{bspm.Topic_TOPIC_MONOCLE_DISABLED},
+				}, mockGRPCSubscribeServer)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, expectedErr))
+		})
+		t.Run("topic is invalid", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockGRPCSubscribeServer := grpc.NewMockBSPM_SubscribeServer(ctrl)
+			mockGRPCSubscribeServer.EXPECT().
+				Context().
+				Return(context.Background())
+
+			logger, err := log.New(zaptest.NewLogger(t), false)
+			require.NoError(t, err)
+
+			err = grpc.
+				NewTestServer(logger, nil, nil, nil, nil).
+				Subscribe(&bspm.SubscribeRequest{
+					Topics: []bspm.Topic{bspm.Topic_TOPIC_INVALID},
+				}, mockGRPCSubscribeServer)
+			assert.Error(t, err)
+		})
+	})
+}
+
+func TestServer_Events(t *testing.T) {
+	t.Run("should stream bspwm events", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			ctx, cancel = context.WithCancel(context.Background())
+			eventCh     = make(chan eventforwarding.Event, 1)
+		)
+		defer cancel()
+
+		var (
+			mockEventForwarding  = eventforwarding.NewMockFeature(ctrl)
+			mockGRPCEventsServer = grpc.NewMockBSPM_EventsServer(ctrl)
+		)
+
+		mockEventForwarding.EXPECT().
+			Subscribe(gomock.Any(), []bspc.EventType{bspc.EventTypeNodeFocus}).
+			Return(eventCh)
+		mockGRPCEventsServer.EXPECT().
+			Context().
+			Return(ctx).
+			AnyTimes()
+		mockGRPCEventsServer.EXPECT().
+			Send(&bspm.EventsResponse{
+				EventType: string(bspc.EventTypeNodeFocus),
+				Payload:   `{"MonitorID":1,"DesktopID":2,"NodeID":3}`,
+				Nodes: []*bspm.EventNode{
+					{
+						Id:        3,
+						ClassName: "Alacritty",
+						Title:     "~/bspm",
+					},
+				},
+			}).
+			Do(func(interface{}) {
+				// End test
+				cancel()
+			}).
+			Return(nil)
+
+		eventCh <- eventforwarding.Event{
+			Type: bspc.EventTypeNodeFocus,
+			Payload: bspc.EventNodeFocus{
+				MonitorID: bspc.ID(1),
+				DesktopID: bspc.ID(2),
+				NodeID:    bspc.ID(3),
+			},
+			Nodes: []eventforwarding.Node{
+				{
+					ID:        bspc.ID(3),
+					ClassName: "Alacritty",
+					Title:     "~/bspm",
+				},
+			},
+		}
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		err = grpc.
+			NewTestServer(logger, nil, mockEventForwarding, nil, nil).
+			Events(&bspm.EventsRequest{
+				EventTypes: []string{string(bspc.EventTypeNodeFocus)},
+			}, mockGRPCEventsServer)
+		require.NoError(t, err)
+	})
+	t.Run("should return error when", func(t *testing.T) {
+		t.Run("sending event fails", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var (
+				eventCh     = make(chan eventforwarding.Event, 1)
+				expectedErr = errors.New("error")
+			)
+
+			var (
+				mockEventForwarding  = eventforwarding.NewMockFeature(ctrl)
+				mockGRPCEventsServer = grpc.NewMockBSPM_EventsServer(ctrl)
+			)
+
+			mockEventForwarding.EXPECT().
+				Subscribe(gomock.Any(), []bspc.EventType{}).
+				Return(eventCh)
+			mockGRPCEventsServer.EXPECT().
+				Context().
+				Return(context.Background()).
+				AnyTimes()
+			mockGRPCEventsServer.EXPECT().
+				Send(gomock.Any()).
+				Return(expectedErr)
+
+			eventCh <- eventforwarding.Event{Type: bspc.EventTypeNodeAdd, Payload: bspc.EventNodeAdd{}}
+
+			logger, err := log.New(zaptest.NewLogger(t), false)
+			require.NoError(t, err)
+
+			err = grpc.
+				NewTestServer(logger, nil, mockEventForwarding, nil, nil).
+				Events(&bspm.EventsRequest{}, mockGRPCEventsServer)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, expectedErr))
+		})
+		t.Run("event type is invalid", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger, err := log.New(zaptest.NewLogger(t), false)
+			require.NoError(t, err)
+
+			err = grpc.
+				NewTestServer(logger, nil, nil, nil, nil).
+				Events(&bspm.EventsRequest{
+					EventTypes: []string{"invalid"},
+				}, grpc.NewMockBSPM_EventsServer(ctrl))
+			assert.Error(t, err)
+		})
+	})
+}
+
+func TestServer_Metrics(t *testing.T) {
+	t.Run("should return subscription metrics sorted by topic", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSubscriptions := subscription.NewMockManager(ctrl)
+		mockSubscriptions.EXPECT().
+			Metrics().
+			Return(subscription.Metrics{
+				state.ChangedTopic.Name():              {Dropped: 3},
+				eventforwardingtopic.BspwmEvent.Name(): {Dropped: 2, Disconnected: 1},
+			})
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		res, err := grpc.
+			NewTestServer(logger, nil, nil, mockSubscriptions, nil).
+			Metrics(context.Background(), &empty.Empty{})
+		require.NoError(t, err)
+
+		assert.Equal(t, []*bspm.TopicMetrics{
+			{Topic: string(eventforwardingtopic.BspwmEvent), Dropped: 2, Disconnected: 1},
+			{Topic: string(state.ChangedTopic), Dropped: 3},
+		}, res.GetTopics())
+		assert.Empty(t, res.GetCallbacks())
+	})
+	t.Run("should return callback timings sorted by event type", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSubscriptions := subscription.NewMockManager(ctrl)
+		mockSubscriptions.EXPECT().
+			Metrics().
+			Return(subscription.Metrics{})
+
+		timings := bspwmevent.NewTimings()
+		timings.Observe(bspc.EventTypeNodeRemove, time.Millisecond)
+		timings.Observe(bspc.EventTypeNodeAdd, 2*time.Millisecond)
+		timings.Observe(bspc.EventTypeNodeAdd, time.Millisecond)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		res, err := grpc.
+			NewTestServer(logger, nil, nil, mockSubscriptions, timings).
+			Metrics(context.Background(), &empty.Empty{})
+		require.NoError(t, err)
+
+		callbacks := res.GetCallbacks()
+		require.Len(t, callbacks, 2)
+
+		assert.Equal(t, string(bspc.EventTypeNodeAdd), callbacks[0].GetEventType())
+		assert.Equal(t, uint64(2), callbacks[0].GetCalls())
+		assert.Equal(t, 3*time.Millisecond, callbacks[0].GetTotal().AsDuration())
+		assert.Equal(t, 2*time.Millisecond, callbacks[0].GetMax().AsDuration())
+
+		assert.Equal(t, string(bspc.EventTypeNodeRemove), callbacks[1].GetEventType())
+		assert.Equal(t, uint64(1), callbacks[1].GetCalls())
+	})
+}
@@ -0,0 +1,57 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/diogox/bspc-go"
+	"github.com/golang/protobuf/ptypes/empty"
+	"go.uber.org/zap"
+
+	"github.com/diogox/bspm/internal/feature/tabbed"
+	"github.com/diogox/bspm/internal/grpc/bspm"
+	"github.com/diogox/bspm/internal/log"
+)
+
+type tabbedServer struct {
+	logger *log.Logger
+	tabbed tabbed.Feature
+}
+
+func (s *tabbedServer) TabbedToggle(context.Context, *empty.Empty) (*empty.Empty, error) {
+	if err := s.tabbed.Toggle(); err != nil {
+		s.logger.Error("failed to toggle tabbed container", zap.Error(err))
+		return nil, fmt.Errorf("failed to toggle tabbed container: %w", err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *tabbedServer) TabbedCycle(_ context.Context, req *bspm.TabbedCycleRequest) (*empty.Empty, error) {
+	switch req.GetCycleDirection() {
+	case bspm.CycleDir_CYCLE_DIR_PREV:
+		if err := s.tabbed.FocusPreviousTab(); err != nil {
+			s.logger.Error("failed to focus previous tab", zap.Error(err))
+			return nil, fmt.Errorf("failed to focus previous tab: %w", err)
+		}
+	case bspm.CycleDir_CYCLE_DIR_NEXT:
+		if err := s.tabbed.FocusNextTab(); err != nil {
+			s.logger.Error("failed to focus next tab", zap.Error(err))
+			return nil, fmt.Errorf("failed to focus next tab: %w", err)
+		}
+	default:
+		return nil, errors.New("invalid tab cycling direction")
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *tabbedServer) TabbedSelect(_ context.Context, req *bspm.TabbedSelectRequest) (*empty.Empty, error) {
+	if err := s.tabbed.Select(bspc.ID(req.GetNodeId())); err != nil {
+		s.logger.Error("failed to select tab", zap.Uint32("node_id", req.GetNodeId()), zap.Error(err))
+		return nil, fmt.Errorf("failed to select tab %d: %w", req.GetNodeId(), err)
+	}
+
+	return &empty.Empty{}, nil
+}
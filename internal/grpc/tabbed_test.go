@@ -0,0 +1,200 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/diogox/bspc-go"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/diogox/bspm/internal/feature/tabbed"
+	"github.com/diogox/bspm/internal/grpc"
+	"github.com/diogox/bspm/internal/grpc/bspm"
+	"github.com/diogox/bspm/internal/log"
+)
+
+func TestTabbedServer_TabbedToggle(t *testing.T) {
+	t.Run("should toggle tabbed container", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTabbed := tabbed.NewMockFeature(ctrl)
+		mockTabbed.EXPECT().
+			Toggle().
+			Return(nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestTabbedServer(logger, mockTabbed).
+			TabbedToggle(context.Background(), &empty.Empty{})
+		assert.NoError(t, err)
+	})
+	t.Run("should return error when feature returns error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+
+		mockTabbed := tabbed.NewMockFeature(ctrl)
+		mockTabbed.EXPECT().
+			Toggle().
+			Return(expectedErr)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestTabbedServer(logger, mockTabbed).
+			TabbedToggle(context.Background(), &empty.Empty{})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, expectedErr))
+	})
+}
+
+func TestTabbedServer_TabbedCycle(t *testing.T) {
+	t.Run("should cycle tabs", func(t *testing.T) {
+		t.Run("to next tab", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTabbed := tabbed.NewMockFeature(ctrl)
+			mockTabbed.EXPECT().
+				FocusNextTab().
+				Return(nil)
+
+			logger, err := log.New(zaptest.NewLogger(t), false)
+			require.NoError(t, err)
+
+			_, err = grpc.
+				NewTestTabbedServer(logger, mockTabbed).
+				TabbedCycle(context.Background(), &bspm.TabbedCycleRequest{
+					CycleDirection: bspm.CycleDir_CYCLE_DIR_NEXT,
+				})
+			assert.NoError(t, err)
+		})
+		t.Run("to previous tab", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTabbed := tabbed.NewMockFeature(ctrl)
+			mockTabbed.EXPECT().
+				FocusPreviousTab().
+				Return(nil)
+
+			logger, err := log.New(zaptest.NewLogger(t), false)
+			require.NoError(t, err)
+
+			_, err = grpc.
+				NewTestTabbedServer(logger, mockTabbed).
+				TabbedCycle(context.Background(), &bspm.TabbedCycleRequest{
+					CycleDirection: bspm.CycleDir_CYCLE_DIR_PREV,
+				})
+			assert.NoError(t, err)
+		})
+	})
+	t.Run("should return error when feature returns error", func(t *testing.T) {
+		t.Run("when cycling to next tab", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			expectedErr := errors.New("error")
+
+			mockTabbed := tabbed.NewMockFeature(ctrl)
+			mockTabbed.EXPECT().
+				FocusNextTab().
+				Return(expectedErr)
+
+			logger, err := log.New(zaptest.NewLogger(t), false)
+			require.NoError(t, err)
+
+			_, err = grpc.
+				NewTestTabbedServer(logger, mockTabbed).
+				TabbedCycle(context.Background(), &bspm.TabbedCycleRequest{
+					CycleDirection: bspm.CycleDir_CYCLE_DIR_NEXT,
+				})
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, expectedErr))
+		})
+		t.Run("when cycling to previous tab", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			expectedErr := errors.New("error")
+
+			mockTabbed := tabbed.NewMockFeature(ctrl)
+			mockTabbed.EXPECT().
+				FocusPreviousTab().
+				Return(expectedErr)
+
+			logger, err := log.New(zaptest.NewLogger(t), false)
+			require.NoError(t, err)
+
+			_, err = grpc.
+				NewTestTabbedServer(logger, mockTabbed).
+				TabbedCycle(context.Background(), &bspm.TabbedCycleRequest{
+					CycleDirection: bspm.CycleDir_CYCLE_DIR_PREV,
+				})
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, expectedErr))
+		})
+	})
+	t.Run("should return error when direction is invalid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestTabbedServer(logger, tabbed.NewMockFeature(ctrl)).
+			TabbedCycle(context.Background(), &bspm.TabbedCycleRequest{})
+		assert.Error(t, err)
+	})
+}
+
+func TestTabbedServer_TabbedSelect(t *testing.T) {
+	t.Run("should select tab", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTabbed := tabbed.NewMockFeature(ctrl)
+		mockTabbed.EXPECT().
+			Select(bspc.ID(3)).
+			Return(nil)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestTabbedServer(logger, mockTabbed).
+			TabbedSelect(context.Background(), &bspm.TabbedSelectRequest{NodeId: 3})
+		assert.NoError(t, err)
+	})
+	t.Run("should return error when feature returns error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+
+		mockTabbed := tabbed.NewMockFeature(ctrl)
+		mockTabbed.EXPECT().
+			Select(bspc.ID(3)).
+			Return(expectedErr)
+
+		logger, err := log.New(zaptest.NewLogger(t), false)
+		require.NoError(t, err)
+
+		_, err = grpc.
+			NewTestTabbedServer(logger, mockTabbed).
+			TabbedSelect(context.Background(), &bspm.TabbedSelectRequest{NodeId: 3})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, expectedErr))
+	})
+}
@@ -0,0 +1,253 @@
+package grpc
+
+import (
+	"context"
+	"sort"
+
+	"github.com/diogox/bspc-go"
+
+	bspwmevent "github.com/diogox/bspm/internal/bspwm/event"
+	"github.com/diogox/bspm/internal/feature/layout"
+	"github.com/diogox/bspm/internal/feature/minimize"
+	"github.com/diogox/bspm/internal/feature/scratchpad"
+	"github.com/diogox/bspm/internal/feature/tabbed"
+	"github.com/diogox/bspm/internal/feature/transparent_monocle/state"
+	"github.com/diogox/bspm/internal/feature/transparent_monocle/topic"
+	"github.com/diogox/bspm/internal/grpc/bspm"
+	"github.com/diogox/bspm/internal/subscription"
+)
+
+type (
+	// response is a message for clients of the Subscribe stream, along with the id of the desktop it refers to,
+	// if any.
+	response struct {
+		msg       *bspm.SubscribeResponse
+		desktopID bspc.ID
+	}
+
+	// streamTopic adapts a typed internal topic to the Subscribe stream, where all topics share the same message.
+	streamTopic struct {
+		name subscription.Name
+
+		// forward subscribes to the internal topic, and sends its payloads to resCh, until the context is done.
+		forward func(ctx context.Context, subscriptions subscription.Manager, resCh chan<- response)
+	}
+)
+
+// streamTopics maps the topics exposed to clients to the ones used internally by the subscription manager.
+var streamTopics = map[bspm.Topic]streamTopic{
+	bspm.Topic_TOPIC_MONOCLE_ENABLED:               adapt(state.EnabledTopic, toMonocleStateResponse(bspm.Topic_TOPIC_MONOCLE_ENABLED)),
+	bspm.Topic_TOPIC_MONOCLE_DISABLED:              adapt(state.DisabledTopic, toMonocleStateResponse(bspm.Topic_TOPIC_MONOCLE_DISABLED)),
+	bspm.Topic_TOPIC_MONOCLE_STATE_CHANGED:         adapt(state.ChangedTopic, toMonocleStateResponse(bspm.Topic_TOPIC_MONOCLE_STATE_CHANGED)),
+	bspm.Topic_TOPIC_MONOCLE_DESKTOP_FOCUS_CHANGED: adapt(topic.MonocleDesktopFocusChanged, toDesktopFocusResponse),
+	bspm.Topic_TOPIC_BSPWM_RECONNECTED:             adapt(bspwmevent.ReconnectedTopic, toBspwmReconnectedResponse),
+	bspm.Topic_TOPIC_SCRATCHPAD_STATE_CHANGED:      adapt(scratchpad.Topic(subscription.Wildcard), toScratchpadStateResponse),
+	bspm.Topic_TOPIC_LAYOUT_CHANGED:                adapt(layout.AnyTopic, toLayoutStateResponse),
+	bspm.Topic_TOPIC_TABBED_CHANGED:                adapt(tabbed.AnyTopic, toTabbedStateResponse),
+	bspm.Topic_TOPIC_MINIMIZED_CHANGED:             adapt(minimize.ChangedTopic, toMinimizedStateResponse),
+}
+
+// allTopics is used when a client doesn't specify which topics it wants to subscribe to.
+var allTopics = []bspm.Topic{
+	bspm.Topic_TOPIC_MONOCLE_ENABLED,
+	bspm.Topic_TOPIC_MONOCLE_DISABLED,
+	bspm.Topic_TOPIC_MONOCLE_STATE_CHANGED,
+	bspm.Topic_TOPIC_MONOCLE_DESKTOP_FOCUS_CHANGED,
+	bspm.Topic_TOPIC_BSPWM_RECONNECTED,
+	bspm.Topic_TOPIC_SCRATCHPAD_STATE_CHANGED,
+	bspm.Topic_TOPIC_LAYOUT_CHANGED,
+	bspm.Topic_TOPIC_TABBED_CHANGED,
+	bspm.Topic_TOPIC_MINIMIZED_CHANGED,
+}
+
+// TopicsMatching returns the client topics whose internal name matches the given one, which can be a pattern
+// (e.g. monocle/*). They're sorted, so they're always streamed in the same order.
+func TopicsMatching(pattern string) []bspm.Topic {
+	var topics []bspm.Topic
+	for t, st := range streamTopics {
+		if subscription.Match(subscription.Name(pattern), st.name) {
+			topics = append(topics, t)
+		}
+	}
+
+	sort.Slice(topics, func(i, j int) bool {
+		return topics[i] < topics[j]
+	})
+
+	return topics
+}
+
+func adapt[T any](internalTopic subscription.Topic[T], toResponse func(payload T) response) streamTopic {
+	return streamTopic{
+		name: internalTopic.Name(),
+		forward: func(ctx context.Context, subscriptions subscription.Manager, resCh chan<- response) {
+			payloadCh := internalTopic.Subscribe(ctx, subscriptions)
+
+			go func() {
+				for payload := range payloadCh {
+					select {
+					case resCh <- toResponse(payload):
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+		},
+	}
+}
+
+func toMonocleStateResponse(t bspm.Topic) func(ev state.Event) response {
+	return func(ev state.Event) response {
+		return response{
+			msg: &bspm.SubscribeResponse{
+				Topic: t,
+				Payload: &bspm.SubscribeResponse_MonocleState{
+					MonocleState: toMonocleState(ev),
+				},
+			},
+			desktopID: ev.DesktopID,
+		}
+	}
+}
+
+func toDesktopFocusResponse(ev bspc.EventDesktopFocus) response {
+	return response{
+		msg: &bspm.SubscribeResponse{
+			Topic: bspm.Topic_TOPIC_MONOCLE_DESKTOP_FOCUS_CHANGED,
+			Payload: &bspm.SubscribeResponse_DesktopFocus{
+				DesktopFocus: &bspm.DesktopFocus{
+					MonitorId: uint32(ev.MonitorID),
+					DesktopId: uint32(ev.DesktopID),
+				},
+			},
+		},
+		desktopID: ev.DesktopID,
+	}
+}
+
+// toBspwmReconnectedResponse doesn't refer to any desktop, so it's never filtered out.
+func toBspwmReconnectedResponse(ev bspwmevent.Reconnected) response {
+	return response{
+		msg: &bspm.SubscribeResponse{
+			Topic: bspm.Topic_TOPIC_BSPWM_RECONNECTED,
+			Payload: &bspm.SubscribeResponse_BspwmReconnected{
+				BspwmReconnected: &bspm.BspwmReconnected{
+					Attempts: int32(ev.Attempts),
+				},
+			},
+		},
+		desktopID: bspc.NilID,
+	}
+}
+
+// toScratchpadStateResponse doesn't refer to any desktop, since scratchpads follow the focus around.
+func toScratchpadStateResponse(st scratchpad.State) response {
+	return response{
+		msg: &bspm.SubscribeResponse{
+			Topic: bspm.Topic_TOPIC_SCRATCHPAD_STATE_CHANGED,
+			Payload: &bspm.SubscribeResponse_ScratchpadState{
+				ScratchpadState: &bspm.ScratchpadState{
+					Name:      st.Name,
+					ClassName: st.ClassName,
+					Command:   st.Command,
+					NodeId:    uint32(st.NodeID),
+					IsVisible: st.IsVisible,
+				},
+			},
+		},
+		desktopID: bspc.NilID,
+	}
+}
+
+func toLayoutStateResponse(st layout.State) response {
+	return response{
+		msg: &bspm.SubscribeResponse{
+			Topic: bspm.Topic_TOPIC_LAYOUT_CHANGED,
+			Payload: &bspm.SubscribeResponse_LayoutState{
+				LayoutState: &bspm.LayoutState{
+					DesktopId:   uint32(st.DesktopID),
+					Name:        st.Name,
+					MasterCount: int32(st.Params.MasterCount),
+					Ratio:       st.Params.Ratio,
+				},
+			},
+		},
+		desktopID: st.DesktopID,
+	}
+}
+
+func toTabbedStateResponse(st tabbed.State) response {
+	containers := make([]*bspm.TabbedContainer, 0, len(st.Containers))
+	for _, c := range st.Containers {
+		tabs := make([]*bspm.Tab, 0, len(c.Tabs))
+		for _, tab := range c.Tabs {
+			tabs = append(tabs, &bspm.Tab{
+				NodeId:       uint32(tab.NodeID),
+				ClassName:    tab.ClassName,
+				InstanceName: tab.InstanceName,
+				Title:        tab.Title,
+				IsSelected:   tab.IsSelected,
+			})
+		}
+
+		containers = append(containers, &bspm.TabbedContainer{Tabs: tabs})
+	}
+
+	return response{
+		msg: &bspm.SubscribeResponse{
+			Topic: bspm.Topic_TOPIC_TABBED_CHANGED,
+			Payload: &bspm.SubscribeResponse_TabbedState{
+				TabbedState: &bspm.TabbedState{
+					DesktopId:  uint32(st.DesktopID),
+					Containers: containers,
+				},
+			},
+		},
+		desktopID: st.DesktopID,
+	}
+}
+
+// toMinimizedStateResponse doesn't refer to any desktop, since the minimised windows of every desktop are listed
+// together.
+func toMinimizedStateResponse(st minimize.State) response {
+	windows := make([]*bspm.MinimizedWindow, 0, len(st.Windows))
+	for _, w := range st.Windows {
+		windows = append(windows, &bspm.MinimizedWindow{
+			NodeId:       uint32(w.NodeID),
+			DesktopId:    uint32(w.DesktopID),
+			ClassName:    w.ClassName,
+			InstanceName: w.InstanceName,
+			Title:        w.Title,
+		})
+	}
+
+	return response{
+		msg: &bspm.SubscribeResponse{
+			Topic: bspm.Topic_TOPIC_MINIMIZED_CHANGED,
+			Payload: &bspm.SubscribeResponse_MinimizedState{
+				MinimizedState: &bspm.MinimizedState{
+					Windows: windows,
+				},
+			},
+		},
+		desktopID: bspc.NilID,
+	}
+}
+
+func toMonocleState(ev state.Event) *bspm.MonocleState {
+	selectedNodeID := uint32(bspc.NilID)
+	if ev.State.SelectedNodeID != nil {
+		selectedNodeID = uint32(*ev.State.SelectedNodeID)
+	}
+
+	hiddenNodeIDs := make([]uint32, 0, len(ev.State.HiddenNodeIDs))
+	for _, id := range ev.State.HiddenNodeIDs {
+		hiddenNodeIDs = append(hiddenNodeIDs, uint32(id))
+	}
+
+	return &bspm.MonocleState{
+		DesktopId:      uint32(ev.DesktopID),
+		SelectedNodeId: selectedNodeID,
+		HiddenNodeIds:  hiddenNodeIDs,
+	}
+}
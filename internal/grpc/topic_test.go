@@ -0,0 +1,39 @@
+package grpc_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/diogox/bspm/internal/grpc"
+	"github.com/diogox/bspm/internal/grpc/bspm"
+)
+
+func TestTopicsMatching(t *testing.T) {
+	t.Run("should return topic matching name", func(t *testing.T) {
+		assert.Equal(t, []bspm.Topic{bspm.Topic_TOPIC_MONOCLE_ENABLED}, grpc.TopicsMatching("monocle/enabled"))
+	})
+	t.Run("should return all topics matching pattern", func(t *testing.T) {
+		assert.Equal(t, []bspm.Topic{
+			bspm.Topic_TOPIC_MONOCLE_ENABLED,
+			bspm.Topic_TOPIC_MONOCLE_DISABLED,
+			bspm.Topic_TOPIC_MONOCLE_STATE_CHANGED,
+			bspm.Topic_TOPIC_MONOCLE_DESKTOP_FOCUS_CHANGED,
+		}, grpc.TopicsMatching("monocle/*"))
+	})
+	t.Run("should return topics of every scratchpad", func(t *testing.T) {
+		assert.Equal(t, []bspm.Topic{bspm.Topic_TOPIC_SCRATCHPAD_STATE_CHANGED}, grpc.TopicsMatching("scratchpad/*"))
+	})
+	t.Run("should return topics of every desktop's layout", func(t *testing.T) {
+		assert.Equal(t, []bspm.Topic{bspm.Topic_TOPIC_LAYOUT_CHANGED}, grpc.TopicsMatching("layout/*"))
+	})
+	t.Run("should return topics of every desktop's tabbed containers", func(t *testing.T) {
+		assert.Equal(t, []bspm.Topic{bspm.Topic_TOPIC_TABBED_CHANGED}, grpc.TopicsMatching("tabbed/*"))
+	})
+	t.Run("should return topic of minimised windows", func(t *testing.T) {
+		assert.Equal(t, []bspm.Topic{bspm.Topic_TOPIC_MINIMIZED_CHANGED}, grpc.TopicsMatching("minimized/changed"))
+	})
+	t.Run("should return nothing for unknown topic", func(t *testing.T) {
+		assert.Empty(t, grpc.TopicsMatching("invalid"))
+	})
+}
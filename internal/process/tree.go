@@ -0,0 +1,64 @@
+package process
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidStat = errors.New("invalid process stat")
+
+type (
+	// Tree is the hierarchy of the running processes.
+	Tree interface {
+		Parent(pid int) (int, error)
+	}
+
+	tree struct {
+		procDir string
+	}
+)
+
+// NewTree returns the process tree of the system, read from /proc.
+func NewTree() Tree {
+	return NewTreeAt("/proc")
+}
+
+// NewTreeAt returns the process tree read from the given directory, laid out like /proc.
+func NewTreeAt(procDir string) Tree {
+	return tree{
+		procDir: procDir,
+	}
+}
+
+// Parent returns the id of the process' parent, from /proc/<pid>/stat.
+func (t tree) Parent(pid int) (int, error) {
+	bb, err := os.ReadFile(filepath.Join(t.procDir, strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read stat of process %d: %w", pid, err)
+	}
+
+	// The second field is the executable's name, in parentheses, and it can have spaces and parentheses of its own.
+	// The fields after it are "state ppid ...".
+	stat := string(bb)
+
+	end := strings.LastIndex(stat, ")")
+	if end == -1 {
+		return 0, fmt.Errorf("%w: process %d", ErrInvalidStat, pid)
+	}
+
+	fields := strings.Fields(stat[end+1:])
+	if len(fields) < 2 {
+		return 0, fmt.Errorf("%w: process %d", ErrInvalidStat, pid)
+	}
+
+	ppid, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, fmt.Errorf("%w: process %d: %v", ErrInvalidStat, pid, err)
+	}
+
+	return ppid, nil
+}
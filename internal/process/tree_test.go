@@ -0,0 +1,65 @@
+package process_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/diogox/bspm/internal/process"
+)
+
+func writeStat(t *testing.T, procDir string, pid int, stat string) {
+	dir := filepath.Join(procDir, strconv.Itoa(pid))
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "stat"), []byte(stat), 0o644))
+}
+
+func TestTree_Parent(t *testing.T) {
+	t.Run("should return the parent's pid", func(t *testing.T) {
+		tt := []struct {
+			name string
+			stat string
+		}{
+			{
+				name: "with a plain name",
+				stat: "1234 (mpv) S 1000 1234 1000 34816 1234 4194304 0 0",
+			},
+			{
+				name: "with spaces and parentheses in the name",
+				stat: "1234 (Web Content (x)) S 1000 1234 1000 34816 1234 4194304 0 0",
+			},
+		}
+
+		for _, tc := range tt {
+			t.Run(tc.name, func(t *testing.T) {
+				procDir := t.TempDir()
+				writeStat(t, procDir, 1234, tc.stat)
+
+				ppid, err := process.NewTreeAt(procDir).Parent(1234)
+				require.NoError(t, err)
+
+				assert.Equal(t, 1000, ppid)
+			})
+		}
+	})
+
+	t.Run("should fail when the process doesn't exist", func(t *testing.T) {
+		_, err := process.NewTreeAt(t.TempDir()).Parent(1234)
+		assert.Error(t, err)
+	})
+
+	t.Run("should fail when the stat is invalid", func(t *testing.T) {
+		procDir := t.TempDir()
+		writeStat(t, procDir, 1234, "1234 mpv")
+
+		_, err := process.NewTreeAt(procDir).Parent(1234)
+		require.Error(t, err)
+
+		assert.True(t, errors.Is(err, process.ErrInvalidStat))
+	})
+}
@@ -2,44 +2,317 @@
 
 package subscription
 
-import "sync"
-
-type Topic string
+import (
+	"context"
+	"sort"
+	"sync"
+)
 
 type (
+	// Manager publishes payloads to the subscribers of each topic. Prefer going through a Topic, which is type-safe,
+	// and only use it directly when the topic isn't known at compile time (e.g. when a client picks it).
 	Manager interface {
-		Publish(topic Topic, payload interface{})
-		Subscribe(topic Topic) chan interface{}
+		Publish(topic Name, payload interface{})
+		PublishRetained(topic Name, payload interface{})
+		Subscribe(ctx context.Context, topic Name, opts ...Option) chan interface{}
+		Unsubscribe(topic Name, sub chan interface{})
+		Metrics() Metrics
+	}
+
+	// Metrics holds the delivery metrics of each topic.
+	Metrics map[Name]TopicMetrics
+
+	TopicMetrics struct {
+		// Dropped is the number of messages subscribers missed because they weren't keeping up.
+		Dropped uint64
+		// Disconnected is the number of subscribers disconnected because they weren't keeping up.
+		Disconnected uint64
 	}
 
 	manager struct {
-		rwMutex       *sync.RWMutex
-		subscriptions map[Topic][]chan interface{}
+		rwMutex *sync.RWMutex
+
+		// subscriptions are indexed by the name, or pattern, they were subscribed with.
+		subscriptions map[Name][]*subscriber
+		patterns      map[Name]struct{}
+		retained      map[Name]interface{}
+
+		// subscribers is guarded by its own lock, since the main one might be held by a publisher.
+		subscribersMutex *sync.Mutex
+		subscribers      map[chan interface{}]*subscriber
+
+		metricsMutex *sync.Mutex
+		metrics      Metrics
+	}
+
+	subscriber struct {
+		ch     chan interface{}
+		policy Policy
+
+		// mutex serialises deliveries, so concurrent publishers don't drop each other's messages.
+		mutex *sync.Mutex
+
+		// done is closed when the subscription is cancelled, so that publishers stop delivering to it
+		// before the lock is acquired to remove it.
+		done       chan struct{}
+		cancelOnce *sync.Once
 	}
+
+	delivery int
+)
+
+const (
+	delivered delivery = iota
+	dropped
+	disconnected
 )
 
 func NewManager() *manager {
 	return &manager{
-		rwMutex:       &sync.RWMutex{},
-		subscriptions: make(map[Topic][]chan interface{}),
+		rwMutex:          &sync.RWMutex{},
+		subscriptions:    make(map[Name][]*subscriber),
+		patterns:         make(map[Name]struct{}),
+		retained:         make(map[Name]interface{}),
+		subscribersMutex: &sync.Mutex{},
+		subscribers:      make(map[chan interface{}]*subscriber),
+		metricsMutex:     &sync.Mutex{},
+		metrics:          make(Metrics),
+	}
+}
+
+func newSubscriber(ch chan interface{}, policy Policy) *subscriber {
+	return &subscriber{
+		ch:         ch,
+		policy:     policy,
+		mutex:      &sync.Mutex{},
+		done:       make(chan struct{}),
+		cancelOnce: &sync.Once{},
 	}
 }
 
-func (m manager) Publish(topic Topic, payload interface{}) {
+// Publish delivers the payload to every subscriber of the topic, including those subscribed with a matching pattern.
+// It never blocks on slow subscribers, which are handled according to their policy instead.
+func (m *manager) Publish(topic Name, payload interface{}) {
 	m.rwMutex.RLock()
 	defer m.rwMutex.RUnlock()
 
-	for _, subs := range m.subscriptions[topic] {
-		subs <- payload
+	m.publish(topic, payload)
+}
+
+// PublishRetained publishes the payload, and keeps it to deliver to anyone subscribing to the topic later on.
+// Only the last retained payload of each topic is kept.
+func (m *manager) PublishRetained(topic Name, payload interface{}) {
+	m.rwMutex.Lock()
+	defer m.rwMutex.Unlock()
+
+	m.retained[topic] = payload
+	m.publish(topic, payload)
+}
+
+func (m *manager) publish(topic Name, payload interface{}) {
+	m.deliver(topic, topic, payload)
+
+	for pattern := range m.patterns {
+		if Match(pattern, topic) {
+			m.deliver(pattern, topic, payload)
+		}
+	}
+}
+
+// deliver sends the payload, published to the topic, to everyone subscribed with the given name or pattern.
+func (m *manager) deliver(subscribedTo Name, topic Name, payload interface{}) {
+	for _, sub := range m.subscriptions[subscribedTo] {
+		m.deliverTo(sub, subscribedTo, topic, payload)
 	}
 }
 
-func (m *manager) Subscribe(topic Topic) chan interface{} {
+func (m *manager) deliverTo(sub *subscriber, subscribedTo Name, topic Name, payload interface{}) {
+	switch sub.deliver(payload) {
+	case delivered:
+	case dropped:
+		m.record(topic, TopicMetrics{Dropped: 1})
+	case disconnected:
+		m.record(topic, TopicMetrics{Dropped: 1, Disconnected: 1})
+
+		// The subscription can only be removed once publishers release the lock.
+		sub.cancel()
+		go m.Unsubscribe(subscribedTo, sub.ch)
+	}
+}
+
+// Subscribe returns a channel with the payloads published to the topic, starting with its retained payload, if any.
+// The topic can also be a pattern (see Match), in which case the retained payloads of every matching topic are sent.
+// The subscription is cancelled, and the channel closed, when the context is done.
+func (m *manager) Subscribe(ctx context.Context, topic Name, opts ...Option) chan interface{} {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	bufferSize := o.bufferSize
+	if o.policy == PolicyCoalesce {
+		bufferSize = 1
+	}
+
 	m.rwMutex.Lock()
 	defer m.rwMutex.Unlock()
 
-	sub := make(chan interface{}, 1)
+	sub := newSubscriber(make(chan interface{}, bufferSize), o.policy)
 	m.subscriptions[topic] = append(m.subscriptions[topic], sub)
 
-	return sub
+	if IsPattern(topic) {
+		m.patterns[topic] = struct{}{}
+	}
+
+	for _, retainedTopic := range m.retainedTopics(topic) {
+		m.deliverTo(sub, topic, retainedTopic, m.retained[retainedTopic])
+	}
+
+	m.subscribersMutex.Lock()
+	m.subscribers[sub.ch] = sub
+	m.subscribersMutex.Unlock()
+
+	// Contexts that can't be cancelled don't need to be watched.
+	if ctx.Done() != nil {
+		go func() {
+			select {
+			case <-ctx.Done():
+				m.Unsubscribe(topic, sub.ch)
+			case <-sub.done:
+			}
+		}()
+	}
+
+	return sub.ch
+}
+
+// Unsubscribe cancels the subscription to the topic that returned the given channel, and closes it.
+func (m *manager) Unsubscribe(topic Name, ch chan interface{}) {
+	m.subscribersMutex.Lock()
+	sub, ok := m.subscribers[ch]
+	delete(m.subscribers, ch)
+	m.subscribersMutex.Unlock()
+
+	if !ok {
+		return
+	}
+
+	sub.cancel()
+
+	m.rwMutex.Lock()
+	defer m.rwMutex.Unlock()
+
+	subs := m.subscriptions[topic]
+	for i, s := range subs {
+		if s != sub {
+			continue
+		}
+
+		m.subscriptions[topic] = append(subs[:i:i], subs[i+1:]...)
+		close(sub.ch)
+
+		break
+	}
+
+	if len(m.subscriptions[topic]) == 0 {
+		delete(m.subscriptions, topic)
+		delete(m.patterns, topic)
+	}
+}
+
+// retainedTopics returns the topics with a retained payload that match the given topic, sorted by name.
+func (m *manager) retainedTopics(topic Name) []Name {
+	if !IsPattern(topic) {
+		if _, ok := m.retained[topic]; ok {
+			return []Name{topic}
+		}
+
+		return nil
+	}
+
+	var topics []Name
+	for retainedTopic := range m.retained {
+		if Match(topic, retainedTopic) {
+			topics = append(topics, retainedTopic)
+		}
+	}
+
+	sort.Slice(topics, func(i, j int) bool {
+		return topics[i] < topics[j]
+	})
+
+	return topics
+}
+
+// Metrics returns a snapshot of the delivery metrics of each topic.
+func (m *manager) Metrics() Metrics {
+	m.metricsMutex.Lock()
+	defer m.metricsMutex.Unlock()
+
+	metrics := make(Metrics, len(m.metrics))
+	for topic, tm := range m.metrics {
+		metrics[topic] = tm
+	}
+
+	return metrics
+}
+
+func (m *manager) record(topic Name, delta TopicMetrics) {
+	m.metricsMutex.Lock()
+	defer m.metricsMutex.Unlock()
+
+	tm := m.metrics[topic]
+	tm.Dropped += delta.Dropped
+	tm.Disconnected += delta.Disconnected
+	m.metrics[topic] = tm
+}
+
+// deliver sends the payload to the subscriber without blocking, applying its policy if it's full.
+func (s *subscriber) deliver(payload interface{}) delivery {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	select {
+	case <-s.done:
+		// Already being removed.
+		return delivered
+	default:
+	}
+
+	select {
+	case s.ch <- payload:
+		return delivered
+	default:
+	}
+
+	if s.policy == PolicyDisconnect {
+		return disconnected
+	}
+
+	// Make room for the new payload. The subscriber might have received the oldest one in the meantime,
+	// in which case nothing is dropped.
+	var isOldestDropped bool
+	select {
+	case <-s.ch:
+		isOldestDropped = true
+	default:
+	}
+
+	select {
+	case s.ch <- payload:
+		if isOldestDropped {
+			return dropped
+		}
+
+		return delivered
+	default:
+		// Unbuffered channel, with nobody receiving.
+		return dropped
+	}
+}
+
+func (s *subscriber) cancel() {
+	s.cancelOnce.Do(func() {
+		close(s.done)
+	})
 }
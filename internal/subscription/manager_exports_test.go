@@ -1,6 +1,32 @@
 package subscription
 
-func (m manager) WithSubs(newSubs map[Topic][]chan interface{}) *manager {
-	m.subscriptions = newSubs
+// WithSubs replaces the manager's subscriptions with the given channels, so they can be inspected.
+func (m manager) WithSubs(newSubs map[Name][]chan interface{}) *manager {
+	m.subscriptions = make(map[Name][]*subscriber, len(newSubs))
+
+	for topic, chs := range newSubs {
+		for _, ch := range chs {
+			sub := newSubscriber(ch, PolicyDropOldest)
+
+			m.subscriptions[topic] = append(m.subscriptions[topic], sub)
+			m.subscribers[ch] = sub
+		}
+	}
+
 	return &m
 }
+
+// Subs returns the channels currently subscribed to each topic.
+func (m manager) Subs() map[Name][]chan interface{} {
+	m.rwMutex.RLock()
+	defer m.rwMutex.RUnlock()
+
+	subs := make(map[Name][]chan interface{}, len(m.subscriptions))
+	for topic, ss := range m.subscriptions {
+		for _, sub := range ss {
+			subs[topic] = append(subs[topic], sub.ch)
+		}
+	}
+
+	return subs
+}
@@ -1,7 +1,9 @@
 package subscription_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -9,13 +11,13 @@ import (
 	"github.com/diogox/bspm/internal/subscription"
 )
 
-const testTopic subscription.Topic = "test"
+const testTopic subscription.Name = "test"
 
 func TestManager_Publish(t *testing.T) {
 	t.Run("should publish to subscribers", func(t *testing.T) {
 		var (
 			sub  = make(chan interface{}, 1)
-			subs = map[subscription.Topic][]chan interface{}{
+			subs = map[subscription.Name][]chan interface{}{
 				testTopic: {sub},
 			}
 			expected = "expected-string"
@@ -25,15 +27,158 @@ func TestManager_Publish(t *testing.T) {
 
 		assert.Equal(t, expected, <-sub)
 	})
+	t.Run("should drop the oldest message when subscriber is full", func(t *testing.T) {
+		m := subscription.NewManager()
+		sub := m.Subscribe(context.Background(), testTopic, subscription.WithBufferSize(2))
+
+		m.Publish(testTopic, 1)
+		m.Publish(testTopic, 2)
+		m.Publish(testTopic, 3)
+
+		assert.Equal(t, 2, <-sub)
+		assert.Equal(t, 3, <-sub)
+		assert.Equal(t, subscription.Metrics{testTopic: {Dropped: 1}}, m.Metrics())
+	})
+	t.Run("should only keep the latest message when coalescing", func(t *testing.T) {
+		m := subscription.NewManager()
+		sub := m.Subscribe(context.Background(), testTopic,
+			subscription.WithPolicy(subscription.PolicyCoalesce),
+			subscription.WithBufferSize(5), // Ignored
+		)
+
+		m.Publish(testTopic, 1)
+		m.Publish(testTopic, 2)
+		m.Publish(testTopic, 3)
+
+		assert.Equal(t, 3, <-sub)
+		assert.Equal(t, subscription.Metrics{testTopic: {Dropped: 2}}, m.Metrics())
+	})
+	t.Run("should disconnect slow subscriber", func(t *testing.T) {
+		m := subscription.NewManager()
+		sub := m.Subscribe(context.Background(), testTopic,
+			subscription.WithPolicy(subscription.PolicyDisconnect),
+			subscription.WithBufferSize(1),
+		)
+
+		m.Publish(testTopic, 1)
+		m.Publish(testTopic, 2)
+		m.Publish(testTopic, 3)
+
+		assert.Equal(t, 1, <-sub)
+
+		select {
+		case _, ok := <-sub:
+			assert.False(t, ok)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for subscription to be closed")
+		}
+
+		assert.Empty(t, m.Subs())
+		assert.Equal(t, subscription.Metrics{testTopic: {Dropped: 1, Disconnected: 1}}, m.Metrics())
+	})
+}
+
+func TestManager_PublishRetained(t *testing.T) {
+	t.Run("should deliver retained payload to new subscribers", func(t *testing.T) {
+		m := subscription.NewManager()
+		m.PublishRetained(testTopic, 1)
+		m.PublishRetained(testTopic, 2)
+
+		sub := m.Subscribe(context.Background(), testTopic)
+		assert.Equal(t, 2, <-sub)
+	})
+	t.Run("should deliver retained payloads of every matching topic to pattern subscribers", func(t *testing.T) {
+		m := subscription.NewManager()
+		m.PublishRetained("monocle/enabled", 1)
+		m.PublishRetained("monocle/disabled", 2)
+		m.PublishRetained("desktop/1/monocle/enabled", 3)
+
+		sub := m.Subscribe(context.Background(), "monocle/*")
+		assert.Equal(t, 2, <-sub)
+		assert.Equal(t, 1, <-sub)
+		assert.Empty(t, sub)
+	})
 }
 
 func TestManager_Subscribe(t *testing.T) {
+	t.Run("should receive payloads of topics matching pattern", func(t *testing.T) {
+		m := subscription.NewManager()
+		sub := m.Subscribe(context.Background(), "desktop/1/*")
+
+		m.Publish("desktop/1/monocle/enabled", 1)
+		m.Publish("desktop/2/monocle/enabled", 2)
+		m.Publish("monocle/enabled", 3)
+		m.Publish("desktop/1/monocle/disabled", 4)
+
+		assert.Equal(t, 1, <-sub)
+		assert.Equal(t, 4, <-sub)
+		assert.Empty(t, sub)
+	})
 	t.Run("should subscribe to topic", func(t *testing.T) {
-		subs := map[subscription.Topic][]chan interface{}{}
-		sub := subscription.NewManager().WithSubs(subs).Subscribe(testTopic)
+		m := subscription.NewManager()
+		sub := m.Subscribe(context.Background(), testTopic)
 
+		subs := m.Subs()
 		require.NotEmpty(t, subs)
 		require.NotEmpty(t, subs[testTopic])
 		assert.Equal(t, subs[testTopic][0], sub)
 	})
+	t.Run("should unsubscribe when context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		m := subscription.NewManager()
+		sub := m.Subscribe(ctx, testTopic)
+
+		cancel()
+
+		select {
+		case _, ok := <-sub:
+			assert.False(t, ok)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for subscription to be closed")
+		}
+
+		assert.Empty(t, m.Subs())
+	})
+}
+
+func TestManager_Unsubscribe(t *testing.T) {
+	t.Run("should remove subscription and close its channel", func(t *testing.T) {
+		var (
+			sub   = make(chan interface{}, 1)
+			other = make(chan interface{}, 1)
+			subs  = map[subscription.Name][]chan interface{}{
+				testTopic: {sub, other},
+			}
+		)
+
+		m := subscription.NewManager().WithSubs(subs)
+		m.Unsubscribe(testTopic, sub)
+
+		_, ok := <-sub
+		assert.False(t, ok)
+
+		assert.Equal(t, map[subscription.Name][]chan interface{}{testTopic: {other}}, m.Subs())
+	})
+	t.Run("should not block publishers waiting on the subscription", func(t *testing.T) {
+		sub := make(chan interface{}) // Never ready to receive
+
+		m := subscription.NewManager().WithSubs(map[subscription.Name][]chan interface{}{
+			testTopic: {sub},
+		})
+
+		published := make(chan struct{})
+		go func() {
+			m.Publish(testTopic, "payload")
+			close(published)
+		}()
+
+		m.Unsubscribe(testTopic, sub)
+
+		select {
+		case <-published:
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for publisher")
+		}
+	})
 }
@@ -0,0 +1,64 @@
+package subscription
+
+const defaultBufferSize = 16
+
+// Policy decides what happens to a message published to a subscriber whose buffer is full.
+type Policy int
+
+const (
+	// PolicyDropOldest drops the oldest buffered message to make room for the new one.
+	PolicyDropOldest Policy = iota
+
+	// PolicyCoalesce only ever keeps the latest message. Useful for subscribers that only care about the current state.
+	PolicyCoalesce
+
+	// PolicyDisconnect cancels the subscription, closing its channel, so the subscriber can tell it fell behind.
+	PolicyDisconnect
+)
+
+func (p Policy) String() string {
+	switch p {
+	case PolicyDropOldest:
+		return "drop_oldest"
+	case PolicyCoalesce:
+		return "coalesce"
+	case PolicyDisconnect:
+		return "disconnect"
+	default:
+		return "unknown"
+	}
+}
+
+type (
+	// Option configures a subscription.
+	Option func(opts *options)
+
+	options struct {
+		policy     Policy
+		bufferSize int
+	}
+)
+
+func defaultOptions() options {
+	return options{
+		policy:     PolicyDropOldest,
+		bufferSize: defaultBufferSize,
+	}
+}
+
+// WithPolicy sets what happens when the subscriber falls behind. Defaults to PolicyDropOldest.
+func WithPolicy(policy Policy) Option {
+	return func(opts *options) {
+		opts.policy = policy
+	}
+}
+
+// WithBufferSize sets how many messages are buffered for the subscriber. It's ignored by PolicyCoalesce,
+// which always buffers a single message.
+func WithBufferSize(size int) Option {
+	return func(opts *options) {
+		if size > 0 {
+			opts.bufferSize = size
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package subscription
+
+import (
+	"context"
+	"strings"
+)
+
+const (
+	// Separator separates the segments of hierarchical topic names (e.g. monocle/enabled).
+	Separator = "/"
+
+	// Wildcard matches any segment when subscribing. As the last segment, it matches all the remaining ones.
+	Wildcard = "*"
+)
+
+// Name identifies a topic in the Manager, regardless of its payload type.
+type Name string
+
+// IsPattern returns true if the name has wildcard segments.
+func IsPattern(name Name) bool {
+	for _, segment := range strings.Split(string(name), Separator) {
+		if segment == Wildcard {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Match returns true if the topic name matches the pattern. A "*" segment matches exactly one segment,
+// unless it's the last one, which matches one or more. For example, monocle/* matches monocle/enabled,
+// and desktop/*/monocle/enabled matches the monocle/enabled topic of every desktop.
+func Match(pattern Name, name Name) bool {
+	var (
+		patternSegments = strings.Split(string(pattern), Separator)
+		nameSegments    = strings.Split(string(name), Separator)
+	)
+
+	for i, segment := range patternSegments {
+		if i == len(nameSegments) {
+			return false
+		}
+
+		isLast := i == len(patternSegments)-1
+		if segment == Wildcard && isLast {
+			return true
+		}
+
+		if segment != Wildcard && segment != nameSegments[i] {
+			return false
+		}
+	}
+
+	return len(patternSegments) == len(nameSegments)
+}
+
+// Topic is a topic whose payloads are of type T. Publishing and subscribing through it, instead of through the Manager,
+// guarantees at compile time that subscribers only ever receive payloads of the type they expect.
+type Topic[T any] string
+
+func (t Topic[T]) Name() Name {
+	return Name(t)
+}
+
+// Under returns the same topic, nested under the given segments (e.g. desktop/<id>/monocle/enabled).
+func (t Topic[T]) Under(segments ...string) Topic[T] {
+	return Topic[T](strings.Join(append(segments, string(t)), Separator))
+}
+
+// Publish publishes the payload to the topic's subscribers.
+func (t Topic[T]) Publish(m Manager, payload T) {
+	m.Publish(t.Name(), payload)
+}
+
+// PublishRetained publishes the payload to the topic's subscribers, and keeps it for future ones.
+func (t Topic[T]) PublishRetained(m Manager, payload T) {
+	m.PublishRetained(t.Name(), payload)
+}
+
+// Subscribe returns a channel with the payloads published to the topic.
+// The subscription is cancelled, and the channel closed, when the context is done.
+func (t Topic[T]) Subscribe(ctx context.Context, m Manager, opts ...Option) chan T {
+	var (
+		payloadCh = m.Subscribe(ctx, t.Name(), opts...)
+		resCh     = make(chan T)
+	)
+
+	// The manager's policies still apply, since payloads are only taken from its channel as fast as they're received.
+	go func() {
+		defer close(resCh)
+
+		for payload := range payloadCh {
+			p, ok := payload.(T)
+			if !ok {
+				// Published without going through the topic.
+				continue
+			}
+
+			select {
+			case resCh <- p:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return resCh
+}
@@ -0,0 +1,67 @@
+package subscription_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/diogox/bspm/internal/subscription"
+)
+
+const testTypedTopic subscription.Topic[int] = "typed_test"
+
+func TestTopic_Subscribe(t *testing.T) {
+	t.Run("should only receive payloads of the topic's type", func(t *testing.T) {
+		m := subscription.NewManager()
+		sub := testTypedTopic.Subscribe(context.Background(), m)
+
+		m.Publish(testTypedTopic.Name(), "not an int")
+		testTypedTopic.Publish(m, 1)
+
+		select {
+		case got := <-sub:
+			assert.Equal(t, 1, got)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for payload")
+		}
+	})
+	t.Run("should close channel when context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		m := subscription.NewManager()
+		sub := testTypedTopic.Subscribe(ctx, m)
+
+		cancel()
+
+		select {
+		case _, ok := <-sub:
+			assert.False(t, ok)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for subscription to be closed")
+		}
+	})
+}
+
+func TestMatch(t *testing.T) {
+	for _, tc := range []struct {
+		pattern  subscription.Name
+		name     subscription.Name
+		expected bool
+	}{
+		{pattern: "monocle/enabled", name: "monocle/enabled", expected: true},
+		{pattern: "monocle/enabled", name: "monocle/disabled", expected: false},
+		{pattern: "monocle/*", name: "monocle/enabled", expected: true},
+		{pattern: "monocle/*", name: "monocle", expected: false},
+		{pattern: "monocle/*", name: "bspwm/event", expected: false},
+		{pattern: "desktop/1/*", name: "desktop/1/monocle/enabled", expected: true},
+		{pattern: "desktop/*/monocle/enabled", name: "desktop/1/monocle/enabled", expected: true},
+		{pattern: "desktop/*/monocle/enabled", name: "desktop/1/monocle/disabled", expected: false},
+		{pattern: "desktop/*/monocle", name: "desktop/1/monocle/enabled", expected: false},
+	} {
+		t.Run(string(tc.pattern)+" "+string(tc.name), func(t *testing.T) {
+			assert.Equal(t, tc.expected, subscription.Match(tc.pattern, tc.name))
+		})
+	}
+}
@@ -0,0 +1,112 @@
+//go:generate mockgen -package x11 -destination ./properties_mock.go -self_package github.com/diogox/bspm/internal/x11 github.com/diogox/bspm/internal/x11 Properties
+
+package x11
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/xproto"
+	"github.com/diogox/bspc-go"
+)
+
+// maxPropertyLength is the maximum length, in 32-bit multiples, of the property values we read.
+const maxPropertyLength = 1024
+
+var ErrPropertyNotFound = errors.New("window property not found")
+
+type (
+	// Properties reads X11 properties that bspwm doesn't expose, for the windows it manages.
+	// Leaf node ids in bspwm are the ids of their X11 windows.
+	Properties interface {
+		Title(windowID bspc.ID) (string, error)
+		PID(windowID bspc.ID) (int, error)
+	}
+
+	properties struct {
+		conn *xgb.Conn
+	}
+)
+
+// NewProperties connects to the X server in the $DISPLAY environment variable.
+func NewProperties() (Properties, error) {
+	conn, err := xgb.NewConn()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to X server: %w", err)
+	}
+
+	return properties{
+		conn: conn,
+	}, nil
+}
+
+// Title returns the window's title, preferring the EWMH _NET_WM_NAME over the legacy WM_NAME.
+func (p properties) Title(windowID bspc.ID) (string, error) {
+	title, err := p.stringProperty(windowID, "_NET_WM_NAME")
+	if err == nil {
+		return title, nil
+	}
+
+	if !errors.Is(err, ErrPropertyNotFound) {
+		return "", err
+	}
+
+	return p.stringProperty(windowID, "WM_NAME")
+}
+
+// PID returns the id of the process the window belongs to, from its _NET_WM_PID property.
+// Not every program sets it.
+func (p properties) PID(windowID bspc.ID) (int, error) {
+	const name = "_NET_WM_PID"
+
+	reply, err := p.property(windowID, name)
+	if err != nil {
+		return 0, err
+	}
+
+	if reply.Format != 32 || len(reply.Value) < 4 {
+		return 0, fmt.Errorf("invalid %s property", name)
+	}
+
+	return int(xgb.Get32(reply.Value)), nil
+}
+
+func (p properties) stringProperty(windowID bspc.ID, name string) (string, error) {
+	reply, err := p.property(windowID, name)
+	if err != nil {
+		return "", err
+	}
+
+	return string(reply.Value), nil
+}
+
+func (p properties) property(windowID bspc.ID, name string) (*xproto.GetPropertyReply, error) {
+	atom, err := xproto.InternAtom(p.conn, true, uint16(len(name)), name).Reply()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s atom: %w", name, err)
+	}
+
+	if atom.Atom == xproto.AtomNone {
+		return nil, fmt.Errorf("%w: %s", ErrPropertyNotFound, name)
+	}
+
+	reply, err := xproto.GetProperty(
+		p.conn,
+		false,
+		xproto.Window(windowID),
+		atom.Atom,
+		xproto.GetPropertyTypeAny,
+		0,
+		maxPropertyLength,
+	).Reply()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s property: %w", name, err)
+	}
+
+	if reply.Format == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrPropertyNotFound, name)
+	}
+
+	return reply, nil
+}